@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"charm.land/log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+// stateCmd groups the commands that move the local notification state
+// (done notifications and bookmarks) between machines
+var stateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Export or import the local notification state",
+	Long: `Export or import the notifications marked as done and the bookmarked notifications,
+which are stored locally, to carry them between machines.
+To keep them in sync automatically, set stateSync.dir in the configuration to a synced folder.`,
+}
+
+var stateExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export the local notification state to a file, or stdout",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.SetLevel(log.ErrorLevel)
+		w := cmd.OutOrStdout()
+		if len(args) == 1 && args[0] != "-" {
+			f, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		return data.WriteStateSnapshot(w, data.ExportState())
+	},
+}
+
+var stateImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Merge an exported notification state into the local one",
+	Long: `Merge an exported notification state into the local one. A notification marked as done on both
+machines keeps the latest timestamp, and bookmarks are added to the local ones.
+Pass - to read the state from stdin.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.SetLevel(log.ErrorLevel)
+		var r io.Reader = cmd.InOrStdin()
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+
+		snapshot, err := data.ReadStateSnapshot(r)
+		if err != nil {
+			return err
+		}
+		res := data.ImportState(snapshot)
+		if err := data.FlushState(); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Imported %d done notifications and %d bookmarks\n",
+			res.Done, res.Bookmarks)
+		return nil
+	},
+}
+
+func init() {
+	stateCmd.AddCommand(stateExportCmd, stateImportCmd)
+	rootCmd.AddCommand(stateCmd)
+}
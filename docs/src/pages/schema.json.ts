@@ -176,6 +176,44 @@ export function GET() {
             "Set this to `false` to disable [Smart Filtering](/getting-started/smartfiltering) at `gh-dash` launch.\n",
           type: "boolean",
         },
+        notificationRules: {
+          title: "Notification Rules",
+          description:
+            "Triages notifications automatically. Each fetched notification gets the action of the first rule it matches.",
+          type: "array",
+          items: {
+            type: "object",
+            required: ["action"],
+            properties: {
+              name: {
+                title: "Rule Name",
+                description: "Identifies the rule in the log.",
+                type: "string",
+              },
+              match: {
+                title: "Rule Conditions",
+                description:
+                  "All conditions must match. List conditions match if any of their values match.",
+                type: "object",
+                properties: {
+                  reason: { type: "array", items: { type: "string" } },
+                  repo: { type: "array", items: { type: "string" } },
+                  subjectType: { type: "array", items: { type: "string" } },
+                  title: { type: "string", format: "regex" },
+                  author: { type: "array", items: { type: "string" } },
+                  state: {
+                    type: "array",
+                    items: { enum: ["open", "closed", "merged", "draft"] },
+                  },
+                },
+              },
+              action: {
+                title: "Rule Action",
+                enum: ["done", "read", "bookmark", "unsubscribe"],
+              },
+            },
+          },
+        },
         confirmQuit: {
           title: "Confirm Quit",
           description:
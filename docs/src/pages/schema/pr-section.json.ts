@@ -21,6 +21,12 @@ export function GET() {
             "Defines the GitHub search filters for the PRs in the section's table.",
           type: "string",
         },
+        host: {
+          title: "PR Host",
+          description:
+            "Defines the GitHub host the section searches, like github.example.com. Defaults to gh's default host.",
+          type: "string",
+        },
         layout: {
           $ref: "./layout/pr.json",
         },
@@ -29,6 +35,20 @@ export function GET() {
           type: "integer",
           minimum: 1,
         },
+        refetchIntervalMinutes: {
+          title: "Refetch Interval in Minutes",
+          description:
+            "Specifies how often to refetch the section in minutes. Overrides defaults.refetchIntervalMinutes; 0 disables background refreshes.",
+          type: "integer",
+          minimum: 0,
+        },
+        dedupe: {
+          title: "Deduplicate",
+          description:
+            "Hides items already shown by a section listed before this one in the same view.",
+          type: "boolean",
+          default: false,
+        },
       },
     }),
   );
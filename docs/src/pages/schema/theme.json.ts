@@ -38,6 +38,13 @@ export function GET() {
                   type: "boolean",
                   default: false,
                 },
+                showSeenElsewhere: {
+                  title: "Show Seen Elsewhere",
+                  description:
+                    "Whether PR and issue rows list the other sections that also contain them.",
+                  type: "boolean",
+                  default: false,
+                },
               },
             },
           },
@@ -255,6 +262,7 @@ export function GET() {
           table: {
             showSeparators: true,
             compact: false,
+            showSeenElsewhere: false,
           },
         },
         colors: {
@@ -69,6 +69,8 @@ func (a *ViewType) UnmarshalJSON(b []byte) error {
 		*a = IssuesView
 	case "repo":
 		*a = RepoView
+	case "alerts":
+		*a = AlertsView
 	}
 
 	return nil
@@ -79,34 +81,140 @@ const (
 	PRsView           ViewType = "prs"
 	IssuesView        ViewType = "issues"
 	RepoView          ViewType = "repo"
+	AlertsView        ViewType = "alerts"
 )
 
 type SectionConfig struct {
-	Title   string
-	Filters string
-	Limit   *int      `yaml:"limit,omitempty"`
-	Type    *ViewType `yaml:"type,omitempty"`
+	Title                  string
+	Filters                string
+	Host                   string    `yaml:"host,omitempty"                   validate:"omitempty,hostname_rfc1123"`
+	Limit                  *int      `yaml:"limit,omitempty"`
+	Type                   *ViewType `yaml:"type,omitempty"`
+	RefetchIntervalMinutes *int      `yaml:"refetchIntervalMinutes,omitempty"`
+	Dedupe                 *bool     `yaml:"dedupe,omitempty"`
 }
 
 type PrsSectionConfig struct {
-	Title   string
-	Filters string
-	Limit   *int            `yaml:"limit,omitempty"`
-	Layout  PrsLayoutConfig `yaml:"layout,omitempty"`
-	Type    *ViewType       `yaml:"type,omitempty"`
+	Title                  string
+	Filters                string
+	Host                   string          `yaml:"host,omitempty"                   validate:"omitempty,hostname_rfc1123"`
+	Limit                  *int            `yaml:"limit,omitempty"`
+	Layout                 PrsLayoutConfig `yaml:"layout,omitempty"`
+	Type                   *ViewType       `yaml:"type,omitempty"`
+	RefetchIntervalMinutes *int            `yaml:"refetchIntervalMinutes,omitempty"`
+	Dedupe                 *bool           `yaml:"dedupe,omitempty"`
 }
 
 type IssuesSectionConfig struct {
-	Title   string
-	Filters string
-	Limit   *int               `yaml:"limit,omitempty"`
-	Layout  IssuesLayoutConfig `yaml:"layout,omitempty"`
+	Title                  string
+	Filters                string
+	Host                   string             `yaml:"host,omitempty"                   validate:"omitempty,hostname_rfc1123"`
+	Limit                  *int               `yaml:"limit,omitempty"`
+	Layout                 IssuesLayoutConfig `yaml:"layout,omitempty"`
+	RefetchIntervalMinutes *int               `yaml:"refetchIntervalMinutes,omitempty"`
+	Dedupe                 *bool              `yaml:"dedupe,omitempty"`
 }
 
 type NotificationsSectionConfig struct {
-	Title   string
-	Filters string
-	Limit   *int `yaml:"limit,omitempty"`
+	Title                  string
+	Filters                string
+	Host                   string `yaml:"host,omitempty"                   validate:"omitempty,hostname_rfc1123"`
+	Limit                  *int   `yaml:"limit,omitempty"`
+	RefetchIntervalMinutes *int   `yaml:"refetchIntervalMinutes,omitempty"`
+	Grouped                bool   `yaml:"grouped,omitempty"`
+}
+
+// AlertsSectionConfig is a section of the security alerts view. Filters
+// need a repo: or org: qualifier, since GitHub lists alerts per repository
+// or organization.
+type AlertsSectionConfig struct {
+	Title                  string
+	Filters                string
+	Host                   string `yaml:"host,omitempty"                   validate:"omitempty,hostname_rfc1123"`
+	Limit                  *int   `yaml:"limit,omitempty"`
+	RefetchIntervalMinutes *int   `yaml:"refetchIntervalMinutes,omitempty"`
+}
+
+type NotificationRuleAction string
+
+const (
+	NotificationRuleDone        NotificationRuleAction = "done"
+	NotificationRuleRead        NotificationRuleAction = "read"
+	NotificationRuleBookmark    NotificationRuleAction = "bookmark"
+	NotificationRuleUnsubscribe NotificationRuleAction = "unsubscribe"
+)
+
+// NotificationRule applies an action to every fetched notification matching
+// all of its conditions. Empty conditions match everything.
+type NotificationRule struct {
+	Name   string                 `yaml:"name,omitempty"`
+	Match  NotificationRuleMatch  `yaml:"match"`
+	Action NotificationRuleAction `yaml:"action"         validate:"oneof=done read bookmark unsubscribe"`
+}
+
+// NotificationRuleMatch holds the conditions of a notification rule. List
+// conditions match if any of their values match.
+type NotificationRuleMatch struct {
+	Reason      []string `yaml:"reason,omitempty"`
+	Repo        []string `yaml:"repo,omitempty"`
+	SubjectType []string `yaml:"subjectType,omitempty"`
+	Title       string   `yaml:"title,omitempty"       validate:"omitempty,regexp"`
+	Author      []string `yaml:"author,omitempty"`
+	State       []string `yaml:"state,omitempty"       validate:"dive,oneof=open closed merged draft"`
+}
+
+type DesktopNotificationMethod string
+
+const (
+	DesktopNotificationOSC777  DesktopNotificationMethod = "osc777"
+	DesktopNotificationOSC9    DesktopNotificationMethod = "osc9"
+	DesktopNotificationCommand DesktopNotificationMethod = "command"
+)
+
+// DesktopNotificationsConfig configures the desktop notifications sent for
+// new notifications matching Filters while the dashboard is running.
+type DesktopNotificationsConfig struct {
+	Enabled bool                      `yaml:"enabled"`
+	Filters string                    `yaml:"filters"`
+	Method  DesktopNotificationMethod `yaml:"method"            validate:"oneof=osc777 osc9 command"`
+	Command string                    `yaml:"command,omitempty" validate:"required_if=Method command"`
+}
+
+// StateSyncConfig configures syncing the done notifications and bookmarks
+// through Dir, a folder synced between machines.
+type StateSyncConfig struct {
+	Dir string `yaml:"dir,omitempty"`
+}
+
+// WorktreesConfig makes checkouts create a git worktree per PR or issue
+// instead of switching branches in the repo's path. Dir is a pattern for
+// the worktree's path, see common.GetWorktreePath.
+type WorktreesConfig struct {
+	Enabled bool   `yaml:"enabled,omitempty"`
+	Dir     string `yaml:"dir,omitempty"`
+}
+
+// RepoDiscoveryConfig finds the clones of repos repoPaths doesn't map by
+// scanning Roots, and the directories below them up to Depth levels deep, for
+// git repos named after their remotes. CloneDir is a pattern like
+// ~/code/:owner/:repo to clone a missing repo into when a command needs its
+// path.
+type RepoDiscoveryConfig struct {
+	Roots    []string `yaml:"roots,omitempty"`
+	Depth    int      `yaml:"depth,omitempty"    validate:"gte=0"`
+	CloneDir string   `yaml:"cloneDir,omitempty"`
+}
+
+// DefaultRepoDiscoveryDepth is how deep below its roots repo discovery
+// searches when depth isn't set, enough for layouts like root/host/owner/repo
+const DefaultRepoDiscoveryDepth = 3
+
+// GetDepth returns the configured depth or DefaultRepoDiscoveryDepth
+func (c RepoDiscoveryConfig) GetDepth() int {
+	if c.Depth > 0 {
+		return c.Depth
+	}
+	return DefaultRepoDiscoveryDepth
 }
 
 type PreviewConfig struct {
@@ -200,6 +308,9 @@ type Defaults struct {
 type RepoConfig struct {
 	BranchesRefetchIntervalSeconds int `yaml:"branchesRefetchIntervalSeconds,omitempty"`
 	PrsRefetchIntervalSeconds      int `yaml:"prsRefetchIntervalSeconds,omitempty"`
+	// IncludeRepoPaths shows the branches of every repo repoPaths maps by
+	// name in the repo view, along with the repo gh-dash runs in
+	IncludeRepoPaths bool `yaml:"includeRepoPaths,omitempty"`
 }
 
 type Keybinding struct {
@@ -232,6 +343,7 @@ type Keybindings struct {
 	Branches      []Keybinding `yaml:"branches,omitempty"`
 	Notifications []Keybinding `yaml:"notifications,omitempty"`
 	Cmp           []Keybinding `yaml:"completions,omitempty"`
+	Alerts        []Keybinding `yaml:"alerts,omitempty"`
 }
 
 type Pager struct {
@@ -303,8 +415,9 @@ type IconThemeConfig struct {
 }
 
 type TableUIThemeConfig struct {
-	ShowSeparator bool `yaml:"showSeparator" default:"true"`
-	Compact       bool `yaml:"compact"       default:"false"`
+	ShowSeparator     bool `yaml:"showSeparator"     default:"true"`
+	Compact           bool `yaml:"compact"           default:"false"`
+	ShowSeenElsewhere bool `yaml:"showSeenElsewhere" default:"false"`
 }
 
 type UIThemeConfig struct {
@@ -320,9 +433,10 @@ type ThemeConfig struct {
 
 type Config struct {
 	Include                  []string                     `yaml:"include,omitempty"`
-	PRSections               []PrsSectionConfig           `yaml:"prSections"`
-	IssuesSections           []IssuesSectionConfig        `yaml:"issuesSections"`
-	NotificationsSections    []NotificationsSectionConfig `yaml:"notificationsSections"`
+	PRSections               []PrsSectionConfig           `yaml:"prSections"                                     validate:"dive"`
+	IssuesSections           []IssuesSectionConfig        `yaml:"issuesSections"                                 validate:"dive"`
+	NotificationsSections    []NotificationsSectionConfig `yaml:"notificationsSections"                          validate:"dive"`
+	AlertsSections           []AlertsSectionConfig        `yaml:"alertsSections,omitempty"                      validate:"dive"`
 	Repo                     RepoConfig                   `yaml:"repo,omitempty"`
 	Defaults                 Defaults                     `yaml:"defaults"`
 	Keybindings              Keybindings                  `yaml:"keybindings"`
@@ -333,6 +447,11 @@ type Config struct {
 	ShowAuthorIcons          bool                         `yaml:"showAuthorIcons,omitempty"`
 	SmartFilteringAtLaunch   bool                         `yaml:"smartFilteringAtLaunch"                         default:"true"`
 	IncludeReadNotifications bool                         `yaml:"includeReadNotifications"                       default:"true"`
+	NotificationRules        []NotificationRule           `yaml:"notificationRules,omitempty"                    validate:"dive"`
+	DesktopNotifications     DesktopNotificationsConfig   `yaml:"desktopNotifications"`
+	StateSync                StateSyncConfig              `yaml:"stateSync,omitempty"`
+	Worktrees                WorktreesConfig              `yaml:"worktrees,omitempty"`
+	RepoDiscovery            RepoDiscoveryConfig          `yaml:"repoDiscovery,omitempty"`
 }
 
 type configError struct {
@@ -492,8 +611,9 @@ func (parser ConfigParser) getDefaultConfig() Config {
 			Ui: UIThemeConfig{
 				SectionsShowCount: true,
 				Table: TableUIThemeConfig{
-					ShowSeparator: true,
-					Compact:       false,
+					ShowSeparator:     true,
+					Compact:           false,
+					ShowSeenElsewhere: false,
 				},
 			},
 		},
@@ -501,6 +621,11 @@ func (parser ConfigParser) getDefaultConfig() Config {
 		ShowAuthorIcons:          true,
 		SmartFilteringAtLaunch:   true,
 		IncludeReadNotifications: true,
+		DesktopNotifications: DesktopNotificationsConfig{
+			Enabled: false,
+			Filters: "reason:review-requested,mention",
+			Method:  DesktopNotificationOSC777,
+		},
 	}
 }
 
@@ -803,6 +928,11 @@ func validateColor(fl validator.FieldLevel) bool {
 	return err == nil && n >= 0 && n <= 255
 }
 
+func validateRegexp(fl validator.FieldLevel) bool {
+	_, err := regexp.Compile(fl.Field().String())
+	return err == nil
+}
+
 func initParser() ConfigParser {
 	validate = validator.New()
 
@@ -815,6 +945,7 @@ func initParser() ConfigParser {
 	})
 
 	validate.RegisterValidation("color", validateColor)
+	validate.RegisterValidation("regexp", validateRegexp)
 
 	return ConfigParser{
 		k: koanf.NewWithConf(conf),
@@ -875,6 +1006,9 @@ func (parser ConfigParser) unmarshalConfigWithDefaults() (Config, error) {
 	if cfg.Defaults.View == RepoView && !repoFF {
 		cfg.Defaults.View = PRsView
 	}
+	if cfg.Defaults.View == AlertsView && len(cfg.AlertsSections) == 0 {
+		cfg.Defaults.View = PRsView
+	}
 
 	err = validate.Struct(cfg)
 	return cfg, err
@@ -258,6 +258,53 @@ func TestValidateColor(t *testing.T) {
 	}
 }
 
+func TestValidateNotificationRules(t *testing.T) {
+	initParser()
+
+	type testRules struct {
+		Rules []NotificationRule `validate:"dive"`
+	}
+
+	valid := NotificationRule{
+		Match: NotificationRuleMatch{
+			Title: `^v\d+`,
+			State: []string{"merged", "draft"},
+		},
+		Action: NotificationRuleDone,
+	}
+	assert.NoError(t, validate.Struct(testRules{Rules: []NotificationRule{valid}}))
+
+	badAction := valid
+	badAction.Action = "archive"
+	badTitle := valid
+	badTitle.Match.Title = "(unclosed"
+	badState := valid
+	badState.Match.State = []string{"locked"}
+	for _, rule := range []NotificationRule{badAction, badTitle, badState} {
+		err := validate.Struct(testRules{Rules: []NotificationRule{rule}})
+		assert.Errorf(t, err, "expected %+v to be invalid", rule)
+	}
+}
+
+func TestValidateSectionHosts(t *testing.T) {
+	initParser()
+
+	valid := NotificationsSectionConfig{Title: "Work", Host: "github.example.com"}
+	assert.NoError(t, validate.Struct(valid))
+	assert.Equal(t, "github.example.com", valid.ToSectionConfig().Host)
+
+	const badHost = "https://github.example.com"
+	for _, cfg := range []Config{
+		{PRSections: []PrsSectionConfig{{Title: "PRs", Host: badHost}}},
+		{IssuesSections: []IssuesSectionConfig{{Title: "Issues", Host: badHost}}},
+		{NotificationsSections: []NotificationsSectionConfig{{Title: "All", Host: badHost}}},
+		{AlertsSections: []AlertsSectionConfig{{Title: "Alerts", Host: badHost}}},
+	} {
+		assert.ErrorContains(t, validate.Struct(cfg), "Sections[0].host' Error:Field validation for 'host'")
+	}
+	assert.Error(t, validate.Struct(SectionConfig{Host: badHost}))
+}
+
 func setupConfigEnvVar(t *testing.T) func() {
 	t.Helper()
 	cwd := Testwd(t)
@@ -30,26 +30,44 @@ func (cfg Config) GetFullScreenDiffPagerEnv() []string {
 
 func (cfg PrsSectionConfig) ToSectionConfig() SectionConfig {
 	return SectionConfig{
-		Title:   cfg.Title,
-		Filters: cfg.Filters,
-		Limit:   cfg.Limit,
-		Type:    cfg.Type,
+		Title:                  cfg.Title,
+		Filters:                cfg.Filters,
+		Host:                   cfg.Host,
+		Limit:                  cfg.Limit,
+		Type:                   cfg.Type,
+		RefetchIntervalMinutes: cfg.RefetchIntervalMinutes,
+		Dedupe:                 cfg.Dedupe,
 	}
 }
 
 func (cfg IssuesSectionConfig) ToSectionConfig() SectionConfig {
 	return SectionConfig{
-		Title:   cfg.Title,
-		Filters: cfg.Filters,
-		Limit:   cfg.Limit,
+		Title:                  cfg.Title,
+		Filters:                cfg.Filters,
+		Host:                   cfg.Host,
+		Limit:                  cfg.Limit,
+		RefetchIntervalMinutes: cfg.RefetchIntervalMinutes,
+		Dedupe:                 cfg.Dedupe,
 	}
 }
 
 func (cfg NotificationsSectionConfig) ToSectionConfig() SectionConfig {
 	return SectionConfig{
-		Title:   cfg.Title,
-		Filters: cfg.Filters,
-		Limit:   cfg.Limit,
+		Title:                  cfg.Title,
+		Filters:                cfg.Filters,
+		Host:                   cfg.Host,
+		Limit:                  cfg.Limit,
+		RefetchIntervalMinutes: cfg.RefetchIntervalMinutes,
+	}
+}
+
+func (cfg AlertsSectionConfig) ToSectionConfig() SectionConfig {
+	return SectionConfig{
+		Title:                  cfg.Title,
+		Filters:                cfg.Filters,
+		Host:                   cfg.Host,
+		Limit:                  cfg.Limit,
+		RefetchIntervalMinutes: cfg.RefetchIntervalMinutes,
 	}
 }
 
@@ -0,0 +1,602 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"charm.land/log/v2"
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
+// AlertKind is the GitHub feature that raised a security alert
+type AlertKind string
+
+const (
+	AlertKindDependabot     AlertKind = "dependabot"
+	AlertKindCodeScanning   AlertKind = "code-scanning"
+	AlertKindSecretScanning AlertKind = "secret-scanning"
+)
+
+// AlertKinds are all the kinds of security alerts, in the order they're
+// fetched
+var AlertKinds = []AlertKind{AlertKindDependabot, AlertKindCodeScanning, AlertKindSecretScanning}
+
+const (
+	AlertStateOpen      = "open"
+	AlertStateDismissed = "dismissed"
+	AlertStateFixed     = "fixed"
+	// AlertStateResolved is the state of closed secret scanning alerts,
+	// whether they were revoked or dismissed
+	AlertStateResolved = "resolved"
+)
+
+// maxConcurrentAlertFetches bounds how many repos, orgs and alert kinds are
+// fetched at once
+const maxConcurrentAlertFetches = 4
+
+// SecurityAlert is a Dependabot, code scanning or secret scanning alert,
+// with the fields of the three APIs mapped onto the same ones.
+type SecurityAlert struct {
+	Kind   AlertKind
+	Number int
+	Repo   string
+	State  string
+	// Severity is critical, high, medium or low. Code scanning alerts that
+	// aren't security issues have error, warning or note instead, and secret
+	// scanning alerts have none.
+	Severity string
+	// Ecosystem is the package ecosystem of Dependabot alerts and the tool
+	// of code scanning alerts
+	Ecosystem string
+	// Package is the vulnerable package of Dependabot alerts, the rule of
+	// code scanning alerts and the secret type of secret scanning alerts
+	Package string
+	Title   string
+	// Description is the advisory or rule help, in markdown
+	Description     string
+	Identifiers     []string
+	VulnerableRange string
+	PatchedVersion  string
+	// Location is the manifest of Dependabot alerts and the file and line of
+	// code scanning alerts
+	Location         string
+	Url              string
+	DismissedReason  string
+	DismissedComment string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (a *SecurityAlert) GetRepoNameWithOwner() string {
+	return a.Repo
+}
+
+func (a *SecurityAlert) GetTitle() string {
+	return a.Title
+}
+
+func (a *SecurityAlert) GetNumber() int {
+	return a.Number
+}
+
+func (a *SecurityAlert) GetUrl() string {
+	return a.Url
+}
+
+func (a *SecurityAlert) GetUpdatedAt() time.Time {
+	return a.UpdatedAt
+}
+
+// IsOpen returns true if the alert wasn't dismissed, fixed or resolved
+func (a *SecurityAlert) IsOpen() bool {
+	return a.State == AlertStateOpen
+}
+
+// SeverityRank orders severities from the most severe, 0, to the least.
+func SeverityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 0
+	case "high", "error":
+		return 1
+	case "medium", "warning":
+		return 2
+	case "low", "note":
+		return 3
+	default:
+		return 4
+	}
+}
+
+// AlertsRequest selects the security alerts of repositories and
+// organizations. Empty lists match everything.
+type AlertsRequest struct {
+	// Host is the GitHub host the alerts are on, gh's default one if empty
+	Host       string
+	Repos      []string
+	Orgs       []string
+	Kinds      []AlertKind
+	State      string
+	Severities []string
+	Ecosystems []string
+	Limit      int
+}
+
+type dependabotAlert struct {
+	Number     int    `json:"number"`
+	State      string `json:"state"`
+	HtmlUrl    string `json:"html_url"`
+	Dependency struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		ManifestPath string `json:"manifest_path"`
+	} `json:"dependency"`
+	SecurityAdvisory struct {
+		GhsaId      string `json:"ghsa_id"`
+		CveId       string `json:"cve_id"`
+		Summary     string `json:"summary"`
+		Description string `json:"description"`
+		Severity    string `json:"severity"`
+	} `json:"security_advisory"`
+	SecurityVulnerability struct {
+		VulnerableVersionRange string `json:"vulnerable_version_range"`
+		FirstPatchedVersion    *struct {
+			Identifier string `json:"identifier"`
+		} `json:"first_patched_version"`
+	} `json:"security_vulnerability"`
+	DismissedReason  *string        `json:"dismissed_reason"`
+	DismissedComment *string        `json:"dismissed_comment"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	Repository       *alertRepoData `json:"repository"`
+}
+
+type codeScanningAlert struct {
+	Number int    `json:"number"`
+	State  string `json:"state"`
+	// HtmlUrl is the alert's page
+	HtmlUrl string `json:"html_url"`
+	Rule    struct {
+		Id                    string `json:"id"`
+		Severity              string `json:"severity"`
+		SecuritySeverityLevel string `json:"security_severity_level"`
+		Description           string `json:"description"`
+		FullDescription       string `json:"full_description"`
+		Help                  string `json:"help"`
+	} `json:"rule"`
+	Tool struct {
+		Name string `json:"name"`
+	} `json:"tool"`
+	MostRecentInstance struct {
+		Location struct {
+			Path      string `json:"path"`
+			StartLine int    `json:"start_line"`
+		} `json:"location"`
+		Message struct {
+			Text string `json:"text"`
+		} `json:"message"`
+	} `json:"most_recent_instance"`
+	DismissedReason  *string        `json:"dismissed_reason"`
+	DismissedComment *string        `json:"dismissed_comment"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        *time.Time     `json:"updated_at"`
+	Repository       *alertRepoData `json:"repository"`
+}
+
+type secretScanningAlert struct {
+	Number                int            `json:"number"`
+	State                 string         `json:"state"`
+	HtmlUrl               string         `json:"html_url"`
+	SecretType            string         `json:"secret_type"`
+	SecretTypeDisplayName string         `json:"secret_type_display_name"`
+	Validity              string         `json:"validity"`
+	Resolution            *string        `json:"resolution"`
+	ResolutionComment     *string        `json:"resolution_comment"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             *time.Time     `json:"updated_at"`
+	Repository            *alertRepoData `json:"repository"`
+}
+
+type alertRepoData struct {
+	FullName string `json:"full_name"`
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// alertRepo returns the alert's repository, which the repository endpoints
+// leave out
+func alertRepo(repo *alertRepoData, scopeRepo string) string {
+	if repo != nil && repo.FullName != "" {
+		return repo.FullName
+	}
+	return scopeRepo
+}
+
+func (a dependabotAlert) toSecurityAlert(scopeRepo string) SecurityAlert {
+	advisory := a.SecurityAdvisory
+	var identifiers []string
+	for _, id := range []string{advisory.GhsaId, advisory.CveId} {
+		if id != "" {
+			identifiers = append(identifiers, id)
+		}
+	}
+	patched := ""
+	if a.SecurityVulnerability.FirstPatchedVersion != nil {
+		patched = a.SecurityVulnerability.FirstPatchedVersion.Identifier
+	}
+	return SecurityAlert{
+		Kind:             AlertKindDependabot,
+		Number:           a.Number,
+		Repo:             alertRepo(a.Repository, scopeRepo),
+		State:            a.State,
+		Severity:         advisory.Severity,
+		Ecosystem:        a.Dependency.Package.Ecosystem,
+		Package:          a.Dependency.Package.Name,
+		Title:            advisory.Summary,
+		Description:      advisory.Description,
+		Identifiers:      identifiers,
+		VulnerableRange:  a.SecurityVulnerability.VulnerableVersionRange,
+		PatchedVersion:   patched,
+		Location:         a.Dependency.ManifestPath,
+		Url:              a.HtmlUrl,
+		DismissedReason:  deref(a.DismissedReason),
+		DismissedComment: deref(a.DismissedComment),
+		CreatedAt:        a.CreatedAt,
+		UpdatedAt:        a.UpdatedAt,
+	}
+}
+
+func (a codeScanningAlert) toSecurityAlert(scopeRepo string) SecurityAlert {
+	rule := a.Rule
+	severity := rule.SecuritySeverityLevel
+	if severity == "" {
+		severity = rule.Severity
+	}
+	description := rule.Help
+	if description == "" {
+		description = rule.FullDescription
+	}
+	if msg := a.MostRecentInstance.Message.Text; msg != "" {
+		description = strings.TrimSpace(msg + "\n\n" + description)
+	}
+	location := a.MostRecentInstance.Location.Path
+	if line := a.MostRecentInstance.Location.StartLine; location != "" && line > 0 {
+		location = fmt.Sprintf("%s:%d", location, line)
+	}
+	updatedAt := a.CreatedAt
+	if a.UpdatedAt != nil {
+		updatedAt = *a.UpdatedAt
+	}
+	return SecurityAlert{
+		Kind:             AlertKindCodeScanning,
+		Number:           a.Number,
+		Repo:             alertRepo(a.Repository, scopeRepo),
+		State:            a.State,
+		Severity:         severity,
+		Ecosystem:        a.Tool.Name,
+		Package:          rule.Id,
+		Title:            rule.Description,
+		Description:      description,
+		Location:         location,
+		Url:              a.HtmlUrl,
+		DismissedReason:  deref(a.DismissedReason),
+		DismissedComment: deref(a.DismissedComment),
+		CreatedAt:        a.CreatedAt,
+		UpdatedAt:        updatedAt,
+	}
+}
+
+func (a secretScanningAlert) toSecurityAlert(scopeRepo string) SecurityAlert {
+	title := a.SecretTypeDisplayName
+	if title == "" {
+		title = a.SecretType
+	}
+	description := ""
+	if a.Validity != "" && a.Validity != "unknown" {
+		description = fmt.Sprintf("The secret is **%s**.", a.Validity)
+	}
+	updatedAt := a.CreatedAt
+	if a.UpdatedAt != nil {
+		updatedAt = *a.UpdatedAt
+	}
+	return SecurityAlert{
+		Kind:             AlertKindSecretScanning,
+		Number:           a.Number,
+		Repo:             alertRepo(a.Repository, scopeRepo),
+		State:            a.State,
+		Package:          a.SecretType,
+		Title:            title + " exposed",
+		Description:      description,
+		Url:              a.HtmlUrl,
+		DismissedReason:  deref(a.Resolution),
+		DismissedComment: deref(a.ResolutionComment),
+		CreatedAt:        a.CreatedAt,
+		UpdatedAt:        updatedAt,
+	}
+}
+
+// alertsPath returns the path listing the alerts of a kind in a repository
+// or organization, or false if the kind can't match the request.
+func alertsPath(kind AlertKind, scope string, req AlertsRequest) (string, bool) {
+	perPage := min(max(req.Limit, 1), 100)
+	params := url.Values{}
+	params.Set("per_page", fmt.Sprint(perPage))
+	params.Set("sort", "updated")
+	params.Set("direction", "desc")
+
+	switch kind {
+	case AlertKindDependabot:
+		switch req.State {
+		case "":
+		case AlertStateDismissed:
+			params.Set("state", "dismissed,auto_dismissed")
+		default:
+			params.Set("state", req.State)
+		}
+		if len(req.Severities) > 0 {
+			params.Set("severity", strings.Join(req.Severities, ","))
+		}
+		if len(req.Ecosystems) > 0 {
+			params.Set("ecosystem", strings.Join(req.Ecosystems, ","))
+		}
+	case AlertKindCodeScanning:
+		// Only Dependabot alerts have an ecosystem
+		if len(req.Ecosystems) > 0 {
+			return "", false
+		}
+		if req.State != "" {
+			params.Set("state", req.State)
+		}
+		if len(req.Severities) > 0 {
+			params.Set("severity", strings.Join(req.Severities, ","))
+		}
+	case AlertKindSecretScanning:
+		if len(req.Ecosystems) > 0 || len(req.Severities) > 0 {
+			return "", false
+		}
+		switch req.State {
+		case "":
+		case AlertStateOpen:
+			params.Set("state", AlertStateOpen)
+		default:
+			params.Set("state", AlertStateResolved)
+		}
+	}
+
+	owner := "repos/" + scope
+	if !strings.Contains(scope, "/") {
+		owner = "orgs/" + scope
+	}
+	return fmt.Sprintf("%s/%s/alerts?%s", owner, kind, params.Encode()), true
+}
+
+func fetchAlerts(host string, kind AlertKind, path, scope string) ([]SecurityAlert, error) {
+	client, err := getHostRESTClient(host)
+	if err != nil {
+		return nil, err
+	}
+	// Organization endpoints fill in the repository of every alert
+	scopeRepo := ""
+	if strings.Contains(scope, "/") {
+		scopeRepo = scope
+	}
+
+	var alerts []SecurityAlert
+	switch kind {
+	case AlertKindDependabot:
+		var res []dependabotAlert
+		err = client.Get(path, &res)
+		for _, a := range res {
+			alerts = append(alerts, a.toSecurityAlert(scopeRepo))
+		}
+	case AlertKindCodeScanning:
+		var res []codeScanningAlert
+		err = client.Get(path, &res)
+		for _, a := range res {
+			alerts = append(alerts, a.toSecurityAlert(scopeRepo))
+		}
+	case AlertKindSecretScanning:
+		var res []secretScanningAlert
+		err = client.Get(path, &res)
+		for _, a := range res {
+			alerts = append(alerts, a.toSecurityAlert(scopeRepo))
+		}
+	}
+	return alerts, err
+}
+
+// FetchSecurityAlerts fetches the security alerts of the requested
+// repositories and organizations, most severe first. Kinds that aren't
+// enabled for a repository, or that the user can't see, are skipped unless
+// every fetch fails.
+func FetchSecurityAlerts(req AlertsRequest) ([]SecurityAlert, error) {
+	if len(req.Repos) == 0 && len(req.Orgs) == 0 {
+		return nil, errors.New("security alerts need a repo: or org: filter")
+	}
+	kinds := req.Kinds
+	if len(kinds) == 0 {
+		kinds = AlertKinds
+	}
+
+	type fetch struct {
+		kind  AlertKind
+		scope string
+		path  string
+	}
+	var fetches []fetch
+	for _, scope := range slices.Concat(req.Repos, req.Orgs) {
+		for _, kind := range kinds {
+			if path, ok := alertsPath(kind, scope, req); ok {
+				fetches = append(fetches, fetch{kind, scope, path})
+			}
+		}
+	}
+
+	type fetchResult struct {
+		alerts []SecurityAlert
+		err    error
+	}
+	results := make([]fetchResult, len(fetches))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentAlertFetches)
+	for i, f := range fetches {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			alerts, err := fetchAlerts(req.Host, f.kind, f.path, f.scope)
+			results[i] = fetchResult{alerts, err}
+		})
+	}
+	wg.Wait()
+
+	var alerts []SecurityAlert
+	var errs []error
+	for i, res := range results {
+		if res.err != nil {
+			log.Warn("Failed to fetch security alerts", "kind", fetches[i].kind,
+				"scope", fetches[i].scope, "err", res.err)
+			errs = append(errs, fmt.Errorf("%s alerts of %s: %w", fetches[i].kind,
+				fetches[i].scope, res.err))
+			continue
+		}
+		alerts = append(alerts, res.alerts...)
+	}
+	if len(fetches) > 0 && len(errs) == len(fetches) {
+		return nil, errors.Join(errs...)
+	}
+
+	slices.SortStableFunc(alerts, func(a, b SecurityAlert) int {
+		if d := SeverityRank(a.Severity) - SeverityRank(b.Severity); d != 0 {
+			return d
+		}
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+	if req.Limit > 0 && len(alerts) > req.Limit {
+		alerts = alerts[:req.Limit]
+	}
+	log.Debug("Fetched security alerts", "count", len(alerts))
+	return alerts, nil
+}
+
+// AlertDismissReasons returns the reasons an alert of the given kind can be
+// dismissed with, as the API names them.
+func AlertDismissReasons(kind AlertKind) []string {
+	switch kind {
+	case AlertKindDependabot:
+		return []string{"fix_started", "inaccurate", "no_bandwidth", "not_used", "tolerable_risk"}
+	case AlertKindCodeScanning:
+		return []string{"false positive", "won't fix", "used in tests"}
+	case AlertKindSecretScanning:
+		return []string{"false_positive", "wont_fix", "revoked", "used_in_tests"}
+	default:
+		return nil
+	}
+}
+
+// SetAlertState dismisses an alert with one of AlertDismissReasons and an
+// optional comment, or reopens it if reason is empty.
+func SetAlertState(alert SecurityAlert, reason, comment string) error {
+	client, err := getHostRESTClient(RowHost(&alert))
+	if err != nil {
+		return err
+	}
+
+	body := map[string]string{"state": AlertStateOpen}
+	if reason != "" {
+		if !slices.Contains(AlertDismissReasons(alert.Kind), reason) {
+			return fmt.Errorf("invalid dismiss reason %q for %s alerts", reason, alert.Kind)
+		}
+		switch alert.Kind {
+		case AlertKindSecretScanning:
+			body["state"] = AlertStateResolved
+			body["resolution"] = reason
+			if comment != "" {
+				body["resolution_comment"] = comment
+			}
+		default:
+			body["state"] = AlertStateDismissed
+			body["dismissed_reason"] = reason
+			if comment != "" {
+				body["dismissed_comment"] = comment
+			}
+		}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/alerts/%d", alert.Repo, alert.Kind, alert.Number)
+	log.Debug("Setting alert state", "path", path, "state", body["state"], "reason", reason)
+	if err := client.Patch(path, bytes.NewReader(data), nil); err != nil {
+		return err
+	}
+	log.Info("Successfully set alert state", "path", path, "state", body["state"])
+	return nil
+}
+
+// AlertFixPR is the pull request Dependabot opened to fix an alert
+type AlertFixPR struct {
+	Number int
+	Title  string
+	Url    string
+	State  string
+}
+
+// FetchAlertFixPR fetches the security update pull request Dependabot
+// opened for an alert of a repo on host, if any.
+func FetchAlertFixPR(host string, repo string, number int) (*AlertFixPR, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository %q", repo)
+	}
+	client, err := getGraphQLClient(host)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResult struct {
+		Repository struct {
+			VulnerabilityAlert *struct {
+				DependabotUpdate *struct {
+					PullRequest *struct {
+						Number int
+						Title  string
+						Url    string
+						State  string
+					}
+				}
+			} `graphql:"vulnerabilityAlert(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	variables := map[string]any{
+		"owner":  graphql.String(owner),
+		"name":   graphql.String(name),
+		"number": graphql.Int(number),
+	}
+	log.Debug("Fetching alert fix PR", "repo", repo, "number", number)
+	if err := client.Query("FetchAlertFixPR", &queryResult, variables); err != nil {
+		return nil, err
+	}
+
+	alert := queryResult.Repository.VulnerabilityAlert
+	if alert == nil || alert.DependabotUpdate == nil || alert.DependabotUpdate.PullRequest == nil {
+		return nil, nil
+	}
+	pr := alert.DependabotUpdate.PullRequest
+	return &AlertFixPR{Number: pr.Number, Title: pr.Title, Url: pr.Url, State: pr.State}, nil
+}
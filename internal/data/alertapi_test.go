@@ -0,0 +1,196 @@
+package data
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertsPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   AlertKind
+		scope  string
+		req    AlertsRequest
+		want   url.Values
+		wantOk bool
+	}{
+		{
+			name:   "dependabot alerts of a repo",
+			kind:   AlertKindDependabot,
+			scope:  "o/r",
+			req:    AlertsRequest{State: AlertStateDismissed, Severities: []string{"critical", "high"}, Limit: 30},
+			want:   url.Values{"state": {"dismissed,auto_dismissed"}, "severity": {"critical,high"}, "per_page": {"30"}},
+			wantOk: true,
+		},
+		{
+			name:   "code scanning alerts can't match an ecosystem",
+			kind:   AlertKindCodeScanning,
+			scope:  "o/r",
+			req:    AlertsRequest{Ecosystems: []string{"npm"}},
+			wantOk: false,
+		},
+		{
+			name:   "secret scanning alerts are resolved rather than fixed",
+			kind:   AlertKindSecretScanning,
+			scope:  "o",
+			req:    AlertsRequest{State: AlertStateFixed, Limit: 500},
+			want:   url.Values{"state": {"resolved"}, "per_page": {"100"}},
+			wantOk: true,
+		},
+		{
+			name:   "secret scanning alerts have no severity",
+			kind:   AlertKindSecretScanning,
+			scope:  "o/r",
+			req:    AlertsRequest{Severities: []string{"high"}},
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, ok := alertsPath(tt.kind, tt.scope, tt.req)
+			require.Equal(t, tt.wantOk, ok)
+			if !ok {
+				return
+			}
+			u, err := url.Parse(path)
+			require.NoError(t, err)
+			prefix := "repos/"
+			if tt.scope == "o" {
+				prefix = "orgs/"
+			}
+			require.Equal(t, prefix+tt.scope+"/"+string(tt.kind)+"/alerts", u.Path)
+			for k, v := range tt.want {
+				require.Equal(t, v, u.Query()[k], k)
+			}
+		})
+	}
+}
+
+func TestFetchSecurityAlerts(t *testing.T) {
+	originalClient := restClient
+	t.Cleanup(func() {
+		restClient = originalClient
+	})
+
+	var mu sync.Mutex
+	var paths []string
+	restClient = newNotificationsClient(t, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		paths = append(paths, req.URL.Path)
+		mu.Unlock()
+		switch req.URL.Path {
+		case "/repos/o/r/dependabot/alerts":
+			return notificationsResponse(req, http.StatusOK, http.Header{}, `[{
+				"number": 1,
+				"state": "open",
+				"html_url": "https://github.com/o/r/security/dependabot/1",
+				"dependency": {"package": {"ecosystem": "npm", "name": "lodash"}, "manifest_path": "package-lock.json"},
+				"security_advisory": {"ghsa_id": "GHSA-1", "cve_id": "CVE-1", "summary": "Prototype pollution", "severity": "medium"},
+				"security_vulnerability": {"vulnerable_version_range": "< 4.17.21", "first_patched_version": {"identifier": "4.17.21"}},
+				"created_at": "2026-01-01T00:00:00Z",
+				"updated_at": "2026-01-02T00:00:00Z"
+			}]`), nil
+		case "/repos/o/r/code-scanning/alerts":
+			return notificationsResponse(req, http.StatusOK, http.Header{}, `[{
+				"number": 2,
+				"state": "open",
+				"html_url": "https://github.com/o/r/security/code-scanning/2",
+				"rule": {"id": "js/sql-injection", "severity": "error", "security_severity_level": "critical", "description": "SQL injection"},
+				"tool": {"name": "CodeQL"},
+				"most_recent_instance": {"location": {"path": "db.js", "start_line": 12}},
+				"created_at": "2026-01-01T00:00:00Z"
+			}]`), nil
+		default:
+			return notificationsResponse(req, http.StatusNotFound, http.Header{},
+				`{"message": "Secret scanning is disabled on this repository."}`), nil
+		}
+	})
+
+	alerts, err := FetchSecurityAlerts(AlertsRequest{Repos: []string{"o/r"}, State: AlertStateOpen})
+	require.NoError(t, err, "kinds that aren't enabled are skipped")
+	require.Len(t, paths, 3)
+	require.Len(t, alerts, 2)
+
+	require.Equal(t, AlertKindCodeScanning, alerts[0].Kind, "the most severe alert comes first")
+	require.Equal(t, "critical", alerts[0].Severity)
+	require.Equal(t, "db.js:12", alerts[0].Location)
+	require.Equal(t, "o/r", alerts[0].Repo)
+
+	dependabot := alerts[1]
+	require.Equal(t, "lodash", dependabot.Package)
+	require.Equal(t, "npm", dependabot.Ecosystem)
+	require.Equal(t, "4.17.21", dependabot.PatchedVersion)
+	require.Equal(t, []string{"GHSA-1", "CVE-1"}, dependabot.Identifiers)
+
+	_, err = FetchSecurityAlerts(AlertsRequest{Repos: []string{"o/r"}, Kinds: []AlertKind{AlertKindSecretScanning}})
+	require.Error(t, err, "fails when every fetch fails")
+
+	_, err = FetchSecurityAlerts(AlertsRequest{})
+	require.Error(t, err, "needs a repo or org")
+}
+
+func TestSetAlertState(t *testing.T) {
+	originalClient := restClient
+	t.Cleanup(func() {
+		restClient = originalClient
+	})
+
+	var requests []string
+	restClient = newNotificationsClient(t, func(req *http.Request) (*http.Response, error) {
+		b, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		requests = append(requests, req.Method+" "+req.URL.Path+" "+string(b))
+		return notificationsResponse(req, http.StatusOK, http.Header{}, `{}`), nil
+	})
+
+	dependabot := SecurityAlert{Kind: AlertKindDependabot, Repo: "o/r", Number: 1}
+	secret := SecurityAlert{Kind: AlertKindSecretScanning, Repo: "o/r", Number: 2}
+	require.NoError(t, SetAlertState(dependabot, "tolerable_risk", "dev only"))
+	require.NoError(t, SetAlertState(secret, "revoked", ""))
+	require.NoError(t, SetAlertState(secret, "", ""))
+	require.Error(t, SetAlertState(dependabot, "revoked", ""))
+	require.Equal(t, []string{
+		`PATCH /repos/o/r/dependabot/alerts/1 {"dismissed_comment":"dev only","dismissed_reason":"tolerable_risk","state":"dismissed"}`,
+		`PATCH /repos/o/r/secret-scanning/alerts/2 {"resolution":"revoked","state":"resolved"}`,
+		`PATCH /repos/o/r/secret-scanning/alerts/2 {"state":"open"}`,
+	}, requests)
+}
+
+func TestAlertsOnOtherHosts(t *testing.T) {
+	t.Cleanup(func() { delete(hostRESTClients, "ghe.example.com") })
+
+	var requests []string
+	hostRESTClients["ghe.example.com"] = newNotificationsClient(t,
+		func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			if req.Method == http.MethodGet && strings.Contains(req.URL.Path, "dependabot") {
+				return notificationsResponse(req, http.StatusOK, http.Header{}, `[{
+					"number": 1,
+					"state": "open",
+					"html_url": "https://ghe.example.com/o/r/security/dependabot/1"
+				}]`), nil
+			}
+			return notificationsResponse(req, http.StatusOK, http.Header{}, `[]`), nil
+		})
+
+	alerts, err := FetchSecurityAlerts(AlertsRequest{
+		Host:  "ghe.example.com",
+		Repos: []string{"o/r"},
+		Kinds: []AlertKind{AlertKindDependabot},
+	})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+
+	require.NoError(t, SetAlertState(alerts[0], "", ""))
+	require.Equal(t, []string{
+		"GET /repos/o/r/dependabot/alerts",
+		"PATCH /repos/o/r/dependabot/alerts/1",
+	}, requests, "the alert's host is used")
+}
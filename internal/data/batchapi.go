@@ -0,0 +1,265 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"charm.land/log/v2"
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
+const (
+	// maxBatchedSearches caps the number of aliased searches in one document.
+	maxBatchedSearches = 10
+
+	// maxBatchCost caps the estimated rate limit cost of one document, keeping
+	// batches well clear of GitHub's per-query limits and secondary limits.
+	maxBatchCost = 10
+
+	// prConnections and issueConnections are the nested connections requested
+	// for every search result, used to estimate a search's cost.
+	prConnections    = 7
+	issueConnections = 4
+)
+
+// SearchRequest is a single search to be fetched as part of a batch. Host is
+// the GitHub host to search, or empty for the default host.
+type SearchRequest struct {
+	Host     string
+	Query    string
+	Limit    int
+	PageInfo *PageInfo
+}
+
+// SearchResult holds the outcome of a single search of a batch. Err is set
+// when only this search failed and the rest of the batch succeeded.
+type SearchResult[T any] struct {
+	Response T
+	Err      error
+}
+
+type prSearch struct {
+	Nodes []struct {
+		PullRequest PullRequestData `graphql:"... on PullRequest"`
+	}
+	IssueCount int
+	PageInfo   PageInfo
+}
+
+type issueSearch struct {
+	Nodes []struct {
+		Issue IssueData `graphql:"... on Issue"`
+	}
+	IssueCount int
+	PageInfo   PageInfo
+}
+
+// FetchPullRequestsBatch fetches several PR searches in as few round trips as
+// the query cost limits allow, with one or more round trips per host. PRs
+// that show up in more than one search share the same data. Results are
+// returned in the order of the requests.
+func FetchPullRequestsBatch(reqs []SearchRequest) []SearchResult[PullRequestsResponse] {
+	results := make([]SearchResult[PullRequestsResponse], len(reqs))
+	searches := batchSearch[prSearch]("SearchPullRequestsBatch", reqs, prConnections,
+		makePullRequestsQuery)
+
+	seen := make(map[string]PullRequestData)
+	for i, search := range searches {
+		if search.Err != nil {
+			results[i].Err = search.Err
+			continue
+		}
+
+		prs := make([]PullRequestData, 0, len(search.Response.Nodes))
+		for _, node := range search.Response.Nodes {
+			pr, ok := seen[node.PullRequest.Url]
+			if !ok {
+				pr = node.PullRequest
+				seen[pr.Url] = pr
+			}
+			prs = append(prs, pr)
+		}
+		results[i].Response = PullRequestsResponse{
+			Prs:        prs,
+			TotalCount: search.Response.IssueCount,
+			PageInfo:   search.Response.PageInfo,
+		}
+	}
+	log.Info("Successfully fetched PRs batch", "searches", len(reqs), "uniquePrs", len(seen))
+
+	return results
+}
+
+// FetchIssuesBatch is the issues counterpart of FetchPullRequestsBatch.
+func FetchIssuesBatch(reqs []SearchRequest) []SearchResult[IssuesResponse] {
+	results := make([]SearchResult[IssuesResponse], len(reqs))
+	searches := batchSearch[issueSearch]("SearchIssuesBatch", reqs, issueConnections,
+		makeIssuesQuery)
+
+	seen := make(map[string]IssueData)
+	for i, search := range searches {
+		if search.Err != nil {
+			results[i].Err = search.Err
+			continue
+		}
+
+		issues := make([]IssueData, 0, len(search.Response.Nodes))
+		for _, node := range search.Response.Nodes {
+			issue, ok := seen[node.Issue.Url]
+			if !ok {
+				issue = node.Issue
+				seen[issue.Url] = issue
+			}
+			issues = append(issues, issue)
+		}
+		results[i].Response = IssuesResponse{
+			Issues:     issues,
+			TotalCount: search.Response.IssueCount,
+			PageInfo:   search.Response.PageInfo,
+		}
+	}
+	log.Info("Successfully fetched issues batch", "searches", len(reqs), "uniqueIssues", len(seen))
+
+	return results
+}
+
+// estimateSearchCost approximates GitHub's rate limit cost of a search: one
+// request for the search itself plus one per nested connection of every
+// result, at 100 requests per point.
+func estimateSearchCost(limit int, connections int) int {
+	requests := 1 + limit*connections
+	return max(1, (requests+99)/100)
+}
+
+// splitBatches groups the requests into batches that each stay within
+// maxBatchedSearches and maxBatchCost. A single search exceeding the cost
+// limit gets a batch of its own.
+func splitBatches(reqs []SearchRequest, connections int) [][]int {
+	var batches [][]int
+	var batch []int
+	cost := 0
+	for i, req := range reqs {
+		reqCost := estimateSearchCost(req.Limit, connections)
+		if len(batch) > 0 && (len(batch) == maxBatchedSearches || cost+reqCost > maxBatchCost) {
+			batches = append(batches, batch)
+			batch, cost = nil, 0
+		}
+		batch = append(batch, i)
+		cost += reqCost
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func batchSearch[T any](
+	queryName string,
+	reqs []SearchRequest,
+	connections int,
+	makeQuery func(string) string,
+) []SearchResult[T] {
+	results := make([]SearchResult[T], len(reqs))
+	for _, group := range groupByHost(reqs) {
+		gqlClient, err := getGraphQLClient(reqs[group[0]].Host)
+		if err != nil {
+			for _, i := range group {
+				results[i].Err = err
+			}
+			continue
+		}
+
+		hostReqs := make([]SearchRequest, 0, len(group))
+		for _, i := range group {
+			hostReqs = append(hostReqs, reqs[i])
+		}
+		for _, batch := range splitBatches(hostReqs, connections) {
+			fields := make([]reflect.StructField, 0, len(batch))
+			variables := make(map[string]any, 3*len(batch))
+			for n, j := range batch {
+				req := hostReqs[j]
+				var endCursor *string
+				if req.PageInfo != nil {
+					endCursor = &req.PageInfo.EndCursor
+				}
+				variables[fmt.Sprintf("query%d", n)] = graphql.String(makeQuery(req.Query))
+				variables[fmt.Sprintf("limit%d", n)] = graphql.Int(req.Limit)
+				variables[fmt.Sprintf("endCursor%d", n)] = (*graphql.String)(endCursor)
+				fields = append(fields, reflect.StructField{
+					Name: fmt.Sprintf("S%d", n),
+					Type: reflect.TypeFor[T](),
+					Tag: reflect.StructTag(fmt.Sprintf(
+						`graphql:"s%[1]d: search(type: ISSUE, first: $limit%[1]d, after: $endCursor%[1]d, query: $query%[1]d)"`,
+						n,
+					)),
+				})
+			}
+
+			queryResult := reflect.New(reflect.StructOf(fields))
+			log.Debug("Fetching search batch", "name", queryName, "host", hostReqs[0].Host,
+				"searches", len(batch))
+			err := gqlClient.Query(queryName, queryResult.Interface(), variables)
+			failed := failedAliases(err)
+			for n, j := range batch {
+				i := group[j]
+				if aliasErr, ok := failed[fmt.Sprintf("s%d", n)]; ok {
+					results[i].Err = aliasErr
+				} else if err != nil && failed == nil {
+					results[i].Err = err
+				} else {
+					results[i].Response = queryResult.Elem().Field(n).Interface().(T)
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+// groupByHost groups the indexes of the requests by host, in the order each
+// host first appears. Requests for the default host are grouped together
+// whether they name it or not.
+func groupByHost(reqs []SearchRequest) [][]int {
+	var groups [][]int
+	hosts := make(map[string]int)
+	for i, req := range reqs {
+		host := strings.ToLower(req.Host)
+		if IsDefaultHost(host) {
+			host = ""
+		}
+		g, ok := hosts[host]
+		if !ok {
+			g = len(groups)
+			hosts[host] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// failedAliases maps the aliases of a batched query to the GraphQL errors
+// reported for them. It returns nil if err is not attributable to specific
+// aliases, in which case the whole batch failed.
+func failedAliases(err error) map[string]error {
+	var gqlErr *gh.GraphQLError
+	if err == nil || !errors.As(err, &gqlErr) {
+		return nil
+	}
+
+	failed := make(map[string]error)
+	for _, item := range gqlErr.Errors {
+		if len(item.Path) == 0 {
+			return nil
+		}
+		alias, ok := item.Path[0].(string)
+		if !ok {
+			return nil
+		}
+		failed[alias] = errors.New(item.Message)
+	}
+	return failed
+}
@@ -0,0 +1,116 @@
+package data
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBatches(t *testing.T) {
+	reqs := func(limits ...int) []SearchRequest {
+		res := make([]SearchRequest, 0, len(limits))
+		for _, limit := range limits {
+			res = append(res, SearchRequest{Limit: limit})
+		}
+		return res
+	}
+
+	tests := []struct {
+		name string
+		reqs []SearchRequest
+		want [][]int
+	}{
+		{name: "empty", reqs: nil, want: nil},
+		{name: "fits in one batch", reqs: reqs(20, 20, 20), want: [][]int{{0, 1, 2}}},
+		{
+			name: "split by cost",
+			reqs: reqs(100, 100, 20),
+			want: [][]int{{0}, {1, 2}},
+		},
+		{
+			name: "oversized search gets its own batch",
+			reqs: reqs(20, 500, 20),
+			want: [][]int{{0}, {1}, {2}},
+		},
+		{
+			name: "split by count",
+			reqs: reqs(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1),
+			want: [][]int{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, {10}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, splitBatches(tt.reqs, prConnections))
+		})
+	}
+}
+
+func TestFetchPullRequestsBatch(t *testing.T) {
+	originalClient := client
+	t.Cleanup(func() {
+		client = originalClient
+	})
+
+	var query string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Query     string
+			Variables map[string]any
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		query = body.Query
+		require.Equal(t, "is:pr archived:false author:@me sort:updated", body.Variables["query0"])
+		require.Equal(t, "is:pr archived:false review-requested:@me sort:updated", body.Variables["query1"])
+		require.Equal(t, "is:pr archived:false nope sort:updated", body.Variables["query2"])
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {
+					"s0": {"nodes": [{"number": 1, "url": "https://github.com/o/r/pull/1"}], "issueCount": 1},
+					"s1": {"nodes": [
+						{"number": 1, "url": "https://github.com/o/r/pull/1"},
+						{"number": 2, "url": "https://github.com/o/r/pull/2"}
+					], "issueCount": 2, "pageInfo": {"hasNextPage": true, "endCursor": "abc"}},
+					"s2": null
+				},
+				"errors": [{"message": "invalid search", "path": ["s2"]}]
+			}`)),
+		}, nil
+	})
+	var err error
+	client, err = gh.NewGraphQLClient(gh.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "token",
+		Transport: transport,
+	})
+	require.NoError(t, err)
+
+	results := FetchPullRequestsBatch([]SearchRequest{
+		{Query: "author:@me", Limit: 10},
+		{Query: "review-requested:@me", Limit: 10},
+		{Query: "nope", Limit: 10},
+	})
+
+	require.Contains(t, query, "s0: search(")
+	require.Contains(t, query, "s1: search(")
+	require.Len(t, results, 3)
+
+	require.NoError(t, results[0].Err)
+	require.Len(t, results[0].Response.Prs, 1)
+	require.Equal(t, 1, results[0].Response.TotalCount)
+
+	require.NoError(t, results[1].Err)
+	require.Len(t, results[1].Response.Prs, 2)
+	require.True(t, results[1].Response.PageInfo.HasNextPage)
+	require.Equal(t, "abc", results[1].Response.PageInfo.EndCursor)
+
+	require.EqualError(t, results[2].Err, "invalid search")
+}
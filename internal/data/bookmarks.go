@@ -92,34 +92,7 @@ func (s *NotificationIDStore) save() error {
 		idList = append(idList, id)
 	}
 
-	data, err := json.MarshalIndent(idList, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	dir := filepath.Dir(s.filePath)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return err
-	}
-
-	// Use atomic write: write to temp file, then rename.
-	// This prevents races when multiple async saves run concurrently.
-	tmpFile, err := os.CreateTemp(dir, ".tmp-*")
-	if err != nil {
-		return err
-	}
-	tmpPath := tmpFile.Name()
-	if _, err := tmpFile.Write(data); err != nil {
-		tmpFile.Close()
-		os.Remove(tmpPath)
-		return err
-	}
-	if err := tmpFile.Close(); err != nil {
-		os.Remove(tmpPath)
-		return err
-	}
-	if err := os.Rename(tmpPath, s.filePath); err != nil {
-		os.Remove(tmpPath)
+	if err := writeStateFileAtomic(s.filePath, idList); err != nil {
 		return err
 	}
 
@@ -164,6 +137,23 @@ func (s *NotificationIDStore) Toggle(id string) bool {
 	return newState
 }
 
+// AddAll adds the IDs missing from the store and returns how many were added
+func (s *NotificationIDStore) AddAll(ids []string) int {
+	s.mu.Lock()
+	added := 0
+	for _, id := range ids {
+		if !s.ids[id] {
+			s.ids[id] = true
+			added++
+		}
+	}
+	s.mu.Unlock()
+	if added > 0 {
+		go s.save() // Async save to avoid blocking UI
+	}
+	return added
+}
+
 // GetAll returns all IDs in the store
 func (s *NotificationIDStore) GetAll() []string {
 	s.mu.RLock()
@@ -2,7 +2,6 @@ package data
 
 import (
 	"charm.land/log/v2"
-	gh "github.com/cli/go-gh/v2/pkg/api"
 	graphql "github.com/cli/shurcooL-graphql"
 )
 
@@ -18,7 +17,7 @@ func FetchLatestVersion() (VersionResponse, error) {
 	var queryResult VersionResponse
 	var err error
 	if client == nil {
-		client, err = gh.DefaultGraphQLClient()
+		client, err = newGraphQLClient()
 	}
 	if err != nil {
 		return VersionResponse{}, err
@@ -62,7 +61,7 @@ func FetchSponsors() (SponsorsResponse, error) {
 	var queryResult SponsorsResponse
 	var err error
 	if client == nil {
-		client, err = gh.DefaultGraphQLClient()
+		client, err = newGraphQLClient()
 	}
 	if err != nil {
 		return SponsorsResponse{}, err
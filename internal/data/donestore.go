@@ -2,14 +2,18 @@ package data
 
 import (
 	"encoding/json"
+	"maps"
 	"os"
-	"path/filepath"
 	"sync"
 	"time"
 
 	"charm.land/log/v2"
 )
 
+// doneEntryMaxAge is how long done entries are kept. Older notifications are
+// unlikely to still appear in API responses.
+const doneEntryMaxAge = 90 * 24 * time.Hour
+
 // DoneStore persists notification IDs along with the timestamp at which they
 // were marked done. When checking whether a notification is still "done" we
 // compare the stored timestamp against the notification's current updated_at:
@@ -97,7 +101,7 @@ func (s *DoneStore) load() error {
 // prune removes stale entries on load. It deletes entries older than 90 days
 // and zero-time entries (legacy format with no timestamp).
 func (s *DoneStore) prune() {
-	cutoff := time.Now().Add(-90 * 24 * time.Hour)
+	cutoff := time.Now().Add(-doneEntryMaxAge)
 	for id, t := range s.entries {
 		if t.IsZero() || t.Before(cutoff) {
 			delete(s.entries, id)
@@ -118,32 +122,7 @@ func (s *DoneStore) save() error {
 		tsMap[id] = t.Format(time.RFC3339)
 	}
 
-	data, err := json.Marshal(tsMap)
-	if err != nil {
-		return err
-	}
-
-	dir := filepath.Dir(s.filePath)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return err
-	}
-
-	tmpFile, err := os.CreateTemp(dir, ".tmp-*")
-	if err != nil {
-		return err
-	}
-	tmpPath := tmpFile.Name()
-	if _, err := tmpFile.Write(data); err != nil {
-		tmpFile.Close()
-		os.Remove(tmpPath)
-		return err
-	}
-	if err := tmpFile.Close(); err != nil {
-		os.Remove(tmpPath)
-		return err
-	}
-	if err := os.Rename(tmpPath, s.filePath); err != nil {
-		os.Remove(tmpPath)
+	if err := writeStateFileAtomic(s.filePath, tsMap); err != nil {
 		return err
 	}
 
@@ -173,14 +152,50 @@ func (s *DoneStore) IsDone(id string, updatedAt time.Time) bool {
 	return !updatedAt.After(doneAt)
 }
 
-// Remove removes a notification from the done store.
+// Remove removes a notification from the done store. The notifications polls
+// are invalidated so the notification shows up again on the next fetch.
 func (s *DoneStore) Remove(id string) {
 	s.mu.Lock()
 	delete(s.entries, id)
 	s.mu.Unlock()
+	invalidateNotificationPolls()
 	go s.save()
 }
 
+// GetEntries returns the done notifications, mapped to the updated_at they
+// were marked done at.
+func (s *DoneStore) GetEntries() map[string]time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.entries)
+}
+
+// Merge adds the given entries, keeping the latest timestamp of the entries
+// present in both. Entries old enough to be pruned are skipped. It returns
+// how many entries were added or updated, and invalidates the notifications
+// polls if any were so the next fetch hides them.
+func (s *DoneStore) Merge(entries map[string]time.Time) int {
+	cutoff := time.Now().Add(-doneEntryMaxAge)
+	s.mu.Lock()
+	merged := 0
+	for id, t := range entries {
+		if t.IsZero() || t.Before(cutoff) {
+			continue
+		}
+		if doneAt, ok := s.entries[id]; ok && !t.After(doneAt) {
+			continue
+		}
+		s.entries[id] = t
+		merged++
+	}
+	s.mu.Unlock()
+	if merged > 0 {
+		invalidateNotificationPolls()
+		go s.save()
+	}
+	return merged
+}
+
 // Flush forces an immediate synchronous save.
 func (s *DoneStore) Flush() error {
 	return s.save()
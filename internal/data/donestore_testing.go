@@ -1,6 +1,7 @@
 package data
 
 import (
+	"path/filepath"
 	"time"
 )
 
@@ -21,3 +22,88 @@ func OverrideDoneStoreForTesting(store *DoneStore) func() {
 	doneStore = store
 	return func() { doneStore = old }
 }
+
+// NewBookmarkStoreForTesting creates a bookmark store backed by the given file
+// path.
+func NewBookmarkStoreForTesting(filePath string) *NotificationIDStore {
+	return &NotificationIDStore{
+		ids:      make(map[string]bool),
+		filePath: filePath,
+		name:     "bookmarks",
+	}
+}
+
+// OverrideBookmarkStoreForTesting replaces the singleton bookmark store with
+// the given store. It returns a function that restores the original store.
+func OverrideBookmarkStoreForTesting(store *NotificationIDStore) func() {
+	GetBookmarkStore()
+	old := bookmarkStore
+	bookmarkStore = store
+	return func() { bookmarkStore = old }
+}
+
+// NewSnoozeStoreForTesting creates a SnoozeStore backed by the given file path
+// whose clock is now.
+func NewSnoozeStoreForTesting(filePath string, now func() time.Time) *SnoozeStore {
+	return &SnoozeStore{
+		entries:  make(map[string]SnoozeEntry),
+		filePath: filePath,
+		now:      now,
+	}
+}
+
+// OverrideSnoozeStoreForTesting replaces the singleton SnoozeStore with the
+// given store. It returns a function that restores the original store.
+func OverrideSnoozeStoreForTesting(store *SnoozeStore) func() {
+	GetSnoozeStore()
+	old := snoozeStore
+	snoozeStore = store
+	return func() { snoozeStore = old }
+}
+
+// NewMuteStoreForTesting creates a MuteStore backed by the given file path.
+func NewMuteStoreForTesting(filePath string) *MuteStore {
+	return &MuteStore{filePath: filePath}
+}
+
+// OverrideMuteStoreForTesting replaces the singleton MuteStore with the given
+// store. It returns a function that restores the original store.
+func OverrideMuteStoreForTesting(store *MuteStore) func() {
+	GetMuteStore()
+	old := muteStore
+	muteStore = store
+	return func() { muteStore = old }
+}
+
+// OverrideStateStoresForTesting replaces the done, bookmark, snooze and mute
+// stores with empty ones saved in dir, as if on another machine. It returns a
+// function that restores the original stores.
+//
+// With an empty dir the stores are never saved, as the ones the constructors
+// above create from an empty path, so their background saves can't race with
+// a test removing its temp dir.
+func OverrideStateStoresForTesting(dir string) func() {
+	path := func(filename string) string {
+		if dir == "" {
+			return ""
+		}
+		return filepath.Join(dir, filename)
+	}
+	restores := []func(){
+		OverrideDoneStoreForTesting(NewDoneStoreForTesting(path("done.json"))),
+		OverrideBookmarkStoreForTesting(NewBookmarkStoreForTesting(path("bookmarks.json"))),
+		OverrideSnoozeStoreForTesting(NewSnoozeStoreForTesting(path("snoozed.json"), time.Now)),
+		OverrideMuteStoreForTesting(NewMuteStoreForTesting(path("muted.json"))),
+	}
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
+
+// CacheCommentAuthorForTesting records the author of the comment at apiUrl,
+// so looking it up doesn't hit the API.
+func CacheCommentAuthorForTesting(apiUrl string, author string) {
+	commentAuthors.Store(apiUrl, author)
+}
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
+)
+
+var (
+	hostClientsMu      sync.Mutex
+	hostGraphQLClients = make(map[string]*gh.GraphQLClient)
+	hostRESTClients    = make(map[string]*gh.RESTClient)
+)
+
+// DefaultHost returns the host gh is configured to use, i.e. GH_HOST or the
+// host gh is logged in to, falling back to github.com. Sections that don't
+// set a host query it.
+var DefaultHost = sync.OnceValue(func() string {
+	host, _ := auth.DefaultHost()
+	return host
+})
+
+// IsDefaultHost returns true if host is empty or the default host.
+func IsDefaultHost(host string) bool {
+	return host == "" || strings.EqualFold(host, DefaultHost())
+}
+
+// SameHost returns true if a and b are the same host, where an empty host is
+// the default one.
+func SameHost(a, b string) bool {
+	if IsDefaultHost(a) || IsDefaultHost(b) {
+		return IsDefaultHost(a) && IsDefaultHost(b)
+	}
+	return strings.EqualFold(a, b)
+}
+
+// HostFromUrl returns the host of a PR, issue or repo URL, e.g.
+// "github.example.com" for "https://github.example.com/owner/repo/pull/1".
+func HostFromUrl(rawUrl string) string {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
+
+// RowHost returns the host of the row, or an empty string for the default host.
+func RowHost(row RowData) string {
+	host := HostFromUrl(row.GetUrl())
+	if IsDefaultHost(host) {
+		return ""
+	}
+	return host
+}
+
+// RepoArg returns the value for gh's --repo flag for the row: OWNER/REPO on
+// the default host and HOST/OWNER/REPO on any other.
+func RepoArg(row RowData) string {
+	if host := RowHost(row); host != "" {
+		return host + "/" + row.GetRepoNameWithOwner()
+	}
+	return row.GetRepoNameWithOwner()
+}
+
+// HostKey returns the key of a notification thread id or repository in the
+// local stores. Ids are only unique per host, so the keys of any host but the
+// default one are prefixed with it, e.g. "github.example.com/123". Keys of the
+// default host aren't, which keeps the state files written before working.
+func HostKey(host, key string) string {
+	if IsDefaultHost(host) {
+		return key
+	}
+	return strings.ToLower(host) + "/" + key
+}
+
+// SplitHostKey splits a thread key made by HostKey into its host, empty for
+// the default host, and the thread id.
+func SplitHostKey(threadKey string) (host, id string) {
+	if host, id, ok := strings.Cut(threadKey, "/"); ok {
+		return host, id
+	}
+	return "", threadKey
+}
+
+func hostClientOptions(host string) (gh.ClientOptions, error) {
+	token, _ := auth.TokenForHost(host)
+	if token == "" {
+		return gh.ClientOptions{}, fmt.Errorf(
+			"not logged in to %s, run `gh auth login --hostname %s`", host, host)
+	}
+	opts := defaultClientOptions()
+	opts.Host = host
+	opts.AuthToken = token
+	return opts, nil
+}
+
+// getGraphQLClient returns the GraphQL client for host, authenticated with the
+// token gh has for it. The default host uses the shared search client.
+func getGraphQLClient(host string) (*gh.GraphQLClient, error) {
+	if IsDefaultHost(host) {
+		if err := initSearchClient(); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+
+	hostClientsMu.Lock()
+	defer hostClientsMu.Unlock()
+
+	if c, ok := hostGraphQLClients[host]; ok {
+		return c, nil
+	}
+	opts, err := hostClientOptions(host)
+	if err != nil {
+		return nil, err
+	}
+	c, err := gh.NewGraphQLClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	hostGraphQLClients[host] = c
+	return c, nil
+}
+
+// getHostRESTClient is the REST counterpart of getGraphQLClient.
+func getHostRESTClient(host string) (*gh.RESTClient, error) {
+	if IsDefaultHost(host) {
+		return getRESTClient()
+	}
+
+	hostClientsMu.Lock()
+	defer hostClientsMu.Unlock()
+
+	if c, ok := hostRESTClients[host]; ok {
+		return c, nil
+	}
+	opts, err := hostClientOptions(host)
+	if err != nil {
+		return nil, err
+	}
+	c, err := gh.NewRESTClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	hostRESTClients[host] = c
+	return c, nil
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoArg(t *testing.T) {
+	defaultRow := PullRequestData{
+		Url:        "https://" + DefaultHost() + "/owner/repo/pull/1",
+		Repository: Repository{NameWithOwner: "owner/repo"},
+	}
+	require.Empty(t, RowHost(defaultRow))
+	require.Equal(t, "owner/repo", RepoArg(defaultRow))
+
+	enterpriseRow := PullRequestData{
+		Url:        "https://ghe.example.com/owner/repo/pull/1",
+		Repository: Repository{NameWithOwner: "owner/repo"},
+	}
+	require.Equal(t, "ghe.example.com", RowHost(enterpriseRow))
+	require.Equal(t, "ghe.example.com/owner/repo", RepoArg(enterpriseRow))
+}
+
+func TestHostKey(t *testing.T) {
+	require.Equal(t, "123", HostKey("", "123"))
+	require.Equal(t, "123", HostKey(DefaultHost(), "123"))
+	require.Equal(t, "ghe.example.com/123", HostKey("GHE.example.com", "123"))
+
+	host, id := SplitHostKey("ghe.example.com/123")
+	require.Equal(t, "ghe.example.com", host)
+	require.Equal(t, "123", id)
+	host, id = SplitHostKey("123")
+	require.Empty(t, host)
+	require.Equal(t, "123", id)
+
+	require.True(t, SameHost("", DefaultHost()))
+	require.True(t, SameHost("ghe.example.com", "GHE.example.com"))
+	require.False(t, SameHost("", "ghe.example.com"))
+
+	n := NotificationData{
+		Id:         "123",
+		Repository: NotificationRepository{HtmlUrl: "https://ghe.example.com/owner/repo"},
+	}
+	require.Equal(t, "ghe.example.com/123", n.StoreKey())
+}
+
+func TestGroupByHost(t *testing.T) {
+	groups := groupByHost([]SearchRequest{
+		{Query: "a"},
+		{Host: "ghe.example.com", Query: "b"},
+		{Host: DefaultHost(), Query: "c"},
+		{Host: "GHE.example.com", Query: "d"},
+	})
+	require.Equal(t, [][]int{{0, 2}, {1, 3}}, groups)
+}
+
+func TestFetchPullRequestsBatch_MultipleHosts(t *testing.T) {
+	originalClient := client
+	t.Cleanup(func() {
+		client = originalClient
+		delete(hostGraphQLClients, "ghe.example.com")
+	})
+
+	newClient := func(host string, nodeUrl string) *gh.GraphQLClient {
+		transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Variables map[string]any
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			require.Len(t, body.Variables, 3, "only the searches of %s are batched", host)
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: io.NopCloser(strings.NewReader(`{"data": {
+					"s0": {"nodes": [{"number": 1, "url": "` + nodeUrl + `"}], "issueCount": 1}
+				}}`)),
+			}, nil
+		})
+		c, err := gh.NewGraphQLClient(gh.ClientOptions{
+			Host:      host,
+			AuthToken: "token",
+			Transport: transport,
+		})
+		require.NoError(t, err)
+		return c
+	}
+
+	client = newClient("github.com", "https://github.com/o/r/pull/1")
+	hostGraphQLClients["ghe.example.com"] = newClient(
+		"ghe.example.com", "https://ghe.example.com/o/r/pull/1")
+
+	results := FetchPullRequestsBatch([]SearchRequest{
+		{Host: "ghe.example.com", Query: "author:@me", Limit: 10},
+		{Query: "author:@me", Limit: 10},
+	})
+
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, "https://ghe.example.com/o/r/pull/1", results[0].Response.Prs[0].Url)
+	require.NoError(t, results[1].Err)
+	require.Equal(t, "https://github.com/o/r/pull/1", results[1].Response.Prs[0].Url)
+}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"charm.land/log/v2"
-	gh "github.com/cli/go-gh/v2/pkg/api"
 	graphql "github.com/cli/shurcooL-graphql"
 	"github.com/shurcooL/githubv4"
 
@@ -99,12 +98,14 @@ func makeIssuesQuery(query string) string {
 	return fmt.Sprintf("is:issue archived:false %s sort:updated", query)
 }
 
-func FetchIssues(query string, limit int, pageInfo *PageInfo) (IssuesResponse, error) {
-	var err error
-	if client == nil {
-		client, err = gh.DefaultGraphQLClient()
-	}
-
+// FetchIssues searches for issues on host, or on the default host if empty.
+func FetchIssues(
+	host string,
+	query string,
+	limit int,
+	pageInfo *PageInfo,
+) (IssuesResponse, error) {
+	client, err := getGraphQLClient(host)
 	if err != nil {
 		return IssuesResponse{}, err
 	}
@@ -127,7 +128,7 @@ func FetchIssues(query string, limit int, pageInfo *PageInfo) (IssuesResponse, e
 		"limit":     graphql.Int(limit),
 		"endCursor": (*graphql.String)(endCursor),
 	}
-	log.Debug("Fetching issues", "query", query, "limit", limit, "endCursor", endCursor)
+	log.Debug("Fetching issues", "host", host, "query", query, "limit", limit, "endCursor", endCursor)
 	err = client.Query("SearchIssues", &queryResult, variables)
 	if err != nil {
 		return IssuesResponse{}, err
@@ -154,12 +155,9 @@ type IssuesResponse struct {
 
 // FetchIssue fetches a single issue by its GitHub URL
 func FetchIssue(issueUrl string) (IssueData, error) {
-	var err error
-	if client == nil {
-		client, err = gh.DefaultGraphQLClient()
-		if err != nil {
-			return IssueData{}, err
-		}
+	client, err := getGraphQLClient(HostFromUrl(issueUrl))
+	if err != nil {
+		return IssueData{}, err
 	}
 
 	var queryResult struct {
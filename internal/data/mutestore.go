@@ -0,0 +1,147 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"charm.land/log/v2"
+)
+
+// MuteRule hides the notifications of a repository, or only the ones with
+// Reason if it's set. Host is empty for a repository on the default host.
+type MuteRule struct {
+	Host   string `json:"host,omitempty"`
+	Repo   string `json:"repo"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Matches returns true if the rule mutes a notification of the repository
+// on host with the given reason.
+func (r MuteRule) Matches(host, repo, reason string) bool {
+	return SameHost(r.Host, host) && strings.EqualFold(r.Repo, repo) &&
+		(r.Reason == "" || r.Reason == reason)
+}
+
+// MuteStore persists the mute rules. Muting is done client-side: GitHub
+// still sends the notifications, they're just not shown.
+type MuteStore struct {
+	mu       sync.RWMutex
+	rules    []MuteRule
+	filePath string
+}
+
+func newMuteStore(filename string) *MuteStore {
+	store := &MuteStore{}
+	filePath, err := getStateFilePath(filename)
+	if err != nil {
+		log.Error("Failed to get state file path for mute rules", "err", err)
+	}
+	store.filePath = filePath
+	if err := store.load(); err != nil {
+		log.Error("Failed to load mute rules", "err", err)
+	}
+	return store
+}
+
+func (s *MuteStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.filePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := json.Unmarshal(data, &s.rules); err != nil {
+		return err
+	}
+	log.Debug("Loaded mute rules", "count", len(s.rules))
+	return nil
+}
+
+func (s *MuteStore) save() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.filePath == "" {
+		return nil
+	}
+
+	if err := writeStateFileAtomic(s.filePath, s.rules); err != nil {
+		return err
+	}
+
+	log.Debug("Saved mute rules", "count", len(s.rules))
+	return nil
+}
+
+// Mute adds a mute rule, unless it's already there.
+func (s *MuteStore) Mute(rule MuteRule) {
+	s.mu.Lock()
+	if slices.Contains(s.rules, rule) {
+		s.mu.Unlock()
+		return
+	}
+	s.rules = append(s.rules, rule)
+	s.mu.Unlock()
+	go s.save()
+}
+
+// Unmute removes a mute rule. The notifications polls are invalidated so the
+// notifications it muted show up again on the next fetch.
+func (s *MuteStore) Unmute(rule MuteRule) {
+	s.mu.Lock()
+	s.rules = slices.DeleteFunc(s.rules, func(r MuteRule) bool {
+		return r == rule
+	})
+	s.mu.Unlock()
+	invalidateNotificationPolls()
+	go s.save()
+}
+
+// IsMuted returns true if a rule mutes the notifications of the repository
+// on host with the given reason.
+func (s *MuteStore) IsMuted(host, repo, reason string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.ContainsFunc(s.rules, func(r MuteRule) bool {
+		return r.Matches(host, repo, reason)
+	})
+}
+
+// GetRules returns the mute rules in the order they were added.
+func (s *MuteStore) GetRules() []MuteRule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.rules)
+}
+
+// Flush forces an immediate synchronous save.
+func (s *MuteStore) Flush() error {
+	return s.save()
+}
+
+// Singleton
+
+var (
+	muteStore     *MuteStore
+	muteStoreOnce sync.Once
+)
+
+// GetMuteStore returns the singleton mute store.
+func GetMuteStore() *MuteStore {
+	muteStoreOnce.Do(func() {
+		muteStore = newMuteStore("muted.json")
+	})
+	return muteStore
+}
@@ -0,0 +1,72 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMuteStore(t *testing.T) {
+	tempDir := t.TempDir()
+
+	t.Run("Mutes a repository or one of its reasons", func(t *testing.T) {
+		store := NewMuteStoreForTesting("")
+		store.Mute(MuteRule{Repo: "o/a"})
+		store.Mute(MuteRule{Repo: "o/b", Reason: ReasonCIActivity})
+
+		if !store.IsMuted("", "O/A", ReasonMention) {
+			t.Error("Muting a repository should mute all of its notifications")
+		}
+		if !store.IsMuted("", "o/b", ReasonCIActivity) {
+			t.Error("Muting CI should mute CI notifications")
+		}
+		if store.IsMuted("", "o/b", ReasonMention) {
+			t.Error("Muting CI should NOT mute other notifications")
+		}
+		if store.IsMuted("", "o/c", ReasonCIActivity) {
+			t.Error("Rules should NOT mute other repositories")
+		}
+	})
+
+	t.Run("Mutes a repository on its host only", func(t *testing.T) {
+		store := NewMuteStoreForTesting("")
+		store.Mute(MuteRule{Host: "github.example.com", Repo: "o/a"})
+
+		if !store.IsMuted("GitHub.example.com", "o/a", ReasonMention) {
+			t.Error("Rules should mute the repository on their host")
+		}
+		if store.IsMuted("", "o/a", ReasonMention) {
+			t.Error("Rules should NOT mute the repository on other hosts")
+		}
+	})
+
+	t.Run("Unmute", func(t *testing.T) {
+		store := NewMuteStoreForTesting("")
+		rule := MuteRule{Repo: "o/a"}
+		store.Mute(rule)
+		store.Mute(rule)
+		if len(store.GetRules()) != 1 {
+			t.Errorf("GetRules() = %v, want one rule", store.GetRules())
+		}
+		store.Unmute(rule)
+		if store.IsMuted("", "o/a", ReasonMention) {
+			t.Error("Should NOT be muted after unmuting")
+		}
+	})
+
+	t.Run("Persists rules", func(t *testing.T) {
+		filePath := filepath.Join(tempDir, "test3.json")
+		store := NewMuteStoreForTesting(filePath)
+		store.rules = []MuteRule{{Repo: "o/a"}, {Repo: "o/b", Reason: ReasonCIActivity}}
+		if err := store.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+
+		loaded := NewMuteStoreForTesting(filePath)
+		if err := loaded.load(); err != nil {
+			t.Fatalf("load() error = %v", err)
+		}
+		if got := loaded.GetRules(); len(got) != 2 || got[1].Reason != ReasonCIActivity {
+			t.Errorf("loaded rules = %v, want the saved ones", got)
+		}
+	})
+}
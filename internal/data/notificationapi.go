@@ -3,6 +3,7 @@ package data
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"charm.land/log/v2"
@@ -94,10 +95,19 @@ func (n NotificationData) GetCreatedAt() time.Time {
 	return n.UpdatedAt
 }
 
+// StoreKey returns the key of the notification in the done, snooze and
+// bookmark stores.
+func (n NotificationData) StoreKey() string {
+	return HostKey(RowHost(n), n.Id)
+}
+
 type NotificationsResponse struct {
 	Notifications []NotificationData
 	TotalCount    int
 	PageInfo      PageInfo
+	// NotModified is true if none of the notifications changed since they
+	// were last fetched.
+	NotModified bool
 }
 
 func getRESTClient() (*gh.RESTClient, error) {
@@ -105,7 +115,7 @@ func getRESTClient() (*gh.RESTClient, error) {
 		return restClient, nil
 	}
 	var err error
-	restClient, err = gh.DefaultRESTClient()
+	restClient, err = gh.NewRESTClient(defaultClientOptions())
 	return restClient, err
 }
 
@@ -118,13 +128,16 @@ const (
 	NotificationStateAll    NotificationReadState = "all"    // Both read and unread
 )
 
+// FetchNotifications fetches a page of the notifications on host, or on the
+// default host if it's empty.
 func FetchNotifications(
+	host string,
 	limit int,
 	repoFilters []string,
 	readState NotificationReadState,
 	pageInfo *PageInfo,
 ) (NotificationsResponse, error) {
-	client, err := getRESTClient()
+	client, err := getHostRESTClient(host)
 	if err != nil {
 		return NotificationsResponse{}, err
 	}
@@ -145,43 +158,68 @@ func FetchNotifications(
 		fmt.Sscanf(pageInfo.EndCursor, "%d", &page)
 	}
 
+	var paths []string
 	if len(repoFilters) == 0 {
 		// No repo filter, fetch all notifications
-		path := fmt.Sprintf("notifications?per_page=%d&page=%d%s", limit, page, allParam)
+		paths = []string{fmt.Sprintf("notifications?per_page=%d&page=%d%s", limit, page, allParam)}
 		log.Debug("Fetching notifications", "limit", limit, "page", page, "readState", readState)
-		err = client.Get(path, &allNotifications)
-		if err != nil {
-			return NotificationsResponse{}, err
-		}
 	} else {
 		// Fetch notifications for each repo and combine
 		for _, repo := range repoFilters {
-			var repoNotifications []NotificationData
-			path := fmt.Sprintf(
+			paths = append(paths, fmt.Sprintf(
 				"repos/%s/notifications?per_page=%d&page=%d%s",
 				repo,
 				limit,
 				page,
 				allParam,
-			)
-			log.Debug(
-				"Fetching notifications for repo",
-				"repo",
-				repo,
-				"limit",
-				limit,
-				"page",
-				page,
-				"readState",
-				readState,
-			)
-			err = client.Get(path, &repoNotifications)
-			if err != nil {
-				log.Warn("Failed to fetch notifications for repo", "repo", repo, "err", err)
-				continue
+			))
+		}
+		log.Debug(
+			"Fetching notifications for repos",
+			"repos",
+			repoFilters,
+			"limit",
+			limit,
+			"page",
+			page,
+			"readState",
+			readState,
+		)
+	}
+
+	type pollResult struct {
+		notifications []NotificationData
+		modified      bool
+		err           error
+	}
+	results := make([]pollResult, len(paths))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentNotificationFetches)
+	for i, path := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			notifications, modified, err := pollNotifications(client, host, path)
+			results[i] = pollResult{notifications, modified, err}
+		}()
+	}
+	wg.Wait()
+
+	modified := false
+	for i, res := range results {
+		if res.err != nil {
+			if len(repoFilters) == 0 {
+				return NotificationsResponse{}, res.err
 			}
-			allNotifications = append(allNotifications, repoNotifications...)
+			log.Warn("Failed to fetch notifications for repo", "repo", repoFilters[i], "err", res.err)
+			// Treat failures as changes so they aren't mistaken for "no change"
+			modified = true
+			continue
 		}
+		modified = modified || res.modified
+		allNotifications = append(allNotifications, res.notifications...)
 	}
 
 	// Determine if there's a next page BEFORE filtering (based on raw API response count).
@@ -237,14 +275,16 @@ func FetchNotifications(
 			HasNextPage: hasNextPage,
 			EndCursor:   nextPage,
 		},
+		NotModified: !modified,
 	}, nil
 }
 
 // FetchNotificationByThreadId fetches a single notification by its thread ID.
 // This is useful for fetching bookmarked or session-marked-read notifications
-// that may not appear in the regular notifications list.
-func FetchNotificationByThreadId(threadId string) (*NotificationData, error) {
-	client, err := getRESTClient()
+// that may not appear in the regular notifications list. Like every thread
+// ID, it's looked up on host, or on the default host if it's empty.
+func FetchNotificationByThreadId(host string, threadId string) (*NotificationData, error) {
+	client, err := getHostRESTClient(host)
 	if err != nil {
 		return nil, err
 	}
@@ -261,8 +301,8 @@ func FetchNotificationByThreadId(threadId string) (*NotificationData, error) {
 	return &notification, nil
 }
 
-func MarkNotificationDone(threadId string) error {
-	client, err := getRESTClient()
+func MarkNotificationDone(host string, threadId string) error {
+	client, err := getHostRESTClient(host)
 	if err != nil {
 		return err
 	}
@@ -275,12 +315,13 @@ func MarkNotificationDone(threadId string) error {
 	if err != nil {
 		return err
 	}
+	invalidateNotificationPolls()
 	log.Info("Successfully marked notification as done", "threadId", threadId)
 	return nil
 }
 
-func MarkNotificationRead(threadId string) error {
-	client, err := getRESTClient()
+func MarkNotificationRead(host string, threadId string) error {
+	client, err := getHostRESTClient(host)
 	if err != nil {
 		return err
 	}
@@ -294,12 +335,13 @@ func MarkNotificationRead(threadId string) error {
 	if err != nil && err.Error() != "unexpected end of JSON input" {
 		return err
 	}
+	invalidateNotificationPolls()
 	log.Info("Successfully marked notification as read", "threadId", threadId)
 	return nil
 }
 
-func UnsubscribeFromThread(threadId string) error {
-	client, err := getRESTClient()
+func UnsubscribeFromThread(host string, threadId string) error {
+	client, err := getHostRESTClient(host)
 	if err != nil {
 		return err
 	}
@@ -313,12 +355,13 @@ func UnsubscribeFromThread(threadId string) error {
 	if err != nil && err.Error() != "unexpected end of JSON input" {
 		return err
 	}
+	invalidateNotificationPolls()
 	log.Info("Successfully unsubscribed from thread", "threadId", threadId)
 	return nil
 }
 
-func MarkAllNotificationsRead() error {
-	client, err := getRESTClient()
+func MarkAllNotificationsRead(host string) error {
+	client, err := getHostRESTClient(host)
 	if err != nil {
 		return err
 	}
@@ -331,17 +374,26 @@ func MarkAllNotificationsRead() error {
 	if err != nil && err.Error() != "unexpected end of JSON input" {
 		return err
 	}
+	invalidateNotificationPolls()
 	log.Info("Successfully marked all notifications as read")
 	return nil
 }
 
-// CommentResponse represents a GitHub comment with author info
+// CommentResponse represents a GitHub comment with author info. Issues and
+// PRs have the same shape, and releases have an author instead of a user.
 type CommentResponse struct {
 	User struct {
 		Login string `json:"login"`
 	} `json:"user"`
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
 }
 
+// commentAuthors caches comment authors by API URL, as they never change and
+// both notification enrichment and author: filters look them up.
+var commentAuthors sync.Map
+
 // WorkflowRun represents a GitHub Actions workflow run
 type WorkflowRun struct {
 	Id         int64     `json:"id"`
@@ -360,21 +412,30 @@ type WorkflowRunsResponse struct {
 
 // FetchCommentAuthor fetches the author of a comment from its API URL
 // apiUrl is like: https://api.github.com/repos/owner/repo/issues/comments/123456
+// or https://github.example.com/api/v3/repos/owner/repo/issues/comments/123456
+// It also works with the API URLs of issues, PRs and releases.
 func FetchCommentAuthor(apiUrl string) (string, error) {
 	if apiUrl == "" {
 		return "", nil
 	}
-
-	client, err := getRESTClient()
-	if err != nil {
-		return "", err
+	if author, ok := commentAuthors.Load(apiUrl); ok {
+		return author.(string), nil
 	}
 
 	// Extract the path from the full URL
 	const apiPrefix = "https://api.github.com/"
 	path := apiUrl
+	host := ""
 	if len(apiUrl) > len(apiPrefix) && apiUrl[:len(apiPrefix)] == apiPrefix {
 		path = apiUrl[len(apiPrefix):]
+	} else {
+		// Enterprise API URLs are requested as is with the client of their host
+		host = HostFromUrl(apiUrl)
+	}
+
+	client, err := getHostRESTClient(host)
+	if err != nil {
+		return "", err
 	}
 
 	var response CommentResponse
@@ -384,7 +445,12 @@ func FetchCommentAuthor(apiUrl string) (string, error) {
 		return "", err
 	}
 
-	return response.User.Login, nil
+	author := response.User.Login
+	if author == "" {
+		author = response.Author.Login
+	}
+	commentAuthors.Store(apiUrl, author)
+	return author, nil
 }
 
 // FindBestWorkflowRunMatch finds the workflow run closest in time to the notification.
@@ -0,0 +1,169 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"charm.land/log/v2"
+	gh "github.com/cli/go-gh/v2/pkg/api"
+)
+
+// maxConcurrentNotificationFetches bounds how many repo: filters of a
+// notifications section are fetched at once.
+const maxConcurrentNotificationFetches = 4
+
+// notificationPoll is the last response to a notifications request. GitHub
+// asks clients to poll notifications with If-Modified-Since and no more often
+// than X-Poll-Interval, and doesn't count 304 responses against the rate limit.
+type notificationPoll struct {
+	lastModified  string
+	pollInterval  time.Duration
+	polledAt      time.Time
+	notifications []NotificationData
+}
+
+// notificationPollKey is the host and path of a notifications request
+type notificationPollKey struct {
+	host string
+	path string
+}
+
+var notificationPolls = struct {
+	sync.Mutex
+	byKey map[notificationPollKey]notificationPoll
+}{byKey: make(map[notificationPollKey]notificationPoll)}
+
+func getNotificationPoll(key notificationPollKey) (notificationPoll, bool) {
+	notificationPolls.Lock()
+	defer notificationPolls.Unlock()
+	poll, ok := notificationPolls.byKey[key]
+	return poll, ok
+}
+
+func setNotificationPoll(key notificationPollKey, poll notificationPoll) {
+	notificationPolls.Lock()
+	defer notificationPolls.Unlock()
+	notificationPolls.byKey[key] = poll
+}
+
+// invalidateNotificationPolls forgets the previous notifications responses,
+// so the next fetch after marking notifications read or done sees the change
+// instead of waiting for the poll interval.
+func invalidateNotificationPolls() {
+	notificationPolls.Lock()
+	defer notificationPolls.Unlock()
+	clear(notificationPolls.byKey)
+}
+
+// ExpireNotificationPolls lets the next fetches poll GitHub even when the poll
+// interval didn't pass yet, e.g. when the user refreshes. They still send
+// If-Modified-Since, so the notifications aren't downloaded again unless they
+// changed.
+func ExpireNotificationPolls() {
+	notificationPolls.Lock()
+	defer notificationPolls.Unlock()
+	for key, poll := range notificationPolls.byKey {
+		poll.polledAt = time.Time{}
+		notificationPolls.byKey[key] = poll
+	}
+}
+
+func parsePollInterval(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("X-Poll-Interval"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// pollNotifications GETs a notifications list of host with its client,
+// reusing the previous response when it's younger than the poll interval or
+// GitHub answers 304 Not Modified. It returns false if the notifications
+// didn't change since the last poll.
+func pollNotifications(
+	client *gh.RESTClient,
+	host string,
+	path string,
+) ([]NotificationData, bool, error) {
+	key := notificationPollKey{host: host, path: path}
+	poll, polled := getNotificationPoll(key)
+	if polled && time.Since(poll.polledAt) < poll.pollInterval {
+		log.Debug("Skipping notifications poll until the poll interval passes", "path", path,
+			"interval", poll.pollInterval)
+		return poll.notifications, false, nil
+	}
+
+	ctx := context.Background()
+	if polled && poll.lastModified != "" {
+		ctx = withRequestHeaders(ctx, http.Header{"If-Modified-Since": {poll.lastModified}})
+	}
+
+	res, err := client.RequestWithContext(ctx, http.MethodGet, path, nil)
+	var httpErr *gh.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotModified {
+		log.Debug("Notifications not modified", "path", path)
+		poll.polledAt = time.Now()
+		poll.pollInterval = parsePollInterval(httpErr.Headers)
+		setNotificationPoll(key, poll)
+		return poll.notifications, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	defer res.Body.Close()
+
+	var notifications []NotificationData
+	if err := json.NewDecoder(res.Body).Decode(&notifications); err != nil {
+		return nil, false, err
+	}
+	setNotificationPoll(key, notificationPoll{
+		lastModified:  res.Header.Get("Last-Modified"),
+		pollInterval:  parsePollInterval(res.Header),
+		polledAt:      time.Now(),
+		notifications: notifications,
+	})
+	return notifications, true, nil
+}
+
+// PollUnreadNotifications polls the latest unread notifications, returning
+// false if they didn't change since the last poll. Its path has no page,
+// unlike the sections' ones, so it doesn't share their poll state and
+// sections still see every change.
+func PollUnreadNotifications(limit int) ([]NotificationData, bool, error) {
+	client, err := getRESTClient()
+	if err != nil {
+		return nil, false, err
+	}
+	return pollNotifications(client, "", fmt.Sprintf("notifications?per_page=%d", limit))
+}
+
+type requestHeadersKey struct{}
+
+// withRequestHeaders returns a context whose requests are sent with the given
+// headers, as go-gh's REST client has no way to set per-request headers.
+func withRequestHeaders(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, requestHeadersKey{}, header)
+}
+
+// requestHeadersTransport adds the headers set with withRequestHeaders.
+type requestHeadersTransport struct {
+	base http.RoundTripper
+}
+
+func (t requestHeadersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	header, ok := req.Context().Value(requestHeadersKey{}).(http.Header)
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	for name, values := range header {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+	return t.base.RoundTrip(req)
+}
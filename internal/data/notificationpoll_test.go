@@ -0,0 +1,200 @@
+package data
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+const lastModified = "Thu, 15 Jan 2026 10:00:00 GMT"
+
+func newNotificationsClient(t *testing.T, handler roundTripFunc) *gh.RESTClient {
+	t.Helper()
+	c, err := gh.NewRESTClient(gh.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "token",
+		Transport: requestHeadersTransport{base: handler},
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func notificationsResponse(
+	req *http.Request,
+	status int,
+	header http.Header,
+	body string,
+) *http.Response {
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		Request:    req,
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestPollNotifications(t *testing.T) {
+	t.Cleanup(invalidateNotificationPolls)
+	invalidateNotificationPolls()
+
+	requests := 0
+	pollInterval := "0"
+	client := newNotificationsClient(t, func(req *http.Request) (*http.Response, error) {
+		requests++
+		header := http.Header{"X-Poll-Interval": {pollInterval}}
+		if req.Header.Get("If-Modified-Since") == lastModified {
+			return notificationsResponse(req, http.StatusNotModified, header, ""), nil
+		}
+		header.Set("Last-Modified", lastModified)
+		return notificationsResponse(req, http.StatusOK, header, `[{"id": "1"}]`), nil
+	})
+
+	notifications, modified, err := pollNotifications(client, "", "notifications")
+	require.NoError(t, err)
+	require.True(t, modified)
+	require.Len(t, notifications, 1)
+
+	// The second poll is conditional and reuses the first response
+	pollInterval = "60"
+	notifications, modified, err = pollNotifications(client, "", "notifications")
+	require.NoError(t, err)
+	require.False(t, modified)
+	require.Len(t, notifications, 1)
+	require.Equal(t, 2, requests)
+
+	// Polls within the poll interval don't hit the API
+	_, modified, err = pollNotifications(client, "", "notifications")
+	require.NoError(t, err)
+	require.False(t, modified)
+	require.Equal(t, 2, requests)
+
+	// Refreshing polls again, still conditionally
+	ExpireNotificationPolls()
+	notifications, modified, err = pollNotifications(client, "", "notifications")
+	require.NoError(t, err)
+	require.False(t, modified)
+	require.Len(t, notifications, 1)
+	require.Equal(t, 3, requests)
+
+	// Invalidating the polls, e.g. after marking a notification read, refetches
+	invalidateNotificationPolls()
+	_, modified, err = pollNotifications(client, "", "notifications")
+	require.NoError(t, err)
+	require.True(t, modified)
+	require.Equal(t, 4, requests)
+}
+
+func TestStoreChangesInvalidateNotificationPolls(t *testing.T) {
+	t.Cleanup(invalidateNotificationPolls)
+	// Stores without a file path aren't saved
+	now := time.Now()
+	snoozes := NewSnoozeStoreForTesting("", func() time.Time { return now })
+	mutes := NewMuteStoreForTesting("")
+	done := NewDoneStoreForTesting("")
+
+	changes := map[string]func(){
+		"snooze expired": func() {
+			snoozes.entries["1"] = SnoozeEntry{Until: now.Add(-time.Minute)}
+			snoozes.PruneExpired()
+		},
+		"unsnoozed":    func() { snoozes.Remove("1") },
+		"unmuted":      func() { mutes.Unmute(MuteRule{Repo: "o/r"}) },
+		"done synced":  func() { done.Merge(map[string]time.Time{"1": now}) },
+		"done removed": func() { done.Remove("1") },
+	}
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			key := notificationPollKey{path: "notifications"}
+			setNotificationPoll(key, notificationPoll{lastModified: lastModified})
+			change()
+			_, polled := getNotificationPoll(key)
+			require.False(t, polled, "the next fetch isn't conditional")
+		})
+	}
+}
+
+func TestFetchNotifications_RepoFilters(t *testing.T) {
+	originalClient := restClient
+	t.Cleanup(func() {
+		restClient = originalClient
+		invalidateNotificationPolls()
+	})
+	invalidateNotificationPolls()
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	restClient = newNotificationsClient(t, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		if req.Header.Get("If-Modified-Since") != "" {
+			return notificationsResponse(req, http.StatusNotModified, http.Header{}, ""), nil
+		}
+		repo := strings.Split(strings.TrimPrefix(req.URL.Path, "/repos/"), "/notifications")[0]
+		return notificationsResponse(req, http.StatusOK, http.Header{"Last-Modified": {lastModified}},
+			`[{"id": "`+repo+`", "unread": true}]`), nil
+	})
+
+	repos := []string{"o/a", "o/b", "o/c", "o/d", "o/e", "o/f"}
+	res, err := FetchNotifications("", 10, repos, NotificationStateUnread, nil)
+	require.NoError(t, err)
+	require.False(t, res.NotModified)
+	require.LessOrEqual(t, maxInFlight, maxConcurrentNotificationFetches)
+
+	ids := make([]string, 0, len(res.Notifications))
+	for _, n := range res.Notifications {
+		ids = append(ids, n.Id)
+	}
+	require.Equal(t, repos, ids, "notifications are combined in the order of the filters")
+
+	res, err = FetchNotifications("", 10, repos, NotificationStateUnread, nil)
+	require.NoError(t, err)
+	require.True(t, res.NotModified)
+	require.Len(t, res.Notifications, len(repos))
+}
+
+func TestPollUnreadNotifications(t *testing.T) {
+	originalClient := restClient
+	t.Cleanup(func() {
+		restClient = originalClient
+		invalidateNotificationPolls()
+	})
+	invalidateNotificationPolls()
+
+	var paths []string
+	restClient = newNotificationsClient(t, func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.RequestURI())
+		if req.Header.Get("If-Modified-Since") != "" {
+			return notificationsResponse(req, http.StatusNotModified, http.Header{}, ""), nil
+		}
+		return notificationsResponse(req, http.StatusOK, http.Header{"Last-Modified": {lastModified}},
+			`[{"id": "1", "unread": true}]`), nil
+	})
+
+	notifications, modified, err := PollUnreadNotifications(50)
+	require.NoError(t, err)
+	require.True(t, modified)
+	require.Len(t, notifications, 1)
+
+	// Sections poll their own pages, so they still see the notification
+	res, err := FetchNotifications("", 50, nil, NotificationStateUnread, nil)
+	require.NoError(t, err)
+	require.False(t, res.NotModified)
+	require.Equal(t, []string{
+		"/notifications?per_page=50",
+		"/notifications?per_page=50&page=1",
+	}, paths)
+}
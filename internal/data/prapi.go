@@ -44,6 +44,7 @@ type EnrichedPullRequestData struct {
 	Additions         int
 	Deletions         int
 	HeadRefName       string
+	HeadRefOid        string
 	BaseRefName       string
 	HeadRepository    struct {
 		Name string
@@ -80,6 +81,7 @@ type PullRequestData struct {
 	Additions         int
 	Deletions         int
 	HeadRefName       string
+	HeadRefOid        string
 	BaseRefName       string
 	HeadRepository    struct {
 		Name string
@@ -166,19 +168,22 @@ type StatusCheckRollupStats struct {
 }
 
 type AllCommits struct {
-	Nodes []struct {
-		Commit struct {
-			AbbreviatedOid  string
-			CommittedDate   time.Time
-			MessageHeadline string
-			Author          struct {
-				Name string
-				User struct {
-					Login string
-				}
+	Nodes []AllCommitsNode
+}
+
+type AllCommitsNode struct {
+	Commit struct {
+		Oid             string
+		AbbreviatedOid  string
+		CommittedDate   time.Time
+		MessageHeadline string
+		Author          struct {
+			Name string
+			User struct {
+				Login string
 			}
-			StatusCheckRollup StatusCheckRollupStats
 		}
+		StatusCheckRollup StatusCheckRollupStats
 	}
 }
 
@@ -460,6 +465,7 @@ func (e EnrichedPullRequestData) ToPullRequestData() PullRequestData {
 		Additions:         e.Additions,
 		Deletions:         e.Deletions,
 		HeadRefName:       e.HeadRefName,
+		HeadRefOid:        e.HeadRefOid,
 		BaseRefName:       e.BaseRefName,
 		HeadRepository:    e.HeadRepository,
 		HeadRef:           e.HeadRef,
@@ -494,9 +500,11 @@ func SetClient(c *gh.GraphQLClient) {
 }
 
 // ClearEnrichmentCache clears the cached GraphQL client used for fetching
-// enriched PR/Issue data. Call this when refreshing to ensure fresh data.
+// enriched PR/Issue data, along with the enrichments shared between sections.
+// Call this when refreshing to ensure fresh data.
 func ClearEnrichmentCache() {
 	cachedClient = nil
+	GetRowStore().ClearEnriched()
 }
 
 // IsEnrichmentCacheCleared returns true if the enrichment cache is cleared.
@@ -505,30 +513,44 @@ func IsEnrichmentCacheCleared() bool {
 	return cachedClient == nil
 }
 
-func FetchPullRequests(query string, limit int, pageInfo *PageInfo) (PullRequestsResponse, error) {
+// initSearchClient creates the GraphQL client used for searching work items,
+// honoring the mock data feature flag and debug HTTP logging.
+func initSearchClient() error {
+	if client != nil {
+		return nil
+	}
+
 	var err error
-	if client == nil {
-		if config.IsFeatureEnabled(config.FF_MOCK_DATA) {
-			log.Info("using mock data", "server", "https://localhost:3000")
-			http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{
-				InsecureSkipVerify: true,
-			}
-			client, err = gh.NewGraphQLClient(
-				gh.ClientOptions{Host: "localhost:3000", AuthToken: "fake-token"},
-			)
-		} else {
-			level := os.Getenv("LOG_LEVEL")
-			opts := gh.ClientOptions{}
-			if level == "debug" {
-				logger := NewHTTPLogger(0)
-				opts.Log = &logger
-				opts.LogVerboseHTTP = true
-				opts.LogColorize = true
-			}
-			client, err = gh.NewGraphQLClient(opts)
+	if config.IsFeatureEnabled(config.FF_MOCK_DATA) {
+		log.Info("using mock data", "server", "https://localhost:3000")
+		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
 		}
+		client, err = gh.NewGraphQLClient(
+			gh.ClientOptions{Host: "localhost:3000", AuthToken: "fake-token"},
+		)
+	} else {
+		level := os.Getenv("LOG_LEVEL")
+		opts := defaultClientOptions()
+		if level == "debug" {
+			logger := NewHTTPLogger(0)
+			opts.Log = &logger
+			opts.LogVerboseHTTP = true
+			opts.LogColorize = true
+		}
+		client, err = gh.NewGraphQLClient(opts)
 	}
+	return err
+}
 
+// FetchPullRequests searches for PRs on host, or on the default host if empty.
+func FetchPullRequests(
+	host string,
+	query string,
+	limit int,
+	pageInfo *PageInfo,
+) (PullRequestsResponse, error) {
+	client, err := getGraphQLClient(host)
 	if err != nil {
 		return PullRequestsResponse{}, err
 	}
@@ -551,7 +573,7 @@ func FetchPullRequests(query string, limit int, pageInfo *PageInfo) (PullRequest
 		"limit":     graphql.Int(limit),
 		"endCursor": (*graphql.String)(endCursor),
 	}
-	log.Debug("Fetching PRs", "query", query, "limit", limit, "endCursor", endCursor)
+	log.Debug("Fetching PRs", "host", host, "query", query, "limit", limit, "endCursor", endCursor)
 	err = client.Query("SearchPullRequests", &queryResult, variables)
 	if err != nil {
 		return PullRequestsResponse{}, err
@@ -571,12 +593,9 @@ func FetchPullRequests(query string, limit int, pageInfo *PageInfo) (PullRequest
 }
 
 func FetchPullRequest(prUrl string) (EnrichedPullRequestData, error) {
-	var err error
-	if client == nil {
-		client, err = gh.DefaultGraphQLClient()
-		if err != nil {
-			return EnrichedPullRequestData{}, err
-		}
+	client, err := getGraphQLClient(HostFromUrl(prUrl))
+	if err != nil {
+		return EnrichedPullRequestData{}, err
 	}
 
 	var queryResult struct {
@@ -0,0 +1,245 @@
+package data
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"charm.land/log/v2"
+	gh "github.com/cli/go-gh/v2/pkg/api"
+)
+
+const (
+	// lowRateLimitRatio is the fraction of a resource's budget below which
+	// background work (refreshes, enrichment) is deferred until reset.
+	lowRateLimitRatio = 0.1
+
+	// maxRateLimitRetries is how many times a request that hit a secondary
+	// rate limit is retried before giving up.
+	maxRateLimitRetries = 3
+
+	// rateLimitRetryBackoff is the initial wait before retrying a rate limited
+	// request without a Retry-After header. It doubles on every retry.
+	rateLimitRetryBackoff = 15 * time.Second
+
+	// maxRateLimitRetryWait caps how long a single retry may wait. Requests
+	// whose limit resets later than that fail right away instead of hanging.
+	maxRateLimitRetryWait = 2 * time.Minute
+)
+
+// RateLimit is the latest known state of one of GitHub's rate limit
+// resources (e.g. "core" for REST or "graphql").
+type RateLimit struct {
+	Resource  string
+	Limit     int
+	Remaining int
+	Used      int
+	ResetAt   time.Time
+}
+
+// IsLow returns true if the resource is close to being exhausted and has not
+// been reset yet.
+func (r RateLimit) IsLow() bool {
+	if r.Limit == 0 || !time.Now().Before(r.ResetAt) {
+		return false
+	}
+	return float64(r.Remaining) < float64(r.Limit)*lowRateLimitRatio
+}
+
+var rateLimits = struct {
+	sync.RWMutex
+	byResource map[string]RateLimit
+}{byResource: map[string]RateLimit{}}
+
+// GetRateLimits returns the last known rate limit of every resource the
+// dashboard has used, sorted by resource name.
+func GetRateLimits() []RateLimit {
+	rateLimits.RLock()
+	defer rateLimits.RUnlock()
+
+	limits := make([]RateLimit, 0, len(rateLimits.byResource))
+	for _, rl := range rateLimits.byResource {
+		limits = append(limits, rl)
+	}
+	slices.SortFunc(limits, func(a, b RateLimit) int {
+		return strings.Compare(a.Resource, b.Resource)
+	})
+	return limits
+}
+
+// GetLowestRateLimit returns the resource with the smallest share of its
+// budget left.
+func GetLowestRateLimit() (RateLimit, bool) {
+	var lowest RateLimit
+	found := false
+	for _, rl := range GetRateLimits() {
+		if rl.Limit == 0 {
+			continue
+		}
+		if !found || rl.Remaining*lowest.Limit < lowest.Remaining*rl.Limit {
+			lowest = rl
+			found = true
+		}
+	}
+	return lowest, found
+}
+
+// IsRateLimitLow returns true if any resource is running low, along with the
+// time at which all low resources will have been reset.
+func IsRateLimitLow() (bool, time.Time) {
+	low := false
+	var resetAt time.Time
+	for _, rl := range GetRateLimits() {
+		if !rl.IsLow() {
+			continue
+		}
+		low = true
+		if rl.ResetAt.After(resetAt) {
+			resetAt = rl.ResetAt
+		}
+	}
+	return low, resetAt
+}
+
+func recordRateLimit(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	used, _ := strconv.Atoi(header.Get("X-RateLimit-Used"))
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	resource := header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+
+	rl := RateLimit{
+		Resource:  resource,
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      used,
+		ResetAt:   time.Unix(reset, 0),
+	}
+
+	rateLimits.Lock()
+	prev, ok := rateLimits.byResource[resource]
+	// Concurrent responses can arrive out of order; don't let a stale one
+	// report more budget than we already know is left in the same window.
+	if !ok || !prev.ResetAt.Equal(rl.ResetAt) || rl.Remaining < prev.Remaining {
+		rateLimits.byResource[resource] = rl
+	}
+	rateLimits.Unlock()
+
+	if rl.IsLow() && (!ok || !prev.IsLow()) {
+		log.Warn("Running low on GitHub API rate limit", "resource", resource,
+			"remaining", remaining, "limit", limit, "resetAt", rl.ResetAt)
+	}
+}
+
+// rateLimitTransport records the rate limit headers of every response and
+// retries requests rejected by GitHub's secondary rate limits.
+type rateLimitTransport struct {
+	base  http.RoundTripper
+	sleep func(req *http.Request, d time.Duration) error
+}
+
+func newRateLimitTransport(base http.RoundTripper) *rateLimitTransport {
+	return &rateLimitTransport{base: base, sleep: sleepWithContext}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		res, err := t.base.RoundTrip(req)
+		if err != nil {
+			return res, err
+		}
+		recordRateLimit(res.Header)
+
+		if attempt >= maxRateLimitRetries || !isRateLimited(res) {
+			return res, nil
+		}
+
+		wait := rateLimitRetryDelay(res.Header, attempt)
+		if wait > maxRateLimitRetryWait || (req.Body != nil && req.GetBody == nil) {
+			return res, nil
+		}
+
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			if retry.Body, err = req.GetBody(); err != nil {
+				return res, nil
+			}
+		}
+
+		log.Warn("Rate limited by GitHub, retrying", "url", req.URL.String(),
+			"status", res.StatusCode, "in", wait, "attempt", attempt+1)
+		res.Body.Close()
+		if err := t.sleep(req, wait); err != nil {
+			return nil, err
+		}
+		req = retry
+	}
+}
+
+// isRateLimited returns true for 429s and for 403s caused by a rate limit
+// rather than by missing permissions.
+func isRateLimited(res *http.Response) bool {
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		if res.Header.Get("Retry-After") != "" || res.Header.Get("X-RateLimit-Remaining") == "0" {
+			return true
+		}
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		return err == nil && bytes.Contains(bytes.ToLower(body), []byte("rate limit"))
+	default:
+		return false
+	}
+}
+
+// rateLimitRetryDelay follows GitHub's guidance: honor Retry-After, wait for
+// the reset when the primary limit is exhausted and back off exponentially
+// otherwise.
+func rateLimitRetryDelay(header http.Header, attempt int) time.Duration {
+	if secs, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(0, time.Until(time.Unix(reset, 0)))
+		}
+	}
+	return rateLimitRetryBackoff << attempt
+}
+
+func sleepWithContext(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+// defaultClientOptions returns the options used for every GitHub API client so
+// that all requests go through the rate limit tracking transport.
+func defaultClientOptions() gh.ClientOptions {
+	return gh.ClientOptions{
+		Transport: requestHeadersTransport{base: newRateLimitTransport(http.DefaultTransport)},
+	}
+}
+
+func newGraphQLClient() (*gh.GraphQLClient, error) {
+	return gh.NewGraphQLClient(defaultClientOptions())
+}
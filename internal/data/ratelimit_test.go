@@ -0,0 +1,217 @@
+package data
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func resetRateLimits(t *testing.T) {
+	t.Helper()
+	rateLimits.Lock()
+	rateLimits.byResource = map[string]RateLimit{}
+	rateLimits.Unlock()
+	t.Cleanup(func() {
+		rateLimits.Lock()
+		rateLimits.byResource = map[string]RateLimit{}
+		rateLimits.Unlock()
+	})
+}
+
+func rateLimitHeader(resource string, limit, remaining int, resetAt time.Time) http.Header {
+	h := http.Header{}
+	h.Set("X-RateLimit-Resource", resource)
+	h.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-RateLimit-Used", strconv.Itoa(limit-remaining))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+	return h
+}
+
+func TestRecordRateLimit(t *testing.T) {
+	resetRateLimits(t)
+	resetAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	recordRateLimit(rateLimitHeader("graphql", 5000, 4000, resetAt))
+	recordRateLimit(rateLimitHeader("core", 5000, 300, resetAt))
+	// A stale response from the same window must not raise the budget again.
+	recordRateLimit(rateLimitHeader("graphql", 5000, 4500, resetAt))
+
+	limits := GetRateLimits()
+	require.Len(t, limits, 2)
+	require.Equal(t, "core", limits[0].Resource)
+	require.Equal(t, 4000, limits[1].Remaining)
+
+	lowest, ok := GetLowestRateLimit()
+	require.True(t, ok)
+	require.Equal(t, "core", lowest.Resource)
+
+	low, lowResetAt := IsRateLimitLow()
+	require.True(t, low)
+	require.Equal(t, resetAt, lowResetAt)
+
+	// A new window replaces the previous state.
+	recordRateLimit(rateLimitHeader("core", 5000, 5000, resetAt.Add(time.Hour)))
+	low, _ = IsRateLimitLow()
+	require.False(t, low)
+}
+
+func TestRateLimitIsLow(t *testing.T) {
+	tests := []struct {
+		name string
+		rl   RateLimit
+		want bool
+	}{
+		{
+			name: "plenty left",
+			rl:   RateLimit{Limit: 5000, Remaining: 4000, ResetAt: time.Now().Add(time.Hour)},
+			want: false,
+		},
+		{
+			name: "below threshold",
+			rl:   RateLimit{Limit: 5000, Remaining: 100, ResetAt: time.Now().Add(time.Hour)},
+			want: true,
+		},
+		{
+			name: "already reset",
+			rl:   RateLimit{Limit: 5000, Remaining: 0, ResetAt: time.Now().Add(-time.Minute)},
+			want: false,
+		},
+		{
+			name: "unknown",
+			rl:   RateLimit{},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.rl.IsLow())
+		})
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newTestResponse(status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []*http.Response
+		wantCalls int
+		wantCode  int
+		wantWaits []time.Duration
+	}{
+		{
+			name:      "success is not retried",
+			responses: []*http.Response{newTestResponse(http.StatusOK, nil, "{}")},
+			wantCalls: 1,
+			wantCode:  http.StatusOK,
+		},
+		{
+			name: "retry after is honored",
+			responses: []*http.Response{
+				newTestResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"3"}}, ""),
+				newTestResponse(http.StatusOK, nil, "{}"),
+			},
+			wantCalls: 2,
+			wantCode:  http.StatusOK,
+			wantWaits: []time.Duration{3 * time.Second},
+		},
+		{
+			name: "secondary rate limit backs off exponentially",
+			responses: []*http.Response{
+				newTestResponse(http.StatusForbidden, nil, `{"message":"You have exceeded a secondary rate limit"}`),
+				newTestResponse(http.StatusForbidden, nil, `{"message":"You have exceeded a secondary rate limit"}`),
+				newTestResponse(http.StatusOK, nil, "{}"),
+			},
+			wantCalls: 3,
+			wantCode:  http.StatusOK,
+			wantWaits: []time.Duration{rateLimitRetryBackoff, 2 * rateLimitRetryBackoff},
+		},
+		{
+			name: "permission errors are not retried",
+			responses: []*http.Response{
+				newTestResponse(http.StatusForbidden, nil, `{"message":"Resource not accessible"}`),
+			},
+			wantCalls: 1,
+			wantCode:  http.StatusForbidden,
+		},
+		{
+			name: "gives up after max retries",
+			responses: []*http.Response{
+				newTestResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}, ""),
+				newTestResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}, ""),
+				newTestResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}, ""),
+				newTestResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}, ""),
+			},
+			wantCalls: maxRateLimitRetries + 1,
+			wantCode:  http.StatusTooManyRequests,
+			wantWaits: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name: "long waits fail right away",
+			responses: []*http.Response{
+				newTestResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"3600"}}, ""),
+			},
+			wantCalls: 1,
+			wantCode:  http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetRateLimits(t)
+
+			var bodies []string
+			calls := 0
+			var waits []time.Duration
+			transport := &rateLimitTransport{
+				base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body, err := io.ReadAll(req.Body)
+					require.NoError(t, err)
+					bodies = append(bodies, string(body))
+					res := tt.responses[calls]
+					calls++
+					return res, nil
+				}),
+				sleep: func(_ *http.Request, d time.Duration) error {
+					waits = append(waits, d)
+					return nil
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "https://api.github.com/graphql",
+				strings.NewReader(`{"query":"{}"}`))
+			require.NoError(t, err)
+
+			res, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCode, res.StatusCode)
+			require.Equal(t, tt.wantCalls, calls)
+			require.Equal(t, tt.wantWaits, waits)
+			for _, body := range bodies {
+				require.Equal(t, `{"query":"{}"}`, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package data
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"charm.land/log/v2"
+)
+
+// repoPathsMaxAge is how long the clones found by a scan are trusted before
+// the roots are scanned again
+const repoPathsMaxAge = 24 * time.Hour
+
+// RepoPathStore caches the local clones found by scanning repoDiscovery's
+// roots, mapped by their repo's name like repoPaths maps them: owner/repo on
+// the default host and host/owner/repo on any other. Names are stored lower
+// cased, since GitHub's aren't case sensitive.
+type RepoPathStore struct {
+	mu        sync.RWMutex
+	roots     []string
+	depth     int
+	scannedAt time.Time
+	paths     map[string]string
+	filePath  string
+}
+
+// repoPathsFile is the on-disk format of the store. The roots and depth of
+// the scan are kept to scan again when they change.
+type repoPathsFile struct {
+	Roots     []string          `json:"roots"`
+	Depth     int               `json:"depth"`
+	ScannedAt time.Time         `json:"scannedAt"`
+	Paths     map[string]string `json:"paths"`
+}
+
+func newRepoPathStore(filename string) *RepoPathStore {
+	store := &RepoPathStore{
+		paths: make(map[string]string),
+	}
+	filePath, err := getStateFilePath(filename)
+	if err != nil {
+		log.Error("Failed to get state file path for discovered repos", "err", err)
+	}
+	store.filePath = filePath
+	if err := store.load(); err != nil {
+		log.Error("Failed to load discovered repos", "err", err)
+	}
+	return store
+}
+
+func (s *RepoPathStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.filePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var file repoPathsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	s.roots, s.depth, s.scannedAt = file.Roots, file.Depth, file.ScannedAt
+	if file.Paths != nil {
+		s.paths = file.Paths
+	}
+	log.Debug("Loaded discovered repos", "count", len(s.paths))
+	return nil
+}
+
+func (s *RepoPathStore) save() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.filePath == "" {
+		return nil
+	}
+
+	if err := writeStateFileAtomic(s.filePath, repoPathsFile{
+		Roots:     s.roots,
+		Depth:     s.depth,
+		ScannedAt: s.scannedAt,
+		Paths:     s.paths,
+	}); err != nil {
+		return err
+	}
+
+	log.Debug("Saved discovered repos", "count", len(s.paths))
+	return nil
+}
+
+// Get returns the path of the clone found for the repo
+func (s *RepoPathStore) Get(repoName string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	path, ok := s.paths[strings.ToLower(repoName)]
+	return path, ok
+}
+
+// IsStale returns whether roots should be scanned again, because they were
+// never scanned with depth or the last scan is older than a day
+func (s *RepoPathStore) IsStale(roots []string, depth int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return !slices.Equal(s.roots, roots) || s.depth != depth ||
+		time.Since(s.scannedAt) > repoPathsMaxAge
+}
+
+// Set replaces the clones with the ones a scan of roots found and saves
+// them. It's called from the scan's command, off the UI's goroutine.
+func (s *RepoPathStore) Set(roots []string, depth int, paths map[string]string) error {
+	s.mu.Lock()
+	s.roots, s.depth, s.scannedAt = slices.Clone(roots), depth, time.Now()
+	s.paths = make(map[string]string, len(paths))
+	for name, path := range paths {
+		s.paths[strings.ToLower(name)] = path
+	}
+	s.mu.Unlock()
+	return s.save()
+}
+
+// Add records a single clone, like one gh-dash just cloned, until the next
+// scan
+func (s *RepoPathStore) Add(repoName string, path string) error {
+	s.mu.Lock()
+	s.paths[strings.ToLower(repoName)] = path
+	s.mu.Unlock()
+	return s.save()
+}
+
+// GetAll returns the clones by their repo's name
+func (s *RepoPathStore) GetAll() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.paths)
+}
+
+// Singleton
+
+var (
+	repoPathStore     *RepoPathStore
+	repoPathStoreOnce sync.Once
+)
+
+// GetRepoPathStore returns the singleton store of discovered repos.
+func GetRepoPathStore() *RepoPathStore {
+	repoPathStoreOnce.Do(func() {
+		repoPathStore = newRepoPathStore("repo-paths.json")
+	})
+	return repoPathStore
+}
@@ -0,0 +1,44 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoPathStore(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	roots := []string{"/code", "/work"}
+
+	store := newRepoPathStore("repo-paths.json")
+	require.True(t, store.IsStale(roots, 3), "never scanned")
+
+	require.NoError(t, store.Set(roots, 3, map[string]string{
+		"dlvhdr/gh-dash":              "/code/gh-dash",
+		"GHE.example.com/Org/Service": "/work/service",
+	}))
+	require.False(t, store.IsStale(roots, 3))
+	require.True(t, store.IsStale([]string{"/code"}, 3), "other roots")
+	require.True(t, store.IsStale(roots, 2), "other depth")
+
+	path, ok := store.Get("DLVHDR/gh-dash")
+	require.True(t, ok)
+	require.Equal(t, "/code/gh-dash", path)
+	_, ok = store.Get("dlvhdr/other")
+	require.False(t, ok)
+
+	require.NoError(t, store.Add("dlvhdr/diffnav", "/code/diffnav"))
+
+	// A new store reads the saved scan
+	loaded := newRepoPathStore("repo-paths.json")
+	require.Equal(t, map[string]string{
+		"dlvhdr/gh-dash":              "/code/gh-dash",
+		"ghe.example.com/org/service": "/work/service",
+		"dlvhdr/diffnav":              "/code/diffnav",
+	}, loaded.GetAll())
+	require.False(t, loaded.IsStale(roots, 3))
+
+	loaded.scannedAt = time.Now().Add(-2 * repoPathsMaxAge)
+	require.True(t, loaded.IsStale(roots, 3), "old scan")
+}
@@ -0,0 +1,120 @@
+package data
+
+import (
+	"slices"
+	"sync"
+)
+
+// SectionKey identifies a section across views, e.g. {Type: "pr", Id: 2}.
+type SectionKey struct {
+	Type string
+	Id   int
+}
+
+// RowStore is shared by all sections and keyed by the PR/issue URL. It
+// tracks which sections display each row and caches enriched PR data so a PR
+// shown in several sections is only enriched once.
+type RowStore struct {
+	mu       sync.RWMutex
+	enriched map[string]EnrichedPullRequestData
+	sections map[SectionKey][]string
+	byUrl    map[string]map[SectionKey]struct{}
+}
+
+func newRowStore() *RowStore {
+	return &RowStore{
+		enriched: make(map[string]EnrichedPullRequestData),
+		sections: make(map[SectionKey][]string),
+		byUrl:    make(map[string]map[SectionKey]struct{}),
+	}
+}
+
+// Singleton for row store
+
+var (
+	rowStore     *RowStore
+	rowStoreOnce sync.Once
+)
+
+// GetRowStore returns the singleton row store
+func GetRowStore() *RowStore {
+	rowStoreOnce.Do(func() {
+		rowStore = newRowStore()
+	})
+	return rowStore
+}
+
+// SetSectionRows replaces the URLs of the rows displayed by a section.
+func (s *RowStore) SetSectionRows(key SectionKey, urls []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, url := range s.sections[key] {
+		delete(s.byUrl[url], key)
+		if len(s.byUrl[url]) == 0 {
+			delete(s.byUrl, url)
+		}
+	}
+
+	s.sections[key] = slices.Clone(urls)
+	for _, url := range urls {
+		if s.byUrl[url] == nil {
+			s.byUrl[url] = make(map[SectionKey]struct{})
+		}
+		s.byUrl[url][key] = struct{}{}
+	}
+}
+
+// OtherSections returns the ids of the sections of the same type, other than
+// key, that display url, sorted by id.
+func (s *RowStore) OtherSections(key SectionKey, url string) []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ids []int
+	for other := range s.byUrl[url] {
+		if other.Type == key.Type && other.Id != key.Id {
+			ids = append(ids, other.Id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// IsInEarlierSection returns true if url is displayed by a section of the same
+// type that comes before key, i.e. a higher-priority one.
+func (s *RowStore) IsInEarlierSection(key SectionKey, url string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for other := range s.byUrl[url] {
+		if other.Type == key.Type && other.Id < key.Id {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEnrichedPullRequest returns the cached enrichment of the PR, if any.
+func (s *RowStore) GetEnrichedPullRequest(url string) (EnrichedPullRequestData, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pr, ok := s.enriched[url]
+	return pr, ok
+}
+
+func (s *RowStore) SetEnrichedPullRequest(pr EnrichedPullRequestData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.enriched[pr.Url] = pr
+}
+
+// ClearEnriched drops all cached enrichments so they are fetched again.
+func (s *RowStore) ClearEnriched() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.enriched)
+}
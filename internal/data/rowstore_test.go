@@ -0,0 +1,50 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowStoreSections(t *testing.T) {
+	store := newRowStore()
+	mine := SectionKey{Type: "pr", Id: 1}
+	review := SectionKey{Type: "pr", Id: 2}
+	team := SectionKey{Type: "pr", Id: 3}
+	issues := SectionKey{Type: "issue", Id: 1}
+
+	store.SetSectionRows(mine, []string{"a", "b"})
+	store.SetSectionRows(review, []string{"b", "c"})
+	store.SetSectionRows(team, []string{"a", "b", "c"})
+	store.SetSectionRows(issues, []string{"a"})
+
+	require.Equal(t, []int{2, 3}, store.OtherSections(mine, "b"))
+	require.Equal(t, []int{3}, store.OtherSections(mine, "a"))
+	require.Empty(t, store.OtherSections(issues, "a"))
+
+	require.False(t, store.IsInEarlierSection(mine, "a"))
+	require.True(t, store.IsInEarlierSection(review, "b"))
+	require.False(t, store.IsInEarlierSection(review, "c"))
+	require.True(t, store.IsInEarlierSection(team, "c"))
+
+	// Replacing a section's rows drops its previous ones.
+	store.SetSectionRows(mine, []string{"d"})
+	require.False(t, store.IsInEarlierSection(review, "b"))
+	require.Equal(t, []int{3}, store.OtherSections(review, "b"))
+}
+
+func TestRowStoreEnriched(t *testing.T) {
+	store := newRowStore()
+
+	_, ok := store.GetEnrichedPullRequest("a")
+	require.False(t, ok)
+
+	store.SetEnrichedPullRequest(EnrichedPullRequestData{Url: "a", Number: 1})
+	pr, ok := store.GetEnrichedPullRequest("a")
+	require.True(t, ok)
+	require.Equal(t, 1, pr.Number)
+
+	store.ClearEnriched()
+	_, ok = store.GetEnrichedPullRequest("a")
+	require.False(t, ok)
+}
@@ -0,0 +1,180 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"charm.land/log/v2"
+)
+
+// SnoozeEntry is a snoozed notification: it stays hidden until Until passes or
+// the notification is updated after UpdatedAt.
+type SnoozeEntry struct {
+	Until     time.Time `json:"until"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SnoozeStore persists snoozed notification IDs. Like DoneStore, it records the
+// notification's updated_at when it was snoozed so new activity resurfaces it
+// before the snooze expires.
+type SnoozeStore struct {
+	mu       sync.RWMutex
+	entries  map[string]SnoozeEntry
+	filePath string
+	now      func() time.Time
+}
+
+func newSnoozeStore(filename string) *SnoozeStore {
+	store := &SnoozeStore{
+		entries: make(map[string]SnoozeEntry),
+		now:     time.Now,
+	}
+	filePath, err := getStateFilePath(filename)
+	if err != nil {
+		log.Error("Failed to get state file path for snoozed notifications", "err", err)
+	}
+	store.filePath = filePath
+	if err := store.load(); err != nil {
+		log.Error("Failed to load snoozed notifications", "err", err)
+	}
+	return store
+}
+
+func (s *SnoozeStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.filePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return err
+	}
+	s.prune()
+	log.Debug("Loaded snoozed notifications", "count", len(s.entries))
+	return nil
+}
+
+// prune removes expired snoozes.
+func (s *SnoozeStore) prune() {
+	now := s.now()
+	for id, e := range s.entries {
+		if !e.Until.After(now) {
+			delete(s.entries, id)
+		}
+	}
+}
+
+func (s *SnoozeStore) save() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.filePath == "" {
+		return nil
+	}
+
+	if err := writeStateFileAtomic(s.filePath, s.entries); err != nil {
+		return err
+	}
+
+	log.Debug("Saved snoozed notifications", "count", len(s.entries))
+	return nil
+}
+
+// Snooze hides the notification until the given time, or until it's updated
+// after updatedAt.
+func (s *SnoozeStore) Snooze(id string, updatedAt time.Time, until time.Time) {
+	s.mu.Lock()
+	s.entries[id] = SnoozeEntry{Until: until, UpdatedAt: updatedAt}
+	s.mu.Unlock()
+	go s.save()
+}
+
+// IsSnoozed returns true if the snooze hasn't expired and the notification
+// hasn't been updated since it was snoozed.
+func (s *SnoozeStore) IsSnoozed(id string, updatedAt time.Time) bool {
+	_, ok := s.SnoozedUntil(id, updatedAt)
+	return ok
+}
+
+// SnoozedUntil returns the time the notification is snoozed until, if it's
+// still snoozed.
+func (s *SnoozeStore) SnoozedUntil(id string, updatedAt time.Time) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.entries[id]
+	if !ok || !e.Until.After(s.now()) || updatedAt.After(e.UpdatedAt) {
+		return time.Time{}, false
+	}
+	return e.Until, true
+}
+
+// GetSnoozedIds returns the IDs of all snoozed notifications, including ones
+// whose snooze has since expired but weren't pruned yet.
+func (s *SnoozeStore) GetSnoozedIds() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.entries))
+	for id := range s.entries {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Remove removes a notification from the snooze store. The notifications
+// polls are invalidated so the notification shows up again on the next fetch.
+func (s *SnoozeStore) Remove(id string) {
+	s.mu.Lock()
+	delete(s.entries, id)
+	s.mu.Unlock()
+	invalidateNotificationPolls()
+	go s.save()
+}
+
+// PruneExpired removes the snoozes that expired since they were loaded,
+// invalidating the notifications polls so the notifications show up again
+// even if GitHub answers that nothing changed. It returns how many snoozes
+// expired.
+func (s *SnoozeStore) PruneExpired() int {
+	s.mu.Lock()
+	count := len(s.entries)
+	s.prune()
+	expired := count - len(s.entries)
+	s.mu.Unlock()
+	if expired > 0 {
+		invalidateNotificationPolls()
+		go s.save()
+	}
+	return expired
+}
+
+// Flush forces an immediate synchronous save.
+func (s *SnoozeStore) Flush() error {
+	return s.save()
+}
+
+// Singleton
+
+var (
+	snoozeStore     *SnoozeStore
+	snoozeStoreOnce sync.Once
+)
+
+// GetSnoozeStore returns the singleton snooze store.
+func GetSnoozeStore() *SnoozeStore {
+	snoozeStoreOnce.Do(func() {
+		snoozeStore = newSnoozeStore("snoozed.json")
+	})
+	return snoozeStore
+}
@@ -0,0 +1,93 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSnoozeStore(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "gh-dash-snoozestore-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	updatedAt := now.Add(-time.Hour)
+
+	t.Run("Snoozed until the time passes", func(t *testing.T) {
+		store := NewSnoozeStoreForTesting(filepath.Join(tempDir, "test1.json"), clock)
+		store.Snooze("id1", updatedAt, now.Add(time.Hour))
+
+		until, ok := store.SnoozedUntil("id1", updatedAt)
+		if !ok || !until.Equal(now.Add(time.Hour)) {
+			t.Errorf("SnoozedUntil() = %v, %t, want %v, true", until, ok, now.Add(time.Hour))
+		}
+
+		later := NewSnoozeStoreForTesting(store.filePath, func() time.Time {
+			return now.Add(2 * time.Hour)
+		})
+		later.entries = store.entries
+		if later.IsSnoozed("id1", updatedAt) {
+			t.Error("Should NOT be snoozed after the snooze expired")
+		}
+	})
+
+	t.Run("New activity ends the snooze", func(t *testing.T) {
+		store := NewSnoozeStoreForTesting(filepath.Join(tempDir, "test2.json"), clock)
+		store.Snooze("id1", updatedAt, now.Add(time.Hour))
+		if store.IsSnoozed("id1", updatedAt.Add(time.Minute)) {
+			t.Error("Should NOT be snoozed when the notification was updated since")
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		store := NewSnoozeStoreForTesting(filepath.Join(tempDir, "test3.json"), clock)
+		store.Snooze("id1", updatedAt, now.Add(time.Hour))
+		store.Remove("id1")
+		if store.IsSnoozed("id1", updatedAt) {
+			t.Error("Should NOT be snoozed after removal")
+		}
+	})
+
+	t.Run("Persists and prunes expired snoozes", func(t *testing.T) {
+		filePath := filepath.Join(tempDir, "test4.json")
+		store := NewSnoozeStoreForTesting(filePath, clock)
+		store.entries["id1"] = SnoozeEntry{Until: now.Add(time.Hour), UpdatedAt: updatedAt}
+		store.entries["id2"] = SnoozeEntry{Until: now.Add(-time.Hour), UpdatedAt: updatedAt}
+		if err := store.Flush(); err != nil {
+			t.Fatalf("Flush() error: %v", err)
+		}
+
+		loaded := NewSnoozeStoreForTesting(filePath, clock)
+		if err := loaded.load(); err != nil {
+			t.Fatalf("load() error: %v", err)
+		}
+		if !loaded.IsSnoozed("id1", updatedAt) {
+			t.Error("id1 should still be snoozed after reload")
+		}
+		if ids := loaded.GetSnoozedIds(); len(ids) != 1 {
+			t.Errorf("GetSnoozedIds() = %v, want only id1", ids)
+		}
+	})
+
+	t.Run("PruneExpired", func(t *testing.T) {
+		store := NewSnoozeStoreForTesting(filepath.Join(tempDir, "test5.json"), clock)
+		store.Snooze("id1", updatedAt, now.Add(time.Hour))
+		if expired := store.PruneExpired(); expired != 0 {
+			t.Errorf("PruneExpired() = %d, want 0", expired)
+		}
+
+		now = now.Add(2 * time.Hour)
+		defer func() { now = now.Add(-2 * time.Hour) }()
+		if expired := store.PruneExpired(); expired != 1 {
+			t.Errorf("PruneExpired() = %d, want 1", expired)
+		}
+		if ids := store.GetSnoozedIds(); len(ids) != 0 {
+			t.Errorf("GetSnoozedIds() = %v, want none", ids)
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/log/v2"
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
+// StackPR is a PR of a stack, a chain of PRs where each PR's base branch is
+// the head branch of the PR below it.
+type StackPR struct {
+	Number      int
+	Title       string
+	Url         string
+	State       string
+	IsDraft     bool
+	HeadRefName string
+	BaseRefName string
+	// IsCrossRepository is true for PRs from forks, whose head branches live
+	// in another repo and so can't be the base of other PRs
+	IsCrossRepository bool
+}
+
+// StackPRFromPullRequest returns the stack fields of a PR
+func StackPRFromPullRequest(pr PullRequestData) StackPR {
+	return StackPR{
+		Number:      pr.Number,
+		Title:       pr.Title,
+		Url:         pr.Url,
+		State:       pr.State,
+		IsDraft:     pr.IsDraft,
+		HeadRefName: pr.HeadRefName,
+		BaseRefName: pr.BaseRefName,
+	}
+}
+
+// StackNode is a PR in a stack, linked to the PR it's based on and the PRs
+// based on it.
+type StackNode struct {
+	PR       StackPR
+	Parent   *StackNode
+	Children []*StackNode
+}
+
+// Walk calls fn for the node and every PR stacked on it, depth first and
+// parents before their children. isLast is true when the node is the last
+// child of its parent.
+func (n *StackNode) Walk(fn func(node *StackNode, depth int, isLast bool)) {
+	var walk func(node *StackNode, depth int, isLast bool)
+	walk = func(node *StackNode, depth int, isLast bool) {
+		fn(node, depth, isLast)
+		for i, child := range node.Children {
+			walk(child, depth+1, i == len(node.Children)-1)
+		}
+	}
+	walk(n, 0, true)
+}
+
+// Find returns the node of the PR in the stack, or nil
+func (n *StackNode) Find(number int) *StackNode {
+	var found *StackNode
+	n.Walk(func(node *StackNode, _ int, _ bool) {
+		if found == nil && node.PR.Number == number {
+			found = node
+		}
+	})
+	return found
+}
+
+// Size returns the number of PRs in the stack
+func (n *StackNode) Size() int {
+	size := 0
+	n.Walk(func(*StackNode, int, bool) { size++ })
+	return size
+}
+
+// BuildStacks links the open PRs of a repo into stacks, where a PR is the
+// child of the open PR whose head branch is its base branch. It returns the
+// bottom PR of every stack with at least two PRs, in the order of prs.
+func BuildStacks(prs []StackPR) []*StackNode {
+	nodes := make([]*StackNode, 0, len(prs))
+	byHead := make(map[string]*StackNode)
+	for _, pr := range prs {
+		if pr.State != "OPEN" {
+			continue
+		}
+		node := &StackNode{PR: pr}
+		nodes = append(nodes, node)
+		if !pr.IsCrossRepository {
+			byHead[pr.HeadRefName] = node
+		}
+	}
+
+	for _, node := range nodes {
+		parent, ok := byHead[node.PR.BaseRefName]
+		if !ok || parent == node || isAncestor(node, parent) {
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	stacks := make([]*StackNode, 0)
+	for _, node := range nodes {
+		if node.Parent == nil && len(node.Children) > 0 {
+			stacks = append(stacks, node)
+		}
+	}
+	return stacks
+}
+
+// isAncestor returns whether node is below other in its stack, which guards
+// against cycles of PRs based on each other's branches
+func isAncestor(node *StackNode, other *StackNode) bool {
+	for p := other; p != nil; p = p.Parent {
+		if p == node {
+			return true
+		}
+	}
+	return false
+}
+
+// FindStack returns the bottom PR of the stack with the PR, or nil when the
+// PR isn't stacked.
+func FindStack(stacks []*StackNode, number int) *StackNode {
+	for _, stack := range stacks {
+		if stack.Find(number) != nil {
+			return stack
+		}
+	}
+	return nil
+}
+
+// StackRetarget is an open PR whose base branch is the head branch of a
+// merged PR, and the base it should have now that its parent merged.
+type StackRetarget struct {
+	PR      StackPR
+	NewBase string
+}
+
+// FindRetargets returns the open PRs that were stacked on PRs that have
+// since merged, with the base branch of the merged PR as their new base. A
+// PR stacked on a chain of merged PRs gets the base of the bottom one.
+func FindRetargets(prs []StackPR) []StackRetarget {
+	mergedByHead := make(map[string]StackPR)
+	for _, pr := range prs {
+		if pr.State == "MERGED" && !pr.IsCrossRepository {
+			mergedByHead[pr.HeadRefName] = pr
+		}
+	}
+
+	retargets := make([]StackRetarget, 0)
+	for _, pr := range prs {
+		if pr.State != "OPEN" {
+			continue
+		}
+		base := pr.BaseRefName
+		seen := map[string]bool{}
+		for {
+			merged, ok := mergedByHead[base]
+			if !ok || seen[base] {
+				break
+			}
+			seen[base] = true
+			base = merged.BaseRefName
+		}
+		if base != pr.BaseRefName {
+			retargets = append(retargets, StackRetarget{PR: pr, NewBase: base})
+		}
+	}
+	return retargets
+}
+
+// FetchStackPRs fetches the open PRs of a repo, and its most recently merged
+// ones to find PRs stacked on merged PRs.
+func FetchStackPRs(host string, repo string) ([]StackPR, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository %q", repo)
+	}
+	client, err := getGraphQLClient(host)
+	if err != nil {
+		return nil, err
+	}
+
+	type stackPRNodes struct {
+		Nodes []struct {
+			Number            int
+			Title             string
+			Url               string
+			State             string
+			IsDraft           bool
+			HeadRefName       string
+			BaseRefName       string
+			IsCrossRepository bool
+		}
+	}
+	var queryResult struct {
+		Repository struct {
+			Open   stackPRNodes `graphql:"open: pullRequests(states: OPEN, first: 100, orderBy: {field: UPDATED_AT, direction: DESC})"`
+			Merged stackPRNodes `graphql:"merged: pullRequests(states: MERGED, first: 50, orderBy: {field: UPDATED_AT, direction: DESC})"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	variables := map[string]any{
+		"owner": graphql.String(owner),
+		"name":  graphql.String(name),
+	}
+	log.Debug("Fetching stack PRs", "repo", repo)
+	if err := client.Query("FetchStackPRs", &queryResult, variables); err != nil {
+		return nil, err
+	}
+
+	prs := make([]StackPR, 0)
+	for _, nodes := range []stackPRNodes{queryResult.Repository.Open, queryResult.Repository.Merged} {
+		for _, n := range nodes.Nodes {
+			prs = append(prs, StackPR{
+				Number:            n.Number,
+				Title:             n.Title,
+				Url:               n.Url,
+				State:             n.State,
+				IsDraft:           n.IsDraft,
+				HeadRefName:       n.HeadRefName,
+				BaseRefName:       n.BaseRefName,
+				IsCrossRepository: n.IsCrossRepository,
+			})
+		}
+	}
+	slices.SortStableFunc(prs, func(a, b StackPR) int { return a.Number - b.Number })
+	return prs, nil
+}
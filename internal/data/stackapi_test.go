@@ -0,0 +1,90 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func stackPR(number int, state string, base string, head string) StackPR {
+	return StackPR{Number: number, State: state, BaseRefName: base, HeadRefName: head}
+}
+
+func stackNumbers(stack *StackNode) []int {
+	numbers := []int{}
+	stack.Walk(func(node *StackNode, _ int, _ bool) {
+		numbers = append(numbers, node.PR.Number)
+	})
+	return numbers
+}
+
+func TestBuildStacks(t *testing.T) {
+	prs := []StackPR{
+		stackPR(1, "OPEN", "main", "feat-a"),
+		stackPR(2, "OPEN", "feat-a", "feat-b"),
+		stackPR(3, "OPEN", "feat-b", "feat-c"),
+		stackPR(4, "OPEN", "feat-a", "feat-d"),
+		stackPR(5, "OPEN", "main", "lonely"),
+		stackPR(6, "MERGED", "main", "merged"),
+		stackPR(7, "OPEN", "merged", "on-merged"),
+	}
+
+	stacks := BuildStacks(prs)
+	require.Len(t, stacks, 1)
+	require.Equal(t, []int{1, 2, 3, 4}, stackNumbers(stacks[0]))
+	require.Equal(t, 4, stacks[0].Size())
+
+	var depths []int
+	var lasts []bool
+	stacks[0].Walk(func(_ *StackNode, depth int, isLast bool) {
+		depths = append(depths, depth)
+		lasts = append(lasts, isLast)
+	})
+	require.Equal(t, []int{0, 1, 2, 1}, depths)
+	require.Equal(t, []bool{true, false, true, true}, lasts)
+
+	require.Same(t, stacks[0], FindStack(stacks, 3))
+	require.Equal(t, 2, FindStack(stacks, 3).Find(3).Parent.PR.Number)
+	require.Nil(t, FindStack(stacks, 5))
+	require.Nil(t, FindStack(stacks, 7))
+}
+
+func TestBuildStacksIgnoresForksAndCycles(t *testing.T) {
+	fork := stackPR(1, "OPEN", "main", "feat")
+	fork.IsCrossRepository = true
+	require.Empty(t, BuildStacks([]StackPR{fork, stackPR(2, "OPEN", "feat", "other")}))
+
+	// PRs based on each other's branches are cut into a single stack
+	stacks := BuildStacks([]StackPR{
+		stackPR(1, "OPEN", "b", "a"),
+		stackPR(2, "OPEN", "a", "b"),
+	})
+	require.Len(t, stacks, 1)
+	require.Equal(t, []int{2, 1}, stackNumbers(stacks[0]))
+}
+
+func TestFindRetargets(t *testing.T) {
+	prs := []StackPR{
+		stackPR(1, "MERGED", "main", "feat-a"),
+		stackPR(2, "OPEN", "feat-a", "feat-b"),
+		stackPR(3, "MERGED", "feat-a", "feat-c"),
+		stackPR(4, "OPEN", "feat-c", "feat-d"),
+		stackPR(5, "OPEN", "main", "feat-e"),
+		stackPR(6, "CLOSED", "feat-a", "feat-f"),
+	}
+
+	require.Equal(t, []StackRetarget{
+		{PR: prs[1], NewBase: "main"},
+		{PR: prs[3], NewBase: "main"},
+	}, FindRetargets(prs))
+}
+
+func TestFindRetargetsCycle(t *testing.T) {
+	prs := []StackPR{
+		stackPR(1, "MERGED", "b", "a"),
+		stackPR(2, "MERGED", "a", "b"),
+		stackPR(3, "OPEN", "a", "c"),
+	}
+
+	require.Empty(t, FindRetargets(prs))
+}
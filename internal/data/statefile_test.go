@@ -0,0 +1,38 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteStateFileAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	if err := writeStateFileAtomic(path, []string{"a"}); err != nil {
+		t.Fatalf("writeStateFileAtomic() error = %v", err)
+	}
+	if err := writeStateFileAtomic(path, []string{"a", "b"}); err != nil {
+		t.Fatalf("writeStateFileAtomic() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `["a","b"]` {
+		t.Errorf("file = %s, want the last write", got)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("dir has %d entries, want only the state file", len(entries))
+	}
+
+	if err := writeStateFileAtomic(path, func() {}); err == nil {
+		t.Error("Values that can't be encoded should fail")
+	}
+}
@@ -0,0 +1,183 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"charm.land/log/v2"
+)
+
+const (
+	stateSnapshotVersion = 1
+
+	// stateSyncFilename is the name of the state file in the stateSync dir
+	stateSyncFilename = "gh-dash-state.json"
+
+	// stateLockTimeout is how long syncing waits for another instance to
+	// release the lock
+	stateLockTimeout = 10 * time.Second
+
+	// staleStateLockAge is the age after which a lock is considered left
+	// behind by an instance that crashed, and is removed
+	staleStateLockAge = time.Minute
+)
+
+// ErrStateLocked is returned when another instance holds the lock of the
+// state file for longer than syncing waits.
+var ErrStateLocked = errors.New("another gh-dash instance is syncing")
+
+// StateSnapshot is the local notification state that can be exported and
+// imported between machines.
+type StateSnapshot struct {
+	Version   int                  `json:"version"`
+	Done      map[string]time.Time `json:"done"`
+	Bookmarks []string             `json:"bookmarks"`
+}
+
+// StateImportResult counts the entries an import added or updated
+type StateImportResult struct {
+	Done      int
+	Bookmarks int
+}
+
+// ExportState returns the done notifications and the bookmarks.
+func ExportState() StateSnapshot {
+	bookmarks := GetBookmarkStore().GetBookmarkedIds()
+	slices.Sort(bookmarks)
+	return StateSnapshot{
+		Version:   stateSnapshotVersion,
+		Done:      GetDoneStore().GetEntries(),
+		Bookmarks: bookmarks,
+	}
+}
+
+// ImportState merges a snapshot into the local state. The latest timestamp
+// wins for done notifications, and bookmarks are the union of both.
+func ImportState(snapshot StateSnapshot) StateImportResult {
+	return StateImportResult{
+		Done:      GetDoneStore().Merge(snapshot.Done),
+		Bookmarks: GetBookmarkStore().AddAll(snapshot.Bookmarks),
+	}
+}
+
+// FlushState saves the done notifications and bookmarks synchronously.
+func FlushState() error {
+	return errors.Join(GetDoneStore().Flush(), GetBookmarkStore().Flush())
+}
+
+// ReadStateSnapshot decodes a snapshot written by WriteStateSnapshot.
+func ReadStateSnapshot(r io.Reader) (StateSnapshot, error) {
+	var snapshot StateSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return StateSnapshot{}, fmt.Errorf("failed decoding state: %w", err)
+	}
+	if snapshot.Version > stateSnapshotVersion {
+		return StateSnapshot{}, fmt.Errorf(
+			"state version %d is newer than the supported version %d, upgrade gh-dash",
+			snapshot.Version, stateSnapshotVersion)
+	}
+	return snapshot, nil
+}
+
+// WriteStateSnapshot encodes a snapshot as indented JSON.
+func WriteStateSnapshot(w io.Writer, snapshot StateSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// SyncState merges the state file in dir, usually a folder synced between
+// machines, into the local state and writes the merged state back. A lock
+// file keeps concurrent instances from overwriting each other's changes.
+func SyncState(dir string) error {
+	return syncState(dir, stateLockTimeout)
+}
+
+// TrySyncState is SyncState without waiting for the lock, for syncing when
+// quitting. It returns ErrStateLocked if another instance is syncing.
+func TrySyncState(dir string) error {
+	return syncState(dir, 0)
+}
+
+func syncState(dir string, lockTimeout time.Duration) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, stateSyncFilename)
+	unlock, err := lockStateFile(path+".lock", lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := os.Open(path)
+	if err == nil {
+		snapshot, err := ReadStateSnapshot(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed reading %s: %w", path, err)
+		}
+		res := ImportState(snapshot)
+		log.Debug("Imported synced state", "path", path, "done", res.Done,
+			"bookmarks", res.Bookmarks)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := writeStateFile(path, ExportState()); err != nil {
+		return err
+	}
+	return FlushState()
+}
+
+// writeStateFile atomically replaces the state file at path
+func writeStateFile(path string, snapshot StateSnapshot) error {
+	var buf bytes.Buffer
+	if err := WriteStateSnapshot(&buf, snapshot); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// lockStateFile creates the lock file at path, waiting up to timeout for
+// another instance to remove it, or trying once if timeout is 0. It returns a
+// function that removes it.
+func lockStateFile(path string, timeout time.Duration) (func(), error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			hostname, _ := os.Hostname()
+			fmt.Fprintf(f, "%s %d\n", hostname, os.Getpid())
+			f.Close()
+			return func() {
+				if err := os.Remove(path); err != nil {
+					log.Error("Failed removing state lock", "path", path, "err", err)
+				}
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleStateLockAge {
+			log.Warn("Removing stale state lock", "path", path, "modTime", info.ModTime())
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for %s: %w", path, ErrStateLocked)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,154 @@
+package data
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// overrideStateStoresForTesting overrides the stores with empty ones in a
+// temp dir and returns the done and bookmark stores.
+func overrideStateStoresForTesting(t *testing.T) (*DoneStore, *NotificationIDStore) {
+	t.Helper()
+	t.Cleanup(OverrideStateStoresForTesting(t.TempDir()))
+	return GetDoneStore(), GetBookmarkStore()
+}
+
+func TestImportState(t *testing.T) {
+	done, bookmarks := overrideStateStoresForTesting(t)
+	older := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	newer := older.Add(time.Hour)
+	done.entries["1"] = newer
+	done.entries["2"] = older
+	bookmarks.ids["a"] = true
+
+	res := ImportState(StateSnapshot{
+		Version: stateSnapshotVersion,
+		Done: map[string]time.Time{
+			"1": older,
+			"2": newer,
+			"3": older,
+			"4": time.Now().Add(-100 * 24 * time.Hour),
+		},
+		Bookmarks: []string{"a", "b"},
+	})
+
+	if res.Done != 2 || res.Bookmarks != 1 {
+		t.Errorf("ImportState() = %+v, want 2 done and 1 bookmark", res)
+	}
+	want := map[string]time.Time{"1": newer, "2": newer, "3": older}
+	for id, doneAt := range want {
+		if !done.entries[id].Equal(doneAt) {
+			t.Errorf("done[%s] = %v, want the latest timestamp %v", id, done.entries[id], doneAt)
+		}
+	}
+	if _, ok := done.entries["4"]; ok {
+		t.Error("Entries old enough to be pruned should NOT be imported")
+	}
+	if got := bookmarks.GetAll(); len(got) != 2 {
+		t.Errorf("bookmarks = %v, want the union [a b]", got)
+	}
+}
+
+func TestStateSnapshotRoundTrip(t *testing.T) {
+	done, bookmarks := overrideStateStoresForTesting(t)
+	doneAt := time.Now().Truncate(time.Second).UTC()
+	done.entries["1"] = doneAt
+	bookmarks.ids["b"] = true
+	bookmarks.ids["a"] = true
+
+	var buf bytes.Buffer
+	if err := WriteStateSnapshot(&buf, ExportState()); err != nil {
+		t.Fatalf("WriteStateSnapshot() error: %v", err)
+	}
+	snapshot, err := ReadStateSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("ReadStateSnapshot() error: %v", err)
+	}
+	if !snapshot.Done["1"].Equal(doneAt) || !slices.Equal(snapshot.Bookmarks, []string{"a", "b"}) {
+		t.Errorf("ReadStateSnapshot() = %+v, want the exported state", snapshot)
+	}
+
+	_, err = ReadStateSnapshot(strings.NewReader(`{"version": 2}`))
+	if err == nil {
+		t.Error("ReadStateSnapshot() should fail on a newer version")
+	}
+}
+
+func TestSyncState(t *testing.T) {
+	syncDir := t.TempDir()
+	doneAt := time.Now().Truncate(time.Second)
+
+	// The first machine writes its state to the sync dir
+	done, bookmarks := overrideStateStoresForTesting(t)
+	done.entries["1"] = doneAt
+	bookmarks.ids["a"] = true
+	if err := SyncState(syncDir); err != nil {
+		t.Fatalf("SyncState() error: %v", err)
+	}
+
+	// The second machine gets it and adds its own
+	done, bookmarks = overrideStateStoresForTesting(t)
+	bookmarks.ids["b"] = true
+	if err := SyncState(syncDir); err != nil {
+		t.Fatalf("SyncState() error: %v", err)
+	}
+	if !done.IsDone("1", doneAt) || !bookmarks.Has("a") {
+		t.Error("SyncState() should import the synced state")
+	}
+
+	f, err := os.Open(filepath.Join(syncDir, stateSyncFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	snapshot, err := ReadStateSnapshot(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(snapshot.Bookmarks, []string{"a", "b"}) {
+		t.Errorf("synced bookmarks = %v, want [a b]", snapshot.Bookmarks)
+	}
+	if _, err := os.Stat(filepath.Join(syncDir, stateSyncFilename+".lock")); !os.IsNotExist(err) {
+		t.Error("SyncState() should remove its lock")
+	}
+}
+
+func TestLockStateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.lock")
+
+	unlock, err := lockStateFile(path, time.Second)
+	if err != nil {
+		t.Fatalf("lockStateFile() error: %v", err)
+	}
+	if _, err := lockStateFile(path, 200*time.Millisecond); !errors.Is(err, ErrStateLocked) {
+		t.Errorf("lockStateFile() error = %v, want ErrStateLocked while another instance holds the lock", err)
+	}
+	start := time.Now()
+	if _, err := lockStateFile(path, 0); !errors.Is(err, ErrStateLocked) {
+		t.Errorf("lockStateFile() error = %v, want ErrStateLocked without waiting", err)
+	}
+	if waited := time.Since(start); waited > 50*time.Millisecond {
+		t.Errorf("lockStateFile() waited %v with no timeout", waited)
+	}
+	unlock()
+
+	// A lock left behind by a crashed instance is taken over
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * staleStateLockAge)
+	if err := os.Chtimes(path, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = lockStateFile(path, 200*time.Millisecond)
+	if err != nil {
+		t.Fatalf("lockStateFile() should take over a stale lock: %v", err)
+	}
+	unlock()
+}
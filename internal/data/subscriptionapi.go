@@ -0,0 +1,89 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"charm.land/log/v2"
+)
+
+// SubscriptionState is how closely a repository is watched
+type SubscriptionState string
+
+const (
+	// SubscriptionWatching notifies about all activity of the repository
+	SubscriptionWatching SubscriptionState = "watching"
+	// SubscriptionParticipating only notifies about threads you participate
+	// in or are @mentioned in
+	SubscriptionParticipating SubscriptionState = "participating"
+	// SubscriptionIgnoring never notifies about the repository
+	SubscriptionIgnoring SubscriptionState = "ignoring"
+)
+
+// maxWatchedRepoPages bounds how many pages of watched repositories are
+// fetched
+const maxWatchedRepoPages = 10
+
+// FetchWatchedRepos fetches the full names of the repositories the user
+// watches. GitHub doesn't list the ignored ones.
+func FetchWatchedRepos() ([]string, error) {
+	client, err := getRESTClient()
+	if err != nil {
+		return nil, err
+	}
+
+	const perPage = 100
+	var repos []string
+	for page := 1; page <= maxWatchedRepoPages; page++ {
+		var res []struct {
+			FullName string `json:"full_name"`
+		}
+		path := fmt.Sprintf("user/subscriptions?per_page=%d&page=%d", perPage, page)
+		if err := client.Get(path, &res); err != nil {
+			return nil, err
+		}
+		for _, r := range res {
+			repos = append(repos, r.FullName)
+		}
+		if len(res) < perPage {
+			break
+		}
+	}
+	log.Debug("Fetched watched repos", "count", len(repos))
+	return repos, nil
+}
+
+// SetRepoSubscription watches, ignores or stops watching a repository. Not
+// watching a repository still notifies about the threads you participate in.
+func SetRepoSubscription(repo string, state SubscriptionState) error {
+	client, err := getRESTClient()
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("repos/%s/subscription", repo)
+	log.Debug("Setting repo subscription", "repo", repo, "state", state)
+	switch state {
+	case SubscriptionParticipating:
+		// DELETE returns 204 No Content
+		err = client.Delete(path, nil)
+	case SubscriptionWatching, SubscriptionIgnoring:
+		body, marshalErr := json.Marshal(map[string]bool{
+			"subscribed": state == SubscriptionWatching,
+			"ignored":    state == SubscriptionIgnoring,
+		})
+		if marshalErr != nil {
+			return marshalErr
+		}
+		err = client.Put(path, bytes.NewReader(body), nil)
+	default:
+		return fmt.Errorf("unknown subscription state %q", state)
+	}
+	if err != nil {
+		return err
+	}
+	invalidateNotificationPolls()
+	log.Info("Successfully set repo subscription", "repo", repo, "state", state)
+	return nil
+}
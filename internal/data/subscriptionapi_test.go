@@ -0,0 +1,72 @@
+package data
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchWatchedRepos(t *testing.T) {
+	originalClient := restClient
+	t.Cleanup(func() {
+		restClient = originalClient
+	})
+
+	var pages []string
+	restClient = newNotificationsClient(t, func(req *http.Request) (*http.Response, error) {
+		page := req.URL.Query().Get("page")
+		pages = append(pages, page)
+		body := `[]`
+		if page == "1" {
+			repos := make([]string, 100)
+			for i := range repos {
+				repos[i] = `{"full_name": "o/r"}`
+			}
+			body = "[" + strings.Join(repos, ",") + "]"
+		} else if page == "2" {
+			body = `[{"full_name": "o/last"}]`
+		}
+		return notificationsResponse(req, http.StatusOK, http.Header{}, body), nil
+	})
+
+	repos, err := FetchWatchedRepos()
+	require.NoError(t, err)
+	require.Len(t, repos, 101)
+	require.Equal(t, "o/last", repos[100])
+	require.Equal(t, []string{"1", "2"}, pages, "fetching stops at the last page")
+}
+
+func TestSetRepoSubscription(t *testing.T) {
+	originalClient := restClient
+	t.Cleanup(func() {
+		restClient = originalClient
+	})
+
+	var requests []string
+	restClient = newNotificationsClient(t, func(req *http.Request) (*http.Response, error) {
+		body := ""
+		if req.Body != nil {
+			b, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			body = string(b)
+		}
+		requests = append(requests, req.Method+" "+req.URL.Path+" "+body)
+		if req.Method == http.MethodDelete {
+			return notificationsResponse(req, http.StatusNoContent, http.Header{}, ""), nil
+		}
+		return notificationsResponse(req, http.StatusOK, http.Header{}, `{}`), nil
+	})
+
+	require.NoError(t, SetRepoSubscription("o/r", SubscriptionWatching))
+	require.NoError(t, SetRepoSubscription("o/r", SubscriptionIgnoring))
+	require.NoError(t, SetRepoSubscription("o/r", SubscriptionParticipating))
+	require.Error(t, SetRepoSubscription("o/r", "unknown"))
+	require.Equal(t, []string{
+		`PUT /repos/o/r/subscription {"ignored":false,"subscribed":true}`,
+		`PUT /repos/o/r/subscription {"ignored":true,"subscribed":false}`,
+		`DELETE /repos/o/r/subscription `,
+	}, requests)
+}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"net/url"
+	"slices"
+	"time"
+
+	"charm.land/log/v2"
+	"github.com/shurcooL/githubv4"
+)
+
+// State event types of PR and issue timelines
+const (
+	StateEventClosed         = "ClosedEvent"
+	StateEventReopened       = "ReopenedEvent"
+	StateEventMerged         = "MergedEvent"
+	StateEventReadyForReview = "ReadyForReviewEvent"
+	StateEventConvertToDraft = "ConvertToDraftEvent"
+)
+
+// StateEvent is a PR or issue being closed, reopened, merged, marked as ready
+// for review or converted to a draft.
+type StateEvent struct {
+	Type      string
+	Actor     string
+	CreatedAt time.Time
+}
+
+type stateEventFields struct {
+	Actor struct {
+		Login string
+	}
+	CreatedAt time.Time
+}
+
+type stateEventNodes struct {
+	Nodes []struct {
+		Typename            string           `graphql:"__typename"`
+		ClosedEvent         stateEventFields `graphql:"... on ClosedEvent"`
+		ReopenedEvent       stateEventFields `graphql:"... on ReopenedEvent"`
+		MergedEvent         stateEventFields `graphql:"... on MergedEvent"`
+		ReadyForReviewEvent stateEventFields `graphql:"... on ReadyForReviewEvent"`
+		ConvertToDraftEvent stateEventFields `graphql:"... on ConvertToDraftEvent"`
+	}
+}
+
+func (n stateEventNodes) events() []StateEvent {
+	events := make([]StateEvent, 0, len(n.Nodes))
+	for _, node := range n.Nodes {
+		var fields stateEventFields
+		switch node.Typename {
+		case StateEventClosed:
+			fields = node.ClosedEvent
+		case StateEventReopened:
+			fields = node.ReopenedEvent
+		case StateEventMerged:
+			fields = node.MergedEvent
+		case StateEventReadyForReview:
+			fields = node.ReadyForReviewEvent
+		case StateEventConvertToDraft:
+			fields = node.ConvertToDraftEvent
+		default:
+			continue
+		}
+		events = append(events, StateEvent{
+			Type:      node.Typename,
+			Actor:     fields.Actor.Login,
+			CreatedAt: fields.CreatedAt,
+		})
+	}
+	slices.SortFunc(events, func(a, b StateEvent) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return events
+}
+
+// FetchStateEvents fetches the latest state changes of a PR or issue, oldest
+// first. The enriched PR and issue queries only have the current state.
+func FetchStateEvents(subjectUrl string) ([]StateEvent, error) {
+	client, err := getGraphQLClient(HostFromUrl(subjectUrl))
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResult struct {
+		Resource struct {
+			PullRequest struct {
+				TimelineItems stateEventNodes `graphql:"timelineItems(last: 20, itemTypes: [CLOSED_EVENT, REOPENED_EVENT, MERGED_EVENT, READY_FOR_REVIEW_EVENT, CONVERT_TO_DRAFT_EVENT])"`
+			} `graphql:"... on PullRequest"`
+			Issue struct {
+				TimelineItems stateEventNodes `graphql:"timelineItems(last: 20, itemTypes: [CLOSED_EVENT, REOPENED_EVENT])"`
+			} `graphql:"... on Issue"`
+		} `graphql:"resource(url: $url)"`
+	}
+	parsedUrl, err := url.Parse(subjectUrl)
+	if err != nil {
+		return nil, err
+	}
+	variables := map[string]any{
+		"url": githubv4.URI{URL: parsedUrl},
+	}
+	log.Debug("Fetching state events", "url", subjectUrl)
+	err = client.Query("FetchStateEvents", &queryResult, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both fragments decode the same timeline, whichever type the subject is
+	if events := queryResult.Resource.PullRequest.TimelineItems.events(); len(events) > 0 {
+		return events, nil
+	}
+	return queryResult.Resource.Issue.TimelineItems.events(), nil
+}
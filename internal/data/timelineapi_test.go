@@ -0,0 +1,64 @@
+package data
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchStateEvents(t *testing.T) {
+	originalClient := client
+	t.Cleanup(func() {
+		client = originalClient
+	})
+
+	var query string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Query string
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		query = body.Query
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body: io.NopCloser(strings.NewReader(`{
+				"data": {"resource": {"timelineItems": {"nodes": [
+					{"__typename": "MergedEvent", "actor": {"login": "bob"}, "createdAt": "2026-01-16T10:00:00Z"},
+					{"__typename": "ReadyForReviewEvent", "actor": {"login": "alice"}, "createdAt": "2026-01-15T10:00:00Z"}
+				]}}}
+			}`)),
+		}, nil
+	})
+	var err error
+	client, err = gh.NewGraphQLClient(gh.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "token",
+		Transport: transport,
+	})
+	require.NoError(t, err)
+
+	events, err := FetchStateEvents("https://github.com/o/r/pull/1")
+	require.NoError(t, err)
+	require.Contains(t, query, "... on PullRequest{timelineItems(")
+	require.Contains(t, query, "... on Issue{timelineItems(")
+	require.Equal(t, []StateEvent{
+		{
+			Type:      StateEventReadyForReview,
+			Actor:     "alice",
+			CreatedAt: time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Type:      StateEventMerged,
+			Actor:     "bob",
+			CreatedAt: time.Date(2026, 1, 16, 10, 0, 0, 0, time.UTC),
+		},
+	}, events, "events are sorted oldest first")
+}
@@ -4,7 +4,6 @@ import (
 	"sync"
 
 	"charm.land/log/v2"
-	gh "github.com/cli/go-gh/v2/pkg/api"
 	graphql "github.com/cli/shurcooL-graphql"
 )
 
@@ -57,7 +56,7 @@ func FetchRepoUsers(owner, repoName string) ([]User, error) {
 	// Initialize client if needed
 	if client == nil {
 		var err error
-		client, err = gh.DefaultGraphQLClient()
+		client, err = newGraphQLClient()
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,129 @@
+package git
+
+import (
+	"errors"
+	"maps"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	gitm "github.com/aymanbagabas/git-module"
+)
+
+// FindClones returns the clones in root and in the directories below it, up
+// to depth levels deep. Hidden directories aren't searched, and neither are
+// the clones themselves, so their submodules and worktrees are left out.
+func FindClones(root string, depth int) ([]string, error) {
+	clones := make([]string, 0)
+	var walk func(dir string, level int) error
+	walk = func(dir string, level int) error {
+		if info, err := os.Stat(filepath.Join(dir, ".git")); err == nil && info.IsDir() {
+			clones = append(clones, dir)
+			return nil
+		}
+		if level >= depth {
+			return nil
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			// A directory that can't be read is skipped rather than failing
+			// the whole search
+			_ = walk(filepath.Join(dir, entry.Name()), level+1)
+		}
+		return nil
+	}
+	if err := walk(filepath.Clean(root), 0); err != nil {
+		return nil, err
+	}
+	return clones, nil
+}
+
+// GetRemoteUrls returns the fetch URL of each of the repo's remotes by the
+// remote's name
+func GetRemoteUrls(dir string) (map[string]string, error) {
+	urls := make(map[string]string)
+	stdout, err := gitm.NewCommand(
+		"config", "--get-regexp", `^remote\..*\.url$`,
+	).RunInDir(dir)
+	if err != nil {
+		// git config exits with 1 when nothing matches, like for a repo
+		// without remotes
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return urls, nil
+		}
+		return nil, err
+	}
+	for line := range strings.SplitSeq(strings.TrimSpace(string(stdout)), "\n") {
+		key, rawUrl, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		remote := strings.TrimSuffix(strings.TrimPrefix(key, "remote."), ".url")
+		urls[remote] = strings.TrimSpace(rawUrl)
+	}
+	return urls, nil
+}
+
+// ParseRemoteUrl returns the host, owner and name of the repo a remote URL
+// points to, for https and ssh URLs like https://github.com/owner/repo.git and
+// scp-like ones like git@github.com:owner/repo.git
+func ParseRemoteUrl(rawUrl string) (host string, owner string, repo string, ok bool) {
+	var repoPath string
+	if strings.Contains(rawUrl, "://") {
+		parsed, err := url.Parse(rawUrl)
+		if err != nil {
+			return "", "", "", false
+		}
+		host, repoPath = parsed.Hostname(), parsed.Path
+	} else {
+		// scp-like syntax, [user@]host:path
+		hostPart, pathPart, found := strings.Cut(rawUrl, ":")
+		if !found {
+			return "", "", "", false
+		}
+		_, host, _ = strings.Cut(hostPart, "@")
+		if host == "" {
+			host = hostPart
+		}
+		repoPath = pathPart
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	owner, repo, found := strings.Cut(repoPath, "/")
+	if host == "" || !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", "", false
+	}
+	return strings.ToLower(host), owner, repo, true
+}
+
+// FindRemote returns the name of the repo's remote that points to the repo
+// nameWithOwner, like owner/repo, on host, preferring origin when several
+// do. It falls back to origin when none does.
+func FindRemote(dir string, host string, nameWithOwner string) string {
+	urls, err := GetRemoteUrls(dir)
+	if err != nil {
+		return "origin"
+	}
+	remotes := slices.Sorted(maps.Keys(urls))
+	if i := slices.Index(remotes, "origin"); i > 0 {
+		remotes = append([]string{"origin"}, slices.Delete(remotes, i, i+1)...)
+	}
+	for _, remote := range remotes {
+		remoteHost, owner, repo, ok := ParseRemoteUrl(urls[remote])
+		if ok && strings.EqualFold(remoteHost, host) &&
+			strings.EqualFold(owner+"/"+repo, nameWithOwner) {
+			return remote
+		}
+	}
+	return "origin"
+}
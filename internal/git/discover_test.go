@@ -0,0 +1,93 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteUrl(t *testing.T) {
+	testCases := map[string]struct {
+		url   string
+		host  string
+		owner string
+		repo  string
+		ok    bool
+	}{
+		"https":              {"https://github.com/dlvhdr/gh-dash.git", "github.com", "dlvhdr", "gh-dash", true},
+		"https without .git": {"https://github.com/dlvhdr/gh-dash", "github.com", "dlvhdr", "gh-dash", true},
+		"ssh":                {"ssh://git@GitHub.example.com:2222/org/service.git", "github.example.com", "org", "service", true},
+		"scp-like":           {"git@github.com:dlvhdr/gh-dash.git", "github.com", "dlvhdr", "gh-dash", true},
+		"scp-like no user":   {"github.com:dlvhdr/gh-dash", "github.com", "dlvhdr", "gh-dash", true},
+		"local path":         {"/src/origin.git", "", "", "", false},
+		"too deep":           {"https://gitlab.com/group/sub/repo.git", "", "", "", false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			host, owner, repo, ok := ParseRemoteUrl(tc.url)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.host, host)
+			require.Equal(t, tc.owner, owner)
+			require.Equal(t, tc.repo, repo)
+		})
+	}
+}
+
+func TestFindClones(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	root := t.TempDir()
+	for _, dir := range []string{"dlvhdr/gh-dash", "org/service", "a/b/c/too-deep", ".hidden/repo"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o755))
+		runGit(t, filepath.Join(root, dir), "init", "-q")
+	}
+	runGit(t, filepath.Join(root, "dlvhdr/gh-dash"), "remote", "add", "origin",
+		"git@github.com:dlvhdr/gh-dash.git")
+	runGit(t, filepath.Join(root, "dlvhdr/gh-dash"), "remote", "add", "upstream",
+		"https://github.com/other/gh-dash")
+	// A clone's own directories aren't searched
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "org/service/vendor/lib"), 0o755))
+	runGit(t, filepath.Join(root, "org/service/vendor/lib"), "init", "-q")
+
+	clones, err := FindClones(root, 3)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		filepath.Join(root, "dlvhdr/gh-dash"),
+		filepath.Join(root, "org/service"),
+	}, clones)
+
+	urls, err := GetRemoteUrls(filepath.Join(root, "dlvhdr/gh-dash"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"origin":   "git@github.com:dlvhdr/gh-dash.git",
+		"upstream": "https://github.com/other/gh-dash",
+	}, urls)
+
+	urls, err = GetRemoteUrls(filepath.Join(root, "org/service"))
+	require.NoError(t, err)
+	require.Empty(t, urls)
+
+	_, err = FindClones(filepath.Join(root, "missing"), 3)
+	require.Error(t, err)
+}
+
+func TestFindRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "remote", "add", "origin", "git@github.com:me/repo.git")
+	runGit(t, dir, "remote", "add", "upstream", "https://github.com/owner/repo.git")
+	runGit(t, dir, "remote", "add", "enterprise", "https://ghe.example.com/owner/repo.git")
+
+	require.Equal(t, "upstream", FindRemote(dir, "github.com", "owner/repo"))
+	require.Equal(t, "enterprise", FindRemote(dir, "ghe.example.com", "Owner/Repo"))
+	require.Equal(t, "origin", FindRemote(dir, "github.com", "me/repo"))
+	require.Equal(t, "origin", FindRemote(dir, "github.com", "other/repo"))
+}
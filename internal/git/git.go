@@ -4,7 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
-	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -17,11 +17,27 @@ import (
 // Extends git.Repository
 type Repo struct {
 	gitm.Repository
-	Origin         string
-	Remotes        []string
-	Branches       []Branch
+	Origin   string
+	Remotes  []string
+	Branches []Branch
+	// HeadBranchName is the checked out branch, empty when HEAD is detached
 	HeadBranchName string
-	Status         gitm.NameStatus
+	Status         Status
+	Worktrees      []Worktree
+	// DefaultBranch is the remote default branch, like origin/main, which
+	// branches are compared with. It's empty when it can't be found.
+	DefaultBranch string
+}
+
+// Status is the working tree's changes compared with HEAD
+type Status struct {
+	gitm.NameStatus
+	// Files are the changed files, staged and not, and untracked files
+	Files []FileStatus
+	// Conflicted are the files with unresolved conflicts
+	Conflicted []string
+	// Rebase is the rebase in progress, if any
+	Rebase *RebaseState
 }
 
 type Branch struct {
@@ -33,6 +49,29 @@ type Branch struct {
 	CommitsBehind int
 	IsCheckedOut  bool
 	Remotes       []string
+	// Oid is the commit the branch points to
+	Oid string
+	// WorktreePath is the path of the other worktree the branch is checked
+	// out in, if any
+	WorktreePath string
+	// Upstream is the remote branch the branch tracks, like origin/feat or
+	// fork/feat, and CommitsAhead and CommitsBehind are relative to it
+	Upstream string
+	// UpstreamGone is true when the upstream was deleted from its remote,
+	// like after its PR merged
+	UpstreamGone bool
+	// BaseBranch is the repo's default branch, and BaseAhead and BaseBehind
+	// are the commits the branch has that it doesn't, and the other way
+	// around
+	BaseBranch string
+	BaseAhead  int
+	BaseBehind int
+	// IsMerged is true when the default branch has all the commits of the
+	// branch. Squash merged branches are only known to be merged by their PR.
+	// Branches without commits of their own, like new ones, aren't merged:
+	// those that don't track a remote branch, and those pointing at the
+	// default branch's tip, unless their upstream was deleted.
+	IsMerged bool
 }
 
 func GetOriginUrl(dir string) (string, error) {
@@ -66,50 +105,96 @@ func GetRepo(dir string) (*Repo, error) {
 		return nil, err
 	}
 
-	bNames, err := repo.Branches()
+	status, err := getUnstagedStatus(repo)
 	if err != nil {
 		return nil, err
 	}
 
-	headRef, err := repo.RevParse("HEAD", gitm.RevParseOptions{
-		CommandOptions: gitm.CommandOptions{Args: []string{"--abbrev-ref"}},
-	})
+	remotes, err := repo.Remotes(
+		gitm.RemotesOptions{CommandOptions: gitm.CommandOptions{Args: []string{"show"}}},
+	)
 	if err != nil {
 		return nil, err
 	}
-	status, err := getUnstagedStatus(repo)
+	defaultBranch := getDefaultBranch(dir, remotes)
+
+	refs, err := listRefs(dir, defaultBranch)
 	if err != nil {
 		return nil, err
 	}
+	remoteRefs := make(map[string]bool)
+	var baseOid string
+	for _, ref := range refs {
+		if name, ok := strings.CutPrefix(ref.name, refsRemotes); ok {
+			remoteRefs[name] = true
+		}
+		if ref.name == refsRemotes+defaultBranch || ref.name == gitm.RefsHeads+defaultBranch {
+			baseOid = ref.oid
+		}
+	}
 
-	branches := make([]Branch, len(bNames))
-	for i, b := range bNames {
-		var updatedAt *time.Time
-		var lastCommitMsg *string
-		isHead := b == headRef
-		commits, err := gitm.Log(dir, b, gitm.LogOptions{MaxCount: 1})
-		if err == nil && len(commits) > 0 {
-			updatedAt = &commits[0].Committer.When
-			lastCommitMsg = utils.StringPtr(commits[0].Summary())
+	branches := make([]Branch, 0, len(refs))
+	for _, ref := range refs {
+		b, ok := strings.CutPrefix(ref.name, gitm.RefsHeads)
+		if !ok {
+			continue
+		}
+		updatedAt := ref.updatedAt
+		commitsAhead, commitsBehind := ref.ahead, ref.behind
+		// Branches that don't track a remote branch are compared with the
+		// branch of the same name on origin, if there's one
+		if ref.upstream == "" && remoteRefs["origin/"+b] {
+			commitsAhead, commitsBehind, _ = revListCounts(dir, "origin/"+b, ref.name)
 		}
-		commitsAhead, err := repo.RevListCount([]string{fmt.Sprintf("origin/%s..%s", b, b)})
-		if err != nil {
-			commitsAhead = 0
+		baseAhead, baseBehind, hasBaseCounts := ref.baseAhead, ref.baseBehind, ref.hasBaseCounts
+		if defaultBranch != "" && !hasBaseCounts {
+			baseAhead, baseBehind, hasBaseCounts = revListCounts(dir, defaultBranch, ref.name)
 		}
-		commitsBehind, err := repo.RevListCount([]string{fmt.Sprintf("%s..origin/%s", b, b)})
-		if err != nil {
-			commitsBehind = 0
+		isNew := ref.upstream == "" || (ref.oid == baseOid && !ref.upstreamGone)
+		var branchRemotes []string
+		if slices.Contains(remotes, b) {
+			branchRemotes, _ = repo.RemoteGetURL(b)
 		}
-		remotes, _ := repo.RemoteGetURL(b)
-		branches[i] = Branch{
+		branches = append(branches, Branch{
 			Name:          b,
-			LastUpdatedAt: updatedAt,
-			CreatedAt:     updatedAt,
-			IsCheckedOut:  isHead,
-			Remotes:       remotes,
-			LastCommitMsg: lastCommitMsg,
-			CommitsAhead:  int(commitsAhead),
-			CommitsBehind: int(commitsBehind),
+			Oid:           ref.oid,
+			LastUpdatedAt: &updatedAt,
+			CreatedAt:     &updatedAt,
+			IsCheckedOut:  ref.isHead || (status.Rebase != nil && status.Rebase.Branch == b),
+			Remotes:       branchRemotes,
+			LastCommitMsg: utils.StringPtr(ref.subject),
+			CommitsAhead:  commitsAhead,
+			CommitsBehind: commitsBehind,
+			Upstream:      ref.upstream,
+			UpstreamGone:  ref.upstreamGone,
+			BaseBranch:    defaultBranch,
+			BaseAhead:     baseAhead,
+			BaseBehind:    baseBehind,
+			IsMerged:      hasBaseCounts && baseAhead == 0 && !isNew,
+		})
+	}
+
+	headBranch, err := repo.SymbolicRef()
+	if err != nil {
+		// HEAD is detached while a branch is rebased, or after checking out
+		// a commit, when no branch is checked out
+		headBranch = ""
+		if status.Rebase != nil {
+			headBranch = status.Rebase.Branch
+		}
+	}
+	headBranch, _ = strings.CutPrefix(headBranch, gitm.RefsHeads)
+
+	// Worktrees are optional, a failure to list them shouldn't hide the branches
+	worktrees, _ := ListWorktrees(dir)
+	for _, wt := range worktrees {
+		if wt.Branch == "" || wt.Branch == headBranch {
+			continue
+		}
+		for i := range branches {
+			if branches[i].Name == wt.Branch {
+				branches[i].WorktreePath = wt.Path
+			}
 		}
 	}
 	sort.Slice(branches, func(i, j int) bool {
@@ -119,45 +204,34 @@ func GetRepo(dir string) (*Repo, error) {
 		return branches[i].LastUpdatedAt.After(*branches[j].LastUpdatedAt)
 	})
 
-	headBranch, err := repo.SymbolicRef()
-	if err != nil {
-		return nil, err
-	}
-	headBranch, _ = strings.CutPrefix(headBranch, gitm.RefsHeads)
-
-	remotes, err := repo.Remotes(
-		gitm.RemotesOptions{CommandOptions: gitm.CommandOptions{Args: []string{"show"}}},
-	)
-	if err != nil {
-		return nil, err
-	}
-	origin, err := gitm.RemoteGetURL(dir, "origin", gitm.RemoteGetURLOptions{All: true})
+	origin, err := getOriginUrl(dir, remotes)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Repo{
-		Repository: *repo, Origin: origin[0], Remotes: remotes,
+		Repository: *repo, Origin: origin, Remotes: remotes,
 		HeadBranchName: headBranch, Branches: branches, Status: status,
+		Worktrees: worktrees, DefaultBranch: defaultBranch,
 	}, nil
 }
 
-func GetStatus(dir string) (gitm.NameStatus, error) {
+func GetStatus(dir string) (Status, error) {
 	repo, err := gitm.Open(dir)
 	if err != nil {
-		return gitm.NameStatus{}, err
+		return Status{}, err
 	}
 	return getUnstagedStatus(repo)
 }
 
 // test
-func getUnstagedStatus(repo *gitm.Repository) (gitm.NameStatus, error) {
+func getUnstagedStatus(repo *gitm.Repository) (Status, error) {
 	cmd := gitm.NewCommand("diff", "HEAD", "--name-status")
 	stdout, err := cmd.RunInDir(repo.Path())
 	if err != nil {
-		return gitm.NameStatus{}, err
+		return Status{}, err
 	}
-	status := gitm.NameStatus{}
+	status := Status{}
 	scanner := bufio.NewScanner(bytes.NewReader(stdout))
 	for scanner.Scan() {
 		fields := strings.Fields(scanner.Text())
@@ -174,6 +248,22 @@ func getUnstagedStatus(repo *gitm.Repository) (gitm.NameStatus, error) {
 			status.Modified = append(status.Modified, fields[1])
 		}
 	}
+
+	status.Files, err = getFileStatuses(repo.Path())
+	if err != nil {
+		return Status{}, err
+	}
+	for _, file := range status.Files {
+		if file.IsConflicted() {
+			status.Conflicted = append(status.Conflicted, file.Path)
+		}
+	}
+	// Diffing with HEAD reports conflicted files as modified
+	status.Modified = slices.DeleteFunc(status.Modified, func(file string) bool {
+		return slices.Contains(status.Conflicted, file)
+	})
+
+	status.Rebase, err = getRebaseState(repo.Path())
 	return status, err
 }
 
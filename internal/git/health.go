@@ -0,0 +1,63 @@
+package git
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	gitm "github.com/aymanbagabas/git-module"
+)
+
+// getDefaultBranch returns the default branch of the repo's main remote,
+// like origin/main. Forks usually name the repo they were forked from
+// upstream, so that remote is preferred over origin. When no remote knows
+// its default branch, a local main or master branch is used.
+func getDefaultBranch(dir string, remotes []string) string {
+	candidates := make([]string, 0, len(remotes))
+	for _, preferred := range []string{"upstream", "origin"} {
+		if slices.Contains(remotes, preferred) {
+			candidates = append(candidates, preferred)
+		}
+	}
+	for _, remote := range remotes {
+		if !slices.Contains(candidates, remote) {
+			candidates = append(candidates, remote)
+		}
+	}
+
+	for _, remote := range candidates {
+		stdout, err := gitm.NewCommand(
+			"symbolic-ref", "--quiet", "--short", fmt.Sprintf("refs/remotes/%s/HEAD", remote),
+		).RunInDir(dir)
+		if err == nil {
+			return strings.TrimSpace(string(stdout))
+		}
+	}
+
+	for _, name := range []string{"main", "master"} {
+		_, err := gitm.NewCommand(
+			"rev-parse", "--verify", "--quiet", gitm.RefsHeads+name,
+		).RunInDir(dir)
+		if err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// getOriginUrl returns the URL of the origin remote, or of the first remote
+// when there's no origin, and an empty string for repos without remotes.
+func getOriginUrl(dir string, remotes []string) (string, error) {
+	if len(remotes) == 0 {
+		return "", nil
+	}
+	remote := remotes[0]
+	if slices.Contains(remotes, "origin") {
+		remote = "origin"
+	}
+	urls, err := gitm.RemoteGetURL(dir, remote, gitm.RemoteGetURLOptions{All: true})
+	if err != nil || len(urls) == 0 {
+		return "", err
+	}
+	return urls[0], nil
+}
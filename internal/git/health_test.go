@@ -0,0 +1,120 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// runGit runs a git command in dir, failing the test if it fails
+func runGit(t testing.TB, dir string, args ...string) {
+	t.Helper()
+	c := exec.Command("git", args...)
+	c.Dir = dir
+	c.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+	)
+	out, err := c.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, out)
+}
+
+func commitFile(t testing.TB, dir string, name string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644))
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-q", "-m", "add "+name)
+}
+
+// newClone creates a repo with a main branch, pushes it to a bare origin
+// and returns a clone of it
+func newClone(t testing.TB) string {
+	t.Helper()
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	seed := filepath.Join(root, "seed")
+	clone := filepath.Join(root, "clone")
+
+	runGit(t, root, "init", "-q", "--bare", "-b", "main", origin)
+	runGit(t, root, "init", "-q", "-b", "main", seed)
+	commitFile(t, seed, "README.md")
+	runGit(t, seed, "push", "-q", origin, "main")
+	runGit(t, root, "clone", "-q", origin, clone)
+	return clone
+}
+
+func findBranch(t *testing.T, repo *Repo, name string) Branch {
+	t.Helper()
+	for _, b := range repo.Branches {
+		if b.Name == name {
+			return b
+		}
+	}
+	require.Failf(t, "branch not found", "no branch %s", name)
+	return Branch{}
+}
+
+func TestGetRepoBranchHealth(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := newClone(t)
+
+	// pushed and then merged into main
+	runGit(t, dir, "checkout", "-q", "-b", "merged")
+	commitFile(t, dir, "merged.txt")
+	runGit(t, dir, "push", "-q", "-u", "origin", "merged")
+	runGit(t, dir, "checkout", "-q", "main")
+	runGit(t, dir, "merge", "-q", "--ff-only", "merged")
+	runGit(t, dir, "push", "-q", "origin", "main", ":merged")
+	runGit(t, dir, "fetch", "-q", "--prune")
+
+	// pushed, with a local commit on top, and behind main
+	runGit(t, dir, "checkout", "-q", "-b", "feat", "HEAD~1")
+	commitFile(t, dir, "feat.txt")
+	runGit(t, dir, "push", "-q", "-u", "origin", "feat")
+	commitFile(t, dir, "feat2.txt")
+
+	// new, without commits of their own
+	runGit(t, dir, "branch", "new", "main")
+	runGit(t, dir, "branch", "--track", "new-tracking", "origin/main")
+
+	// never pushed
+	runGit(t, dir, "checkout", "-q", "-b", "local", "main")
+	commitFile(t, dir, "local.txt")
+
+	runGit(t, dir, "remote", "set-head", "origin", "main")
+
+	repo, err := GetRepo(dir)
+	require.NoError(t, err)
+	require.Equal(t, "origin/main", repo.DefaultBranch)
+
+	merged := findBranch(t, repo, "merged")
+	require.Equal(t, "origin/merged", merged.Upstream)
+	require.True(t, merged.UpstreamGone)
+	require.True(t, merged.IsMerged)
+	require.Equal(t, 0, merged.BaseAhead)
+
+	feat := findBranch(t, repo, "feat")
+	require.Equal(t, "origin/feat", feat.Upstream)
+	require.False(t, feat.UpstreamGone)
+	require.False(t, feat.IsMerged)
+	require.Equal(t, 1, feat.CommitsAhead)
+	require.Equal(t, 0, feat.CommitsBehind)
+	require.Equal(t, 2, feat.BaseAhead)
+	require.Equal(t, 1, feat.BaseBehind)
+
+	local := findBranch(t, repo, "local")
+	require.Empty(t, local.Upstream)
+	require.Equal(t, 1, local.BaseAhead)
+	require.Equal(t, 0, local.BaseBehind)
+	require.True(t, local.IsCheckedOut)
+
+	require.False(t, findBranch(t, repo, "new").IsMerged)
+	require.False(t, findBranch(t, repo, "new-tracking").IsMerged)
+	require.False(t, findBranch(t, repo, "main").IsMerged)
+}
@@ -0,0 +1,77 @@
+package git
+
+import (
+	"strings"
+
+	gitm "github.com/aymanbagabas/git-module"
+)
+
+// CommitMessage is the message of a commit, split into its subject and body
+type CommitMessage struct {
+	Subject string
+	Body    string
+}
+
+// ListCommitMessages returns the messages of the commits branch has that
+// base doesn't, oldest first
+func ListCommitMessages(dir string, base string, branch string) ([]CommitMessage, error) {
+	stdout, err := gitm.NewCommand(
+		"log", "--reverse", "--format=%s%x00%b%x1e", base+".."+branch, "--",
+	).RunInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return parseCommitMessages(stdout), nil
+}
+
+// parseCommitMessages parses the log of ListCommitMessages, whose commits
+// end with a record separator and have their subject and body separated by
+// a NUL character
+func parseCommitMessages(out []byte) []CommitMessage {
+	commits := make([]CommitMessage, 0)
+	for record := range strings.SplitSeq(string(out), "\x1e") {
+		subject, body, ok := strings.Cut(strings.TrimLeft(record, "\n"), "\x00")
+		if !ok {
+			continue
+		}
+		commits = append(commits, CommitMessage{
+			Subject: strings.TrimSpace(subject),
+			Body:    strings.TrimSpace(body),
+		})
+	}
+	return commits
+}
+
+// ListRemoteBranches returns the names of the branches of remote, without
+// the remote's name
+func ListRemoteBranches(dir string, remote string) ([]string, error) {
+	stdout, err := gitm.NewCommand(
+		"for-each-ref", "--format=%(refname)", refsRemotes+remote+"/",
+	).RunInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	branches := make([]string, 0)
+	for ref := range strings.SplitSeq(strings.TrimSpace(string(stdout)), "\n") {
+		name, ok := strings.CutPrefix(ref, refsRemotes+remote+"/")
+		// HEAD only points to the remote's default branch
+		if !ok || name == "" || name == "HEAD" {
+			continue
+		}
+		branches = append(branches, name)
+	}
+	return branches, nil
+}
+
+// CheckoutCommit checks out the commit as a detached HEAD. A commit the
+// clone doesn't have is fetched from ref on remote first, like the
+// refs/pull/<number>/head ref of a PR from a fork.
+func CheckoutCommit(dir string, remote string, ref string, sha string) error {
+	if _, err := gitm.NewCommand("cat-file", "-e", sha+"^{commit}").RunInDir(dir); err != nil {
+		if _, err := gitm.NewCommand("fetch", "--quiet", remote, ref).RunInDir(dir); err != nil {
+			return err
+		}
+	}
+	_, err := gitm.NewCommand("checkout", "--quiet", "--detach", sha).RunInDir(dir)
+	return err
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gitm "github.com/aymanbagabas/git-module"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommitMessages(t *testing.T) {
+	out := []byte("add a\x00\x1e\nfix b\x00details\n\nmore details\n\x1e\n")
+
+	require.Equal(t, []CommitMessage{
+		{Subject: "add a", Body: ""},
+		{Subject: "fix b", Body: "details\n\nmore details"},
+	}, parseCommitMessages(out))
+	require.Empty(t, parseCommitMessages(nil))
+}
+
+func TestListCommitMessagesAndRemoteBranches(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := newClone(t)
+	runGit(t, dir, "checkout", "-q", "-b", "feat")
+	commitFile(t, dir, "a.txt")
+	commitFile(t, dir, "b.txt")
+	runGit(t, dir, "push", "-q", "origin", "feat")
+
+	commits, err := ListCommitMessages(dir, "origin/main", "feat")
+	require.NoError(t, err)
+	require.Equal(t, []CommitMessage{{Subject: "add a.txt"}, {Subject: "add b.txt"}}, commits)
+
+	branches, err := ListRemoteBranches(dir, "origin")
+	require.NoError(t, err)
+	require.Equal(t, []string{"feat", "main"}, branches)
+}
+
+func TestCheckoutCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := newClone(t)
+	// A PR's commit the clone never fetched, only on the PR's ref
+	seed := filepath.Join(filepath.Dir(dir), "seed")
+	commitFile(t, seed, "pr.txt")
+	runGit(t, seed, "push", "-q", filepath.Join(filepath.Dir(dir), "origin.git"), "HEAD:refs/pull/1/head")
+	stdout, err := gitm.NewCommand("rev-parse", "HEAD").RunInDir(seed)
+	require.NoError(t, err)
+	sha := strings.TrimSpace(string(stdout))
+
+	require.NoError(t, CheckoutCommit(dir, "origin", "refs/pull/1/head", sha))
+	stdout, err = gitm.NewCommand("rev-parse", "HEAD").RunInDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, sha, strings.TrimSpace(string(stdout)))
+	_, err = gitm.NewCommand("symbolic-ref", "-q", "HEAD").RunInDir(dir)
+	require.Error(t, err, "HEAD should be detached")
+
+	// The repo of a detached HEAD still lists its branches
+	repo, err := GetRepo(dir)
+	require.NoError(t, err)
+	require.Empty(t, repo.HeadBranchName)
+	require.False(t, findBranch(t, repo, "main").IsCheckedOut)
+
+	require.Error(t, CheckoutCommit(dir, "origin", "refs/pull/2/head", strings.Repeat("0", 40)))
+}
@@ -0,0 +1,132 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	gitm "github.com/aymanbagabas/git-module"
+)
+
+// RebaseState is a rebase that stopped before it was done, like on
+// conflicts, and is waiting to be continued or aborted.
+type RebaseState struct {
+	// Branch is the branch being rebased, empty when rebasing a detached
+	// HEAD
+	Branch string
+	// Onto is the abbreviated commit the branch is rebased onto
+	Onto string
+	// Step is the commit being applied, out of Total. Both are 0 when git
+	// doesn't report them.
+	Step  int
+	Total int
+}
+
+// getRebaseState returns the rebase in progress in the worktree at dir, or
+// nil when there's none
+func getRebaseState(dir string) (*RebaseState, error) {
+	for _, name := range []string{"rebase-merge", "rebase-apply"} {
+		stdout, err := gitm.NewCommand("rev-parse", "--git-path", name).RunInDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		stateDir := strings.TrimSpace(string(stdout))
+		if !filepath.IsAbs(stateDir) {
+			stateDir = filepath.Join(dir, stateDir)
+		}
+		if _, err := os.Stat(stateDir); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		// rebase-merge and rebase-apply name the step files differently
+		stepFile, totalFile := "msgnum", "end"
+		if name == "rebase-apply" {
+			stepFile, totalFile = "next", "last"
+		}
+		headName, _ := strings.CutPrefix(readStateFile(stateDir, "head-name"), gitm.RefsHeads)
+		onto := readStateFile(stateDir, "onto")
+		if len(onto) > 7 {
+			onto = onto[:7]
+		}
+		step, _ := strconv.Atoi(readStateFile(stateDir, stepFile))
+		total, _ := strconv.Atoi(readStateFile(stateDir, totalFile))
+		return &RebaseState{
+			Branch: headName,
+			Onto:   onto,
+			Step:   step,
+			Total:  total,
+		}, nil
+	}
+	return nil, nil
+}
+
+func readStateFile(dir string, name string) string {
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// RebaseBranch rebases branch onto upstream. It uses upstream's reflog to
+// find where branch forked from it, so only the branch's own commits are
+// replayed even after upstream was rewritten, like when restacking a branch
+// onto a parent that was rebased. It refuses to start while another rebase
+// is in progress. A rebase it started that stops on conflicts is left in
+// progress, to be resolved and continued or aborted, and one that stops for
+// any other reason is aborted.
+func RebaseBranch(dir string, upstream string, branch string) error {
+	rebase, err := getRebaseState(dir)
+	if err != nil {
+		return err
+	}
+	if rebase != nil {
+		return errors.New("a rebase is in progress, continue or abort it first")
+	}
+
+	_, err = gitm.NewCommand("rebase", "--fork-point", upstream, branch).RunInDir(dir)
+	if err == nil {
+		return nil
+	}
+	status, statusErr := GetStatus(dir)
+	if statusErr == nil && status.Rebase != nil && len(status.Conflicted) == 0 {
+		_ = AbortRebase(dir)
+	}
+	return err
+}
+
+// Rebase rebases branch onto onto, checking branch out. Unlike RebaseBranch,
+// a rebase that stops on conflicts is left in progress, to be resolved and
+// continued or aborted.
+func Rebase(dir string, onto string, branch string) error {
+	_, err := gitm.NewCommand("rebase", onto, branch).RunInDir(dir)
+	return err
+}
+
+// ContinueRebase continues the rebase in progress once its conflicts were
+// resolved and staged, keeping the messages of the commits it applies.
+func ContinueRebase(dir string) error {
+	status, err := GetStatus(dir)
+	if err != nil {
+		return err
+	}
+	if status.Rebase == nil {
+		return errors.New("no rebase in progress")
+	}
+	if len(status.Conflicted) > 0 {
+		return fmt.Errorf(
+			"resolve and stage the conflicted files first: %s", strings.Join(status.Conflicted, ", "))
+	}
+	_, err = gitm.NewCommand("-c", "core.editor=true", "rebase", "--continue").RunInDir(dir)
+	return err
+}
+
+// AbortRebase aborts the rebase in progress, restoring the branch as it was
+// before the rebase
+func AbortRebase(dir string) error {
+	_, err := gitm.NewCommand("rebase", "--abort").RunInDir(dir)
+	return err
+}
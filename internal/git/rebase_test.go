@@ -0,0 +1,77 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebaseConflicts(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := newClone(t)
+	runGit(t, dir, "config", "user.name", "test")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+
+	runGit(t, dir, "checkout", "-q", "-b", "feat")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("feat"), 0o644))
+	runGit(t, dir, "commit", "-q", "-am", "feat")
+	runGit(t, dir, "checkout", "-q", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("main"), 0o644))
+	runGit(t, dir, "commit", "-q", "-am", "main")
+
+	require.Error(t, Rebase(dir, "main", "feat"))
+
+	repo, err := GetRepo(dir)
+	require.NoError(t, err)
+	require.Equal(t, "feat", repo.HeadBranchName)
+	require.NotNil(t, repo.Status.Rebase)
+	require.Equal(t, "feat", repo.Status.Rebase.Branch)
+	require.Equal(t, []string{"README.md"}, repo.Status.Conflicted)
+	require.True(t, findBranch(t, repo, "feat").IsCheckedOut)
+
+	require.ErrorContains(t, ContinueRebase(dir), "README.md")
+	require.NoError(t, AbortRebase(dir))
+
+	status, err := GetStatus(dir)
+	require.NoError(t, err)
+	require.Nil(t, status.Rebase)
+	require.Empty(t, status.Conflicted)
+}
+
+func TestRebaseBranchKeepsRebaseInProgress(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := newClone(t)
+	runGit(t, dir, "config", "user.name", "test")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+
+	runGit(t, dir, "checkout", "-q", "-b", "feat")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("feat"), 0o644))
+	runGit(t, dir, "commit", "-q", "-am", "feat")
+	runGit(t, dir, "checkout", "-q", "-b", "other", "main")
+	commitFile(t, dir, "other.txt")
+	runGit(t, dir, "checkout", "-q", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("main"), 0o644))
+	runGit(t, dir, "commit", "-q", "-am", "main")
+
+	// A rebase that stops on conflicts is left for the user
+	require.Error(t, RebaseBranch(dir, "main", "feat"))
+	status, err := GetStatus(dir)
+	require.NoError(t, err)
+	require.NotNil(t, status.Rebase)
+	require.Equal(t, []string{"README.md"}, status.Conflicted)
+
+	// and another rebase doesn't touch it
+	require.ErrorContains(t, RebaseBranch(dir, "main", "other"), "in progress")
+	status, err = GetStatus(dir)
+	require.NoError(t, err)
+	require.NotNil(t, status.Rebase)
+	require.Equal(t, "feat", status.Rebase.Branch)
+	require.Equal(t, []string{"README.md"}, status.Conflicted)
+}
@@ -0,0 +1,201 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	gitm "github.com/aymanbagabas/git-module"
+)
+
+const refsRemotes = "refs/remotes/"
+
+// refInfo is a local or remote branch, as listed by listRefs
+type refInfo struct {
+	// name is the full name of the ref, like refs/heads/main
+	name      string
+	updatedAt time.Time
+	subject   string
+	isHead    bool
+	// oid is the commit the ref points to
+	oid string
+	// upstream is the short name of the branch's upstream, like origin/main,
+	// and ahead and behind are the commits it's ahead of and behind it
+	upstream     string
+	upstreamGone bool
+	ahead        int
+	behind       int
+	// baseAhead and baseBehind are only set when hasBaseCounts is, since git
+	// versions before 2.41 can't count them in for-each-ref
+	hasBaseCounts bool
+	baseAhead     int
+	baseBehind    int
+}
+
+// aheadBehindVersion is the first git version whose for-each-ref supports the
+// ahead-behind field
+var aheadBehindVersion = [2]int{2, 41}
+
+// supportsAheadBehind returns whether the git binary can count the commits
+// of refs ahead of and behind another ref in for-each-ref
+func supportsAheadBehind() bool {
+	version, err := gitm.BinVersion()
+	if err != nil {
+		return false
+	}
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return major > aheadBehindVersion[0] ||
+		(major == aheadBehindVersion[0] && minor >= aheadBehindVersion[1])
+}
+
+// refsFormat returns the for-each-ref format of the fields parseRefs reads,
+// separated by NUL characters
+func refsFormat(base string) string {
+	fields := []string{
+		"%(refname)",
+		"%(committerdate:unix)",
+		"%(HEAD)",
+		"%(upstream:short)",
+		"%(upstream:track,nobracket)",
+		"%(objectname)",
+		"%(contents:subject)",
+	}
+	if base != "" {
+		fields = append(fields, "%(ahead-behind:"+base+")")
+	}
+	return "--format=" + strings.Join(fields, "%00")
+}
+
+// listRefs lists the local and remote branches of the repo in a single
+// for-each-ref pass. When base isn't empty and git supports it, the commits
+// every branch is ahead of and behind base are counted in the same pass.
+func listRefs(dir string, base string) ([]refInfo, error) {
+	if base != "" && supportsAheadBehind() {
+		stdout, err := gitm.NewCommand(
+			"for-each-ref", refsFormat(base), gitm.RefsHeads, refsRemotes,
+		).RunInDir(dir)
+		if err == nil {
+			return parseRefs(stdout), nil
+		}
+		// A base that doesn't resolve fails the whole command, so the refs
+		// are listed again without the counts
+	}
+
+	stdout, err := gitm.NewCommand(
+		"for-each-ref", refsFormat(""), gitm.RefsHeads, refsRemotes,
+	).RunInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return parseRefs(stdout), nil
+}
+
+// parseRefs parses the lines of for-each-ref with the fields of refsFormat
+func parseRefs(out []byte) []refInfo {
+	refs := make([]refInfo, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\x00")
+		if len(fields) < 7 {
+			continue
+		}
+		ref := refInfo{
+			name:     fields[0],
+			isHead:   fields[2] == "*",
+			upstream: fields[3],
+			oid:      fields[5],
+			subject:  fields[6],
+		}
+		if unix, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			ref.updatedAt = time.Unix(unix, 0)
+		}
+		ref.ahead, ref.behind, ref.upstreamGone = parseTrack(fields[4])
+		if len(fields) > 7 {
+			ref.baseAhead, ref.baseBehind, ref.hasBaseCounts = parseAheadBehind(fields[7])
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// parseTrack parses the tracking info of an upstream without brackets, like
+// "ahead 1, behind 2" or "gone"
+func parseTrack(track string) (ahead int, behind int, gone bool) {
+	if track == "gone" {
+		return 0, 0, true
+	}
+	for part := range strings.SplitSeq(track, ", ") {
+		kind, count, ok := strings.Cut(part, " ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			continue
+		}
+		switch kind {
+		case "ahead":
+			ahead = n
+		case "behind":
+			behind = n
+		}
+	}
+	return ahead, behind, false
+}
+
+// parseAheadBehind parses an ahead-behind field, the commits ahead and
+// behind separated by a space
+func parseAheadBehind(field string) (int, int, bool) {
+	aheadStr, behindStr, ok := strings.Cut(field, " ")
+	if !ok {
+		return 0, 0, false
+	}
+	ahead, err := strconv.Atoi(aheadStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	behind, err := strconv.Atoi(behindStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ahead, behind, true
+}
+
+// revListCounts returns how many commits branch has that base doesn't, and
+// the other way around, in a single rev-list. ok is false when either
+// doesn't exist.
+func revListCounts(dir string, base string, branch string) (ahead int, behind int, ok bool) {
+	stdout, err := gitm.NewCommand(
+		"rev-list", "--left-right", "--count", branch+"..."+base, "--",
+	).RunInDir(dir)
+	if err != nil {
+		return 0, 0, false
+	}
+	fields := strings.Fields(string(stdout))
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	ahead, err = strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	behind, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return ahead, behind, true
+}
@@ -0,0 +1,170 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRefs(t *testing.T) {
+	out := []byte("refs/heads/main\x001700000000\x00*\x00origin/main\x00\x00aaa\x00fix: a bug\x000 3\n" +
+		"refs/heads/feat\x001700000100\x00 \x00fork/feat\x00ahead 2, behind 1\x00bbb\x00feat: things\x002 1\n" +
+		"refs/heads/merged\x001700000200\x00 \x00origin/merged\x00gone\x00ccc\x00chore: done\x00\n" +
+		"refs/remotes/origin/main\x001700000300\x00 \x00\x00\x00aaa\x00fix: a bug\n")
+
+	require.Equal(t, []refInfo{
+		{
+			name:          "refs/heads/main",
+			oid:           "aaa",
+			updatedAt:     time.Unix(1700000000, 0),
+			subject:       "fix: a bug",
+			isHead:        true,
+			upstream:      "origin/main",
+			hasBaseCounts: true,
+			baseBehind:    3,
+		},
+		{
+			name:          "refs/heads/feat",
+			oid:           "bbb",
+			updatedAt:     time.Unix(1700000100, 0),
+			subject:       "feat: things",
+			upstream:      "fork/feat",
+			ahead:         2,
+			behind:        1,
+			hasBaseCounts: true,
+			baseAhead:     2,
+			baseBehind:    1,
+		},
+		{
+			name:         "refs/heads/merged",
+			oid:          "ccc",
+			updatedAt:    time.Unix(1700000200, 0),
+			subject:      "chore: done",
+			upstream:     "origin/merged",
+			upstreamGone: true,
+		},
+		{
+			name:      "refs/remotes/origin/main",
+			oid:       "aaa",
+			updatedAt: time.Unix(1700000300, 0),
+			subject:   "fix: a bug",
+		},
+	}, parseRefs(out))
+}
+
+func TestParseTrack(t *testing.T) {
+	testCases := []struct {
+		track  string
+		ahead  int
+		behind int
+		gone   bool
+	}{
+		{track: ""},
+		{track: "gone", gone: true},
+		{track: "ahead 3", ahead: 3},
+		{track: "behind 4", behind: 4},
+		{track: "ahead 1, behind 2", ahead: 1, behind: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.track, func(t *testing.T) {
+			ahead, behind, gone := parseTrack(tc.track)
+			require.Equal(t, tc.ahead, ahead)
+			require.Equal(t, tc.behind, behind)
+			require.Equal(t, tc.gone, gone)
+		})
+	}
+}
+
+// newBranchesFixture returns a clone with n branches that track a branch on
+// origin, each with a commit of its own and half of them a commit ahead of
+// their upstream. The branches are written with fast-import, since creating
+// them one git command at a time is too slow for large fixtures.
+func newBranchesFixture(tb testing.TB, n int) string {
+	tb.Helper()
+	dir := newClone(tb)
+
+	// Newer than main's commit, so the branches sort in order after it
+	now := time.Now().Unix()
+	stream := strings.Builder{}
+	config := strings.Builder{}
+	for i := range n {
+		name := fmt.Sprintf("branch-%04d", i)
+		fmt.Fprintf(&stream, "commit refs/remotes/origin/%s\n", name)
+		fmt.Fprintf(&stream, "mark :%d\n", 2*i+1)
+		fmt.Fprintf(&stream, "committer test <test@example.com> %d +0000\n", now+int64(i))
+		fmt.Fprintf(&stream, "data %d\n%s\n", len(name), name)
+		fmt.Fprintf(&stream, "from refs/heads/main\n")
+		fmt.Fprintf(&stream, "M 644 inline %s.txt\ndata %d\n%s\n\n", name, len(name), name)
+
+		fmt.Fprintf(&stream, "reset refs/heads/%s\nfrom :%d\n\n", name, 2*i+1)
+		if i%2 == 0 {
+			fmt.Fprintf(&stream, "commit refs/heads/%s\n", name)
+			fmt.Fprintf(&stream, "committer test <test@example.com> %d +0000\n", now+int64(i))
+			fmt.Fprintf(&stream, "data %d\n%s\n", len(name), name)
+			fmt.Fprintf(&stream, "M 644 inline %s.local\ndata %d\n%s\n\n", name, len(name), name)
+		}
+
+		fmt.Fprintf(&config, "[branch %q]\n\tremote = origin\n\tmerge = refs/heads/%s\n", name, name)
+	}
+
+	c := exec.Command("git", "fast-import", "--quiet")
+	c.Dir = dir
+	c.Stdin = strings.NewReader(stream.String())
+	out, err := c.CombinedOutput()
+	require.NoError(tb, err, "git fast-import: %s", out)
+
+	f, err := os.OpenFile(filepath.Join(dir, ".git", "config"), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(tb, err)
+	_, err = f.WriteString(config.String())
+	require.NoError(tb, err)
+	require.NoError(tb, f.Close())
+
+	runGit(tb, dir, "remote", "set-head", "origin", "main")
+	return dir
+}
+
+func TestGetRepoBranchesFixture(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := newBranchesFixture(t, 10)
+
+	repo, err := GetRepo(dir)
+	require.NoError(t, err)
+	require.Len(t, repo.Branches, 11)
+	// sorted by the last commit, newest first
+	require.Equal(t, "branch-0009", repo.Branches[0].Name)
+
+	b := findBranch(t, repo, "branch-0004")
+	require.Equal(t, "origin/branch-0004", b.Upstream)
+	require.Equal(t, 1, b.CommitsAhead)
+	require.Equal(t, 0, b.CommitsBehind)
+	require.Equal(t, 2, b.BaseAhead)
+	require.Equal(t, "branch-0004", *b.LastCommitMsg)
+
+	b = findBranch(t, repo, "branch-0005")
+	require.Equal(t, 0, b.CommitsAhead)
+	require.Equal(t, 1, b.BaseAhead)
+}
+
+func BenchmarkGetRepo(b *testing.B) {
+	if _, err := exec.LookPath("git"); err != nil {
+		b.Skip("git isn't installed")
+	}
+	for _, n := range []int{10, 100, 500} {
+		dir := newBranchesFixture(b, n)
+		b.Run(fmt.Sprintf("branches=%d", n), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := GetRepo(dir); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
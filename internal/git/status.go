@@ -0,0 +1,142 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	gitm "github.com/aymanbagabas/git-module"
+)
+
+// FileStatus is a changed file of the working tree, with its state in the
+// index and in the worktree as git status --porcelain reports them, like
+// 'M' for modified, 'A' for added, 'D' for deleted and '?' for untracked.
+type FileStatus struct {
+	Path string
+	// OrigPath is the path a renamed or copied file had before
+	OrigPath string
+	Staged   byte
+	Unstaged byte
+}
+
+func (f FileStatus) IsUntracked() bool {
+	return f.Staged == '?'
+}
+
+// IsConflicted returns whether the file has unresolved conflicts, where
+// either side of the merge is 'U' or both added or deleted the file
+func (f FileStatus) IsConflicted() bool {
+	return f.Staged == 'U' || f.Unstaged == 'U' ||
+		(f.Staged == 'A' && f.Unstaged == 'A') || (f.Staged == 'D' && f.Unstaged == 'D')
+}
+
+// HasStaged returns whether the file has changes in the index
+func (f FileStatus) HasStaged() bool {
+	return f.Staged != ' ' && !f.IsUntracked() && !f.IsConflicted()
+}
+
+// HasUnstaged returns whether the file has changes in the worktree that
+// aren't in the index, which untracked files always have
+func (f FileStatus) HasUnstaged() bool {
+	return f.Unstaged != ' ' && !f.IsConflicted()
+}
+
+// getFileStatuses returns the changed files of the working tree, including
+// every untracked file
+func getFileStatuses(dir string) ([]FileStatus, error) {
+	stdout, err := gitm.NewCommand(
+		"status", "--porcelain=v1", "-z", "--untracked-files=all",
+	).RunInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return parsePorcelainStatus(stdout), nil
+}
+
+// parsePorcelainStatus parses the NUL separated entries of git status
+// --porcelain=v1 -z, where renamed and copied files are followed by an entry
+// with their original path
+func parsePorcelainStatus(out []byte) []FileStatus {
+	files := make([]FileStatus, 0)
+	entries := bytes.Split(out, []byte{0})
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		file := FileStatus{
+			Staged:   entry[0],
+			Unstaged: entry[1],
+			Path:     string(entry[3:]),
+		}
+		if (file.Staged == 'R' || file.Staged == 'C') && i+1 < len(entries) {
+			i++
+			file.OrigPath = string(entries[i])
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// StageFile adds the changes of the file to the index, including its
+// deletion
+func StageFile(dir string, path string) error {
+	_, err := gitm.NewCommand("add", "--all", "--", path).RunInDir(dir)
+	return err
+}
+
+// UnstageFile removes the changes of the file from the index, keeping them
+// in the worktree
+func UnstageFile(dir string, file FileStatus) error {
+	args := []string{"restore", "--staged", "--", file.Path}
+	if file.OrigPath != "" {
+		args = append(args, file.OrigPath)
+	}
+	_, err := gitm.NewCommand(args...).RunInDir(dir)
+	return err
+}
+
+// DiscardFile drops the changes of the file that aren't staged, restoring
+// it from the index. Untracked files are deleted, and conflicted files are
+// restored from HEAD, dropping both sides of the conflict.
+func DiscardFile(dir string, file FileStatus) error {
+	var err error
+	switch {
+	case file.IsUntracked():
+		_, err = gitm.NewCommand("clean", "--force", "--quiet", "--", file.Path).RunInDir(dir)
+	case file.IsConflicted():
+		_, err = gitm.NewCommand(
+			"restore", "--source=HEAD", "--staged", "--worktree", "--", file.Path,
+		).RunInDir(dir)
+	default:
+		_, err = gitm.NewCommand("restore", "--worktree", "--", file.Path).RunInDir(dir)
+	}
+	return err
+}
+
+// Commit commits the staged changes with the message. Amending replaces the
+// last commit, keeping its message when message is empty.
+func Commit(dir string, message string, amend bool) error {
+	args := []string{"commit", "--quiet"}
+	switch {
+	case amend && strings.TrimSpace(message) == "":
+		args = append(args, "--amend", "--no-edit")
+	case amend:
+		args = append(args, "--amend", "--message", message)
+	case strings.TrimSpace(message) == "":
+		return errors.New("the commit message is empty")
+	default:
+		args = append(args, "--message", message)
+	}
+	_, err := gitm.NewCommand(args...).RunInDir(dir)
+	return err
+}
+
+// LastCommitMessage returns the full message of the commit HEAD points to
+func LastCommitMessage(dir string) (string, error) {
+	stdout, err := gitm.NewCommand("log", "-1", "--format=%B").RunInDir(dir)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(stdout)), nil
+}
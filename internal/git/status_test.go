@@ -0,0 +1,96 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePorcelainStatus(t *testing.T) {
+	out := []byte(" M changed.go\x00A  added.go\x00R  new.go\x00old.go\x00UU conflict.go\x00?? dir/untracked.go\x00")
+
+	files := parsePorcelainStatus(out)
+	require.Equal(t, []FileStatus{
+		{Path: "changed.go", Staged: ' ', Unstaged: 'M'},
+		{Path: "added.go", Staged: 'A', Unstaged: ' '},
+		{Path: "new.go", OrigPath: "old.go", Staged: 'R', Unstaged: ' '},
+		{Path: "conflict.go", Staged: 'U', Unstaged: 'U'},
+		{Path: "dir/untracked.go", Staged: '?', Unstaged: '?'},
+	}, files)
+
+	require.False(t, files[0].HasStaged())
+	require.True(t, files[0].HasUnstaged())
+	require.True(t, files[1].HasStaged())
+	require.False(t, files[1].HasUnstaged())
+	require.True(t, files[3].IsConflicted())
+	require.False(t, files[3].HasStaged())
+	require.True(t, files[4].IsUntracked())
+	require.False(t, files[4].HasStaged())
+	require.True(t, files[4].HasUnstaged())
+}
+
+func findFile(t *testing.T, dir string, path string) FileStatus {
+	t.Helper()
+	status, err := GetStatus(dir)
+	require.NoError(t, err)
+	for _, f := range status.Files {
+		if f.Path == path {
+			return f
+		}
+	}
+	require.Failf(t, "file not found", "no changes to %s", path)
+	return FileStatus{}
+}
+
+func TestStageAndCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := newClone(t)
+	runGit(t, dir, "config", "user.name", "test")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0o644))
+	require.True(t, findFile(t, dir, "new.txt").IsUntracked())
+
+	require.NoError(t, StageFile(dir, "new.txt"))
+	require.Equal(t, byte('A'), findFile(t, dir, "new.txt").Staged)
+	require.NoError(t, StageFile(dir, "README.md"))
+	require.NoError(t, UnstageFile(dir, findFile(t, dir, "README.md")))
+	require.False(t, findFile(t, dir, "README.md").HasStaged())
+
+	require.NoError(t, StageFile(dir, "README.md"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed again"), 0o644))
+	require.NoError(t, DiscardFile(dir, findFile(t, dir, "README.md")))
+	content, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	require.NoError(t, err)
+	require.Equal(t, "changed", string(content), "discarding should keep the staged changes")
+	require.NoError(t, UnstageFile(dir, findFile(t, dir, "README.md")))
+	require.NoError(t, DiscardFile(dir, findFile(t, dir, "README.md")))
+	content, err = os.ReadFile(filepath.Join(dir, "README.md"))
+	require.NoError(t, err)
+	require.Equal(t, "README.md", string(content))
+
+	require.Error(t, Commit(dir, " ", false))
+	require.NoError(t, Commit(dir, "add new.txt", false))
+	msg, err := LastCommitMessage(dir)
+	require.NoError(t, err)
+	require.Equal(t, "add new.txt", msg)
+
+	require.NoError(t, Commit(dir, "", true))
+	msg, err = LastCommitMessage(dir)
+	require.NoError(t, err)
+	require.Equal(t, "add new.txt", msg)
+	require.NoError(t, Commit(dir, "add the new file", true))
+	msg, err = LastCommitMessage(dir)
+	require.NoError(t, err)
+	require.Equal(t, "add the new file", msg)
+
+	status, err := GetStatus(dir)
+	require.NoError(t, err)
+	require.Empty(t, status.Files)
+}
@@ -0,0 +1,152 @@
+package git
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gitm "github.com/aymanbagabas/git-module"
+	"github.com/fsnotify/fsnotify"
+)
+
+// refsDebounce is how long a repo's refs have to stay unchanged before a
+// change is reported, since commands like fetch and rebase update many refs
+// one at a time
+const refsDebounce = 300 * time.Millisecond
+
+var ErrRefsWatcherClosed = errors.New("refs watcher closed")
+
+// RefsWatcher watches the files git keeps the repo's branches and checked
+// out branch in: HEAD, packed-refs and the loose refs under refs/heads and
+// refs/remotes.
+type RefsWatcher struct {
+	watcher *fsnotify.Watcher
+	// gitDir is the git dir of the worktree, with its HEAD, and commonDir is
+	// the one all worktrees share, with the refs. They're the same outside
+	// of linked worktrees.
+	gitDir    string
+	commonDir string
+}
+
+// WatchRefs starts watching the refs of the repo at dir
+func WatchRefs(dir string) (*RefsWatcher, error) {
+	stdout, err := gitm.NewCommand(
+		"rev-parse", "--absolute-git-dir", "--git-common-dir",
+	).RunInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(stdout)), "\n")
+	if len(lines) != 2 {
+		return nil, errors.New("failed finding the git dir of " + dir)
+	}
+	gitDir, commonDir := lines[0], lines[1]
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(dir, commonDir)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &RefsWatcher{
+		watcher:   watcher,
+		gitDir:    filepath.Clean(gitDir),
+		commonDir: filepath.Clean(commonDir),
+	}
+	// Git replaces HEAD and packed-refs by renaming lock files over them, so
+	// their dirs are watched rather than the files
+	err = errors.Join(watcher.Add(w.gitDir), watcher.Add(w.commonDir))
+	if err == nil {
+		err = w.addDirs(filepath.Join(w.commonDir, "refs"))
+	}
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// addDirs watches dir and the dirs below it, except for tags
+func (w *RefsWatcher) addDirs(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// The dir can be deleted as it's walked, like by pack-refs
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path == filepath.Join(w.commonDir, "refs", "tags") {
+			return filepath.SkipDir
+		}
+		return w.watcher.Add(path)
+	})
+}
+
+// isRefChange returns whether the event is a change of HEAD, packed-refs or
+// a branch. Lock files are skipped, as git renames them over the files they
+// lock once it's done writing.
+func (w *RefsWatcher) isRefChange(event fsnotify.Event) bool {
+	if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+		return false
+	}
+	if strings.HasSuffix(event.Name, ".lock") {
+		return false
+	}
+	switch event.Name {
+	case filepath.Join(w.gitDir, "HEAD"), filepath.Join(w.commonDir, "packed-refs"):
+		return true
+	}
+	for _, refs := range []string{"heads", "remotes"} {
+		prefix := filepath.Join(w.commonDir, "refs", refs)
+		if event.Name == prefix || strings.HasPrefix(event.Name, prefix+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Wait blocks until the refs change, and returns once they stayed unchanged
+// for refsDebounce. It returns ErrRefsWatcherClosed after Close.
+func (w *RefsWatcher) Wait() error {
+	var settled <-chan time.Time
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return ErrRefsWatcherClosed
+			}
+			// New dirs, like refs/remotes/fork after adding a remote, are
+			// watched too
+			if event.Has(fsnotify.Create) && strings.HasPrefix(event.Name, filepath.Join(w.commonDir, "refs")) {
+				_ = w.addDirs(event.Name)
+			}
+			if w.isRefChange(event) {
+				settled = time.After(refsDebounce)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return ErrRefsWatcherClosed
+			}
+			// Dropped events may have been ref changes
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				settled = time.After(refsDebounce)
+				continue
+			}
+			return err
+		case <-settled:
+			return nil
+		}
+	}
+}
+
+// Close stops watching the refs
+func (w *RefsWatcher) Close() error {
+	return w.watcher.Close()
+}
@@ -0,0 +1,45 @@
+package git
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// waitForRefs returns the result of w.Wait, failing the test if the refs
+// change isn't reported in time
+func waitForRefs(t *testing.T, w *RefsWatcher) error {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() { done <- w.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "refs change wasn't reported")
+		return nil
+	}
+}
+
+func TestRefsWatcher(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := newClone(t)
+	w, err := WatchRefs(dir)
+	require.NoError(t, err)
+
+	runGit(t, dir, "branch", "feat")
+	require.NoError(t, waitForRefs(t, w))
+
+	runGit(t, dir, "checkout", "-q", "feat")
+	require.NoError(t, waitForRefs(t, w))
+
+	runGit(t, dir, "pack-refs", "--all")
+	require.NoError(t, waitForRefs(t, w))
+
+	require.NoError(t, w.Close())
+	require.ErrorIs(t, w.Wait(), ErrRefsWatcherClosed)
+}
@@ -0,0 +1,112 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	gitm "github.com/aymanbagabas/git-module"
+)
+
+type Worktree struct {
+	Path string
+	Head string
+	// Branch is the short name of the checked out branch, empty when the
+	// worktree's HEAD is detached
+	Branch   string
+	Bare     bool
+	Prunable bool
+	// IsMain is true for the repo's main worktree, which can't be removed
+	IsMain bool
+}
+
+// ListWorktrees returns the worktrees of the repo at dir, the main worktree
+// first.
+func ListWorktrees(dir string) ([]Worktree, error) {
+	stdout, err := gitm.NewCommand("worktree", "list", "--porcelain").RunInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktrees(stdout), nil
+}
+
+// parseWorktrees parses the output of git worktree list --porcelain, where
+// every worktree is a block of attribute lines separated by an empty line.
+func parseWorktrees(out []byte) []Worktree {
+	var worktrees []Worktree
+	var curr *Worktree
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		attr, value, _ := strings.Cut(line, " ")
+		switch attr {
+		case "worktree":
+			worktrees = append(worktrees, Worktree{Path: value, IsMain: len(worktrees) == 0})
+			curr = &worktrees[len(worktrees)-1]
+		case "HEAD":
+			if curr != nil {
+				curr.Head = value
+			}
+		case "branch":
+			if curr != nil {
+				curr.Branch, _ = strings.CutPrefix(value, gitm.RefsHeads)
+			}
+		case "bare":
+			if curr != nil {
+				curr.Bare = true
+			}
+		case "prunable":
+			if curr != nil {
+				curr.Prunable = true
+			}
+		case "":
+			curr = nil
+		}
+	}
+	return worktrees
+}
+
+// FindWorktree returns the worktree at path, if the repo at dir has one
+func FindWorktree(dir string, path string) (*Worktree, error) {
+	worktrees, err := ListWorktrees(dir)
+	if err != nil {
+		return nil, err
+	}
+	for i := range worktrees {
+		if worktrees[i].Path == path {
+			return &worktrees[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// AddWorktree creates a worktree at path. It checks out branch, or a
+// detached HEAD when branch is empty.
+func AddWorktree(dir string, path string, branch string) error {
+	args := []string{"worktree", "add"}
+	if branch == "" {
+		args = append(args, "--detach", path)
+	} else {
+		args = append(args, path, branch)
+	}
+	_, err := gitm.NewCommand(args...).RunInDir(dir)
+	return err
+}
+
+// RemoveWorktree removes the worktree at path. Worktrees with uncommitted
+// changes are only removed when force is true.
+func RemoveWorktree(dir string, path string, force bool) error {
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	_, err := gitm.NewCommand(append(args, path)...).RunInDir(dir)
+	return err
+}
+
+// PruneWorktrees cleans up the administrative files of worktrees whose
+// directory was deleted
+func PruneWorktrees(dir string) error {
+	_, err := gitm.NewCommand("worktree", "prune").RunInDir(dir)
+	return err
+}
@@ -0,0 +1,48 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWorktrees(t *testing.T) {
+	out := []byte(`worktree /src/repo
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /src/repo-worktrees/12
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/fix/login
+
+worktree /src/repo-worktrees/15
+HEAD 3333333333333333333333333333333333333333
+detached
+
+worktree /tmp/gone
+HEAD 4444444444444444444444444444444444444444
+branch refs/heads/old
+prunable gitdir file points to non-existent location
+
+`)
+
+	require.Equal(t, []Worktree{
+		{Path: "/src/repo", Head: "1111111111111111111111111111111111111111", Branch: "main", IsMain: true},
+		{Path: "/src/repo-worktrees/12", Head: "2222222222222222222222222222222222222222", Branch: "fix/login"},
+		{Path: "/src/repo-worktrees/15", Head: "3333333333333333333333333333333333333333"},
+		{Path: "/tmp/gone", Head: "4444444444444444444444444444444444444444", Branch: "old", Prunable: true},
+	}, parseWorktrees(out))
+}
+
+func TestParseWorktreesBare(t *testing.T) {
+	out := []byte("worktree /src/repo.git\nbare\n\nworktree /src/main\nHEAD abc\nbranch refs/heads/main\n")
+
+	require.Equal(t, []Worktree{
+		{Path: "/src/repo.git", Bare: true, IsMain: true},
+		{Path: "/src/main", Head: "abc", Branch: "main"},
+	}, parseWorktrees(out))
+}
+
+func TestParseWorktreesEmpty(t *testing.T) {
+	require.Empty(t, parseWorktrees(nil))
+}
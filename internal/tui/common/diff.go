@@ -3,6 +3,7 @@ package common
 import (
 	"fmt"
 	"os/exec"
+	"strings"
 
 	tea "charm.land/bubbletea/v2"
 
@@ -29,3 +30,28 @@ func DiffPR(prNumber int, repoName string, env []string) tea.Cmd {
 		return nil
 	})
 }
+
+// CommitDiffArgs returns the gh arguments that print the diff of a commit.
+// repoName is OWNER/REPO, or HOST/OWNER/REPO for a repo on another host.
+func CommitDiffArgs(sha string, repoName string) []string {
+	args := []string{"api", "-H", "Accept: application/vnd.github.diff"}
+	if parts := strings.SplitN(repoName, "/", 3); len(parts) == 3 {
+		args = append(args, "--hostname", parts[0])
+		repoName = parts[1] + "/" + parts[2]
+	}
+	return append(args, fmt.Sprintf("repos/%s/commits/%s", repoName, sha))
+}
+
+// DiffCommit opens a diff view for a single commit using the gh CLI.
+// The env parameter should be the result of Config.GetFullScreenDiffPagerEnv().
+func DiffCommit(sha string, repoName string, env []string) tea.Cmd {
+	c := exec.Command("gh", CommitDiffArgs(sha, repoName)...)
+	c.Env = env
+
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return constants.ErrMsg{Err: err}
+		}
+		return nil
+	})
+}
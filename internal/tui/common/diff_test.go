@@ -2,6 +2,8 @@ package common
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestDiffPR(t *testing.T) {
@@ -44,3 +46,17 @@ func TestDiffPR(t *testing.T) {
 		})
 	}
 }
+
+func TestCommitDiffArgs(t *testing.T) {
+	require.Equal(t,
+		[]string{"api", "-H", "Accept: application/vnd.github.diff", "repos/owner/repo/commits/abc123"},
+		CommitDiffArgs("abc123", "owner/repo"),
+	)
+	require.Equal(t,
+		[]string{
+			"api", "-H", "Accept: application/vnd.github.diff",
+			"--hostname", "ghe.example.com", "repos/owner/repo/commits/abc123",
+		},
+		CommitDiffArgs("abc123", "ghe.example.com/owner/repo"),
+	)
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"os"
+	"strings"
+
+	"charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+)
+
+// remotePriority ranks the remotes a clone is found by. When two clones
+// have remotes of the same repo, the one where it's origin wins over a fork's
+// upstream, which wins over any other remote.
+func remotePriority(remote string) int {
+	switch remote {
+	case "origin":
+		return 0
+	case "upstream":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// DiscoverRepos scans roots for clones and maps the repos of their remotes
+// to them, named like repoPaths names them: owner/repo on the default host
+// and host/owner/repo on any other. Roots that can't be read are skipped.
+func DiscoverRepos(roots []string, depth int) map[string]string {
+	paths := make(map[string]string)
+	priorities := make(map[string]int)
+	for _, root := range roots {
+		clones, err := git.FindClones(ExpandHomeDir(root), depth)
+		if err != nil {
+			log.Warn("Failed to scan repo discovery root", "root", root, "err", err)
+			continue
+		}
+		for _, clone := range clones {
+			urls, err := git.GetRemoteUrls(clone)
+			if err != nil {
+				log.Debug("Failed to read remotes", "path", clone, "err", err)
+				continue
+			}
+			for remote, url := range urls {
+				host, owner, repo, ok := git.ParseRemoteUrl(url)
+				if !ok {
+					continue
+				}
+				name := owner + "/" + repo
+				if !data.IsDefaultHost(host) {
+					name = host + "/" + name
+				}
+				name = strings.ToLower(name)
+				if p, found := priorities[name]; found && p <= remotePriority(remote) {
+					continue
+				}
+				paths[name], priorities[name] = clone, remotePriority(remote)
+			}
+		}
+	}
+	return paths
+}
+
+// RefreshDiscoveredRepos scans the configured roots again when the cached
+// scan is stale, see data.RepoPathStore.IsStale. It scans the file system,
+// so it's meant to run in a command.
+func RefreshDiscoveredRepos(cfg config.RepoDiscoveryConfig) error {
+	store := data.GetRepoPathStore()
+	if len(cfg.Roots) == 0 || !store.IsStale(cfg.Roots, cfg.GetDepth()) {
+		return nil
+	}
+	paths := DiscoverRepos(cfg.Roots, cfg.GetDepth())
+	log.Info("Discovered repos", "roots", cfg.Roots, "count", len(paths))
+	return store.Set(cfg.Roots, cfg.GetDepth(), paths)
+}
+
+// ResolveRepoLocalPath returns the local path of a repo like GetRepoLocalPath
+// does, falling back to the clone repo discovery found for it. A path
+// repoPaths maps the repo to is preferred unless it doesn't exist and a clone
+// was found elsewhere.
+func ResolveRepoLocalPath(repoName string, cfgPaths map[string]string) (string, bool) {
+	cfgPath, cfgOk := GetRepoLocalPath(repoName, cfgPaths)
+	if cfgOk && pathExists(cfgPath) {
+		return cfgPath, true
+	}
+	if path, ok := data.GetRepoPathStore().Get(repoName); ok && pathExists(path) {
+		return path, true
+	}
+	return cfgPath, cfgOk
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(ExpandHomeDir(path))
+	return err == nil
+}
+
+// GetClonePath returns the path to clone a repo to when no local path is
+// found for it. The pattern can use the placeholders :host, :owner and
+// :repo, like "~/code/:owner/:repo".
+func GetClonePath(pattern string, repoName string) string {
+	host := data.DefaultHost()
+	parts := strings.Split(repoName, "/")
+	if len(parts) == 3 {
+		host, parts = parts[0], parts[1:]
+	}
+	owner, repo := "", repoName
+	if len(parts) == 2 {
+		owner, repo = parts[0], parts[1]
+	}
+
+	path := strings.ReplaceAll(pattern, ":host", host)
+	path = strings.ReplaceAll(path, ":owner", owner)
+	path = strings.ReplaceAll(path, ":repo", repo)
+	return ExpandHomeDir(path)
+}
@@ -0,0 +1,117 @@
+package common_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
+)
+
+// initRepo creates a repo at path with the given remotes, by name
+func initRepo(t *testing.T, path string, remotes map[string]string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(path, 0o755))
+	run := func(args ...string) {
+		c := exec.Command("git", args...)
+		c.Dir = path
+		out, err := c.CombinedOutput()
+		require.NoError(t, err, "git %v: %s", args, out)
+	}
+	run("init", "-q")
+	for name, url := range remotes {
+		run("remote", "add", name, url)
+	}
+}
+
+func TestDiscoverRepos(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	t.Setenv("GH_HOST", "github.com")
+	code, work := t.TempDir(), t.TempDir()
+	initRepo(t, filepath.Join(code, "gh-dash"), map[string]string{
+		"origin": "git@github.com:dlvhdr/gh-dash.git",
+	})
+	// A fork is found by its upstream too, unless the upstream has a clone
+	// of its own
+	initRepo(t, filepath.Join(code, "forks/diffnav"), map[string]string{
+		"origin":   "https://github.com/me/diffnav",
+		"upstream": "https://github.com/dlvhdr/diffnav",
+	})
+	initRepo(t, filepath.Join(code, "forks/fork-of-gh-dash"), map[string]string{
+		"origin":   "https://github.com/me/gh-dash",
+		"upstream": "https://github.com/dlvhdr/gh-dash",
+	})
+	initRepo(t, filepath.Join(work, "org/Service"), map[string]string{
+		"origin": "ssh://git@ghe.example.com/Org/Service.git",
+	})
+
+	paths := common.DiscoverRepos([]string{code, work, filepath.Join(code, "missing")}, 3)
+	require.Equal(t, map[string]string{
+		"dlvhdr/gh-dash":              filepath.Join(code, "gh-dash"),
+		"me/diffnav":                  filepath.Join(code, "forks/diffnav"),
+		"dlvhdr/diffnav":              filepath.Join(code, "forks/diffnav"),
+		"me/gh-dash":                  filepath.Join(code, "forks/fork-of-gh-dash"),
+		"ghe.example.com/org/service": filepath.Join(work, "org/Service"),
+	}, paths)
+}
+
+func TestResolveRepoLocalPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	configured, discovered := t.TempDir(), t.TempDir()
+	require.NoError(t, data.GetRepoPathStore().Set([]string{"/code"}, 3, map[string]string{
+		"user/repo":    discovered,
+		"user/cloned":  discovered,
+		"user/deleted": filepath.Join(discovered, "deleted"),
+	}))
+
+	testCases := map[string]struct {
+		repo  string
+		want  string
+		found bool
+	}{
+		"configured path that exists": {repo: "user/configured", want: configured, found: true},
+		"configured path that doesn't exist falls back to the clone": {
+			repo: "user/repo", want: discovered, found: true,
+		},
+		"discovered only": {repo: "USER/cloned", want: discovered, found: true},
+		"configured wildcard without a clone": {
+			repo: "org/missing", want: "/nowhere/org/missing", found: true,
+		},
+		"deleted clone":                {repo: "user/deleted", want: "", found: false},
+		"neither configured nor found": {repo: "user/other", want: "", found: false},
+	}
+
+	cfgPaths := map[string]string{
+		"user/configured": configured,
+		"user/repo":       "/nowhere/user/repo",
+		"org/*":           "/nowhere/org/*",
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, found := common.ResolveRepoLocalPath(tc.repo, cfgPaths)
+			require.Equal(t, tc.found, found)
+			if tc.found {
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGetClonePath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	t.Setenv("GH_HOST", "github.com")
+
+	require.Equal(t, filepath.Join(home, "code/dlvhdr/gh-dash"),
+		common.GetClonePath("~/code/:owner/:repo", "dlvhdr/gh-dash"))
+	require.Equal(t, "/src/github.com/dlvhdr/gh-dash",
+		common.GetClonePath("/src/:host/:owner/:repo", "dlvhdr/gh-dash"))
+	require.Equal(t, "/src/ghe.example.com/org/service",
+		common.GetClonePath("/src/:host/:owner/:repo", "ghe.example.com/org/service"))
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -17,11 +18,16 @@ import (
 //	{
 //	  "user/repo": "/path/to/user/repo",
 //	  "user_2/*":  "/path/to/user_2/*",
+//	  "github.example.com/user/*": "/path/to/ghe/user/*",
 //	}
 //
 // GetRepoLocalPath("user/repo", config) will return: "/path/to/user/repo", true
 // GetRepoLocalPath("user_2/some_repo", config) will return: "/path/to/user_2/some_repo", true
 // GetRepoLocalPath("user/other_repo", config) will return: "", false
+// GetRepoLocalPath("github.example.com/user/repo", config) will return: "/path/to/ghe/user/repo", true
+//
+// Repos of hosts other than the default one are named host/owner/repo and
+// only match keys prefixed with their host.
 func GetRepoLocalPath(repoName string, cfgPaths map[string]string) (string, bool) {
 	exactMatchPath, ok := cfgPaths[repoName]
 	// prioritize full repo to path mapping in config
@@ -29,10 +35,18 @@ func GetRepoLocalPath(repoName string, cfgPaths map[string]string) (string, bool
 		return exactMatchPath, true
 	}
 
-	owner, repo, repoValid := func() (string, string, bool) {
+	host, owner, repo, repoValid := func() (string, string, string, bool) {
 		repoParts := strings.Split(repoName, "/")
-		// return repo owner, repo, and indicate properly owner/repo format
-		return repoParts[0], repoParts[len(repoParts)-1], len(repoParts) == 2
+		// return the host prefix, repo owner, repo, and indicate properly
+		// [host/]owner/repo format
+		switch len(repoParts) {
+		case 2:
+			return "", repoParts[0], repoParts[1], true
+		case 3:
+			return repoParts[0] + "/", repoParts[1], repoParts[2], true
+		default:
+			return "", "", "", false
+		}
 	}()
 
 	if !repoValid {
@@ -40,14 +54,14 @@ func GetRepoLocalPath(repoName string, cfgPaths map[string]string) (string, bool
 	}
 
 	// match config:repoPath values of {owner}/* as map key
-	wildcardPath, wildcardFound := cfgPaths[fmt.Sprintf("%s/*", owner)]
+	wildcardPath, wildcardFound := cfgPaths[fmt.Sprintf("%s%s/*", host, owner)]
 
 	if wildcardFound {
 		// adjust wildcard match to wildcard path - ~/somepath/* to ~/somepath/{repo}
 		return fmt.Sprintf("%s/%s", strings.TrimSuffix(wildcardPath, "/*"), repo), true
 	}
 
-	if template, ok := cfgPaths[":owner/:repo"]; ok {
+	if template, ok := cfgPaths[host+":owner/:repo"]; ok {
 		return strings.ReplaceAll(
 			strings.ReplaceAll(template, ":owner", owner),
 			":repo",
@@ -57,3 +71,31 @@ func GetRepoLocalPath(repoName string, cfgPaths map[string]string) (string, bool
 
 	return "", false
 }
+
+// LocalRepo is a repo mapped to a local path by its full name in repoPaths
+type LocalRepo struct {
+	// Name is the repo's key in repoPaths, like user/repo, or
+	// github.example.com/user/repo for repos of other hosts
+	Name string
+	Path string
+}
+
+// GetLocalRepos returns the repos repoPaths maps by their full name, sorted
+// by name and with a leading ~ of their path expanded. Wildcard and
+// :owner/:repo keys match repos without naming them, so they're left out.
+func GetLocalRepos(cfgPaths map[string]string) []LocalRepo {
+	repos := make([]LocalRepo, 0, len(cfgPaths))
+	for name, path := range cfgPaths {
+		if strings.ContainsAny(name, "*:") || strings.Contains(path, "*") {
+			continue
+		}
+		if parts := strings.Count(name, "/"); parts < 1 || parts > 2 {
+			continue
+		}
+		repos = append(repos, LocalRepo{Name: name, Path: ExpandHomeDir(path)})
+	}
+	slices.SortFunc(repos, func(a, b LocalRepo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return repos
+}
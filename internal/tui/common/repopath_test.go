@@ -1,6 +1,8 @@
 package common_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -25,6 +27,12 @@ var configPathsWithOwnerRepoTemplate = map[string]string{
 	":owner/:repo": "/path/to/github.com/:owner/:repo",
 }
 
+var configPathsWithHosts = map[string]string{
+	"ghe.example.com/user/repo":    "/path/to/ghe/user/repo",
+	"ghe.example.com/org/*":        "/path/to/ghe/org/*",
+	"ghe.example.com/:owner/:repo": "/path/to/ghe/:owner/:repo",
+}
+
 func TestGetRepoLocalPath(t *testing.T) {
 	testCases := map[string]struct {
 		repo        string
@@ -92,6 +100,36 @@ func TestGetRepoLocalPath(t *testing.T) {
 			found:       true,
 			configPaths: map[string]string{":owner/:repo": "src/github.com/:owner/:repo/:repo"},
 		},
+		"host: exact match": {
+			repo:        "ghe.example.com/user/repo",
+			want:        "/path/to/ghe/user/repo",
+			found:       true,
+			configPaths: configPathsWithHosts,
+		},
+		"host: wildcard match": {
+			repo:        "ghe.example.com/org/some_repo",
+			want:        "/path/to/ghe/org/some_repo",
+			found:       true,
+			configPaths: configPathsWithHosts,
+		},
+		"host: :owner/:repo template": {
+			repo:        "ghe.example.com/any-owner/any-repo",
+			want:        "/path/to/ghe/any-owner/any-repo",
+			found:       true,
+			configPaths: configPathsWithHosts,
+		},
+		"host: default host keys don't match": {
+			repo:        "ghe.example.com/user/repo",
+			want:        "",
+			found:       false,
+			configPaths: configPathsWithOwnerRepoTemplate,
+		},
+		"host: host keys don't match the default host": {
+			repo:        "org/some_repo",
+			want:        "",
+			found:       false,
+			configPaths: configPathsWithHosts,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -102,3 +140,25 @@ func TestGetRepoLocalPath(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLocalRepos(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	repos := common.GetLocalRepos(map[string]string{
+		"user/repo":                 "/path/to/user/repo",
+		"user_2/*":                  "/path/to/user_2/*",
+		":owner/:repo":              "/path/to/:owner/:repo",
+		"ghe.example.com/org/repo":  "~/code/ghe/repo",
+		"ghe.example.com/org/*":     "/path/to/ghe/org/*",
+		"another/repo":              "/path/to/another/repo",
+		"invalid-lookup":            "/path/to/invalid",
+		"too/many/parts/for/a/repo": "/path/to/nothing",
+	})
+
+	require.Equal(t, []common.LocalRepo{
+		{Name: "another/repo", Path: "/path/to/another/repo"},
+		{Name: "ghe.example.com/org/repo", Path: filepath.Join(home, "code/ghe/repo")},
+		{Name: "user/repo", Path: "/path/to/user/repo"},
+	}, repos)
+}
@@ -0,0 +1,160 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+)
+
+// DefaultWorktreeDir puts the worktrees of a repo next to it, one per PR or
+// issue number
+const DefaultWorktreeDir = ":repoPath-worktrees/:number"
+
+// ExpandHomeDir replaces a leading ~ in path with the user's home directory
+func ExpandHomeDir(path string) string {
+	if !strings.HasPrefix(path, "~") {
+		return path
+	}
+	userHomeDir, _ := os.UserHomeDir()
+	return strings.Replace(path, "~", userHomeDir, 1)
+}
+
+// GetWorktreePath returns the path of the worktree of a PR or issue. The
+// pattern can use the placeholders :repoPath, the local path of the repo,
+// :owner, :repo and :number. An empty pattern uses DefaultWorktreeDir.
+//
+// For a repo "user/repo" at "~/code/repo":
+//
+//	GetWorktreePath("", "user/repo", "~/code/repo", 12) returns "/home/me/code/repo-worktrees/12"
+//	GetWorktreePath("~/wt/:owner/:repo/pr-:number", "user/repo", "~/code/repo", 12) returns "/home/me/wt/user/repo/pr-12"
+func GetWorktreePath(pattern string, repoName string, repoPath string, number int) string {
+	if pattern == "" {
+		pattern = DefaultWorktreeDir
+	}
+	owner, repo := "", repoName
+	parts := strings.Split(repoName, "/")
+	if len(parts) >= 2 {
+		owner, repo = parts[len(parts)-2], parts[len(parts)-1]
+	}
+
+	// :repoPath has to be replaced before :repo, which is a prefix of it
+	path := strings.ReplaceAll(pattern, ":repoPath", strings.TrimSuffix(repoPath, "/"))
+	path = strings.ReplaceAll(path, ":owner", owner)
+	path = strings.ReplaceAll(path, ":repo", repo)
+	path = strings.ReplaceAll(path, ":number", fmt.Sprint(number))
+	return filepath.Clean(ExpandHomeDir(path))
+}
+
+// isWorktree returns whether path is the root of a git worktree, which has a
+// .git file pointing to the main repo
+func isWorktree(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !info.IsDir() {
+		return false, fmt.Errorf("%s exists and isn't a directory", path)
+	}
+	if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+		if entries, _ := os.ReadDir(path); len(entries) == 0 {
+			return false, nil
+		}
+		return false, fmt.Errorf("%s exists and isn't a git worktree", path)
+	}
+	return true, nil
+}
+
+// CheckoutPRWorktree checks out a PR in its own worktree of the repo at
+// repoPath, leaving the repo's working tree alone. The worktree is created
+// when it doesn't exist yet, otherwise the PR is checked out again in it to
+// pick up new commits.
+func CheckoutPRWorktree(repoPath string, worktreePath string, number int) error {
+	exists, err := isWorktree(worktreePath)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := git.AddWorktree(repoPath, worktreePath, ""); err != nil {
+			return err
+		}
+	}
+
+	c := exec.Command("gh", "pr", "checkout", fmt.Sprint(number))
+	c.Dir = worktreePath
+	if err := c.Run(); err != nil {
+		if !exists {
+			// don't leave an empty detached worktree behind
+			_ = git.RemoveWorktree(repoPath, worktreePath, true)
+		}
+		return err
+	}
+	return nil
+}
+
+// CheckoutIssueWorktree checks out the branch linked to an issue in its own
+// worktree of the repo at repoPath. The branch is created with gh issue
+// develop when the issue has none yet.
+func CheckoutIssueWorktree(repoName string, repoPath string, worktreePath string, number int) error {
+	exists, err := isWorktree(worktreePath)
+	if err != nil || exists {
+		return err
+	}
+
+	branch, err := getIssueBranch(repoName, repoPath, number)
+	if err != nil {
+		return err
+	}
+	if branch == "" {
+		c := exec.Command("gh", "issue", "develop", fmt.Sprint(number), "-R", repoName)
+		c.Dir = repoPath
+		if err := c.Run(); err != nil {
+			return err
+		}
+		if branch, err = getIssueBranch(repoName, repoPath, number); err != nil {
+			return err
+		}
+		if branch == "" {
+			return fmt.Errorf("no branch was created for issue #%d", number)
+		}
+	}
+
+	c := exec.Command("git", "fetch", "origin", branch)
+	c.Dir = repoPath
+	if err := c.Run(); err != nil {
+		return err
+	}
+	return git.AddWorktree(repoPath, worktreePath, branch)
+}
+
+// getIssueBranch returns the first branch linked to an issue, or an empty
+// string if it has none
+func getIssueBranch(repoName string, repoPath string, number int) (string, error) {
+	c := exec.Command("gh", "issue", "develop", "--list", fmt.Sprint(number), "-R", repoName)
+	c.Dir = repoPath
+	out, err := c.Output()
+	if err != nil {
+		return "", err
+	}
+	return parseIssueBranches(out), nil
+}
+
+// parseIssueBranches returns the first branch name in the output of
+// gh issue develop --list, which prints a branch and its URL per line
+func parseIssueBranches(out []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	return ""
+}
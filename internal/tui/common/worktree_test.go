@@ -0,0 +1,86 @@
+package common_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
+)
+
+func TestGetWorktreePath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		pattern  string
+		repoName string
+		repoPath string
+		number   int
+		want     string
+	}{
+		{
+			name:     "default pattern",
+			repoName: "user/repo",
+			repoPath: "/path/to/repo",
+			number:   12,
+			want:     "/path/to/repo-worktrees/12",
+		},
+		{
+			name:     "repo path with trailing slash",
+			repoName: "user/repo",
+			repoPath: "/path/to/repo/",
+			number:   12,
+			want:     "/path/to/repo-worktrees/12",
+		},
+		{
+			name:     "owner and repo placeholders",
+			pattern:  "/worktrees/:owner/:repo/pr-:number",
+			repoName: "user/repo",
+			repoPath: "/path/to/repo",
+			number:   7,
+			want:     "/worktrees/user/repo/pr-7",
+		},
+		{
+			name:     "repo path isn't mistaken for repo",
+			pattern:  ":repoPath/.worktrees/:repo-:number",
+			repoName: "user/other",
+			repoPath: "/path/to/checkout",
+			number:   3,
+			want:     "/path/to/checkout/.worktrees/other-3",
+		},
+		{
+			name:     "repo of another host",
+			pattern:  "/worktrees/:owner/:repo/:number",
+			repoName: "ghe.example.com/org/repo",
+			repoPath: "/path/to/repo",
+			number:   1,
+			want:     "/worktrees/org/repo/1",
+		},
+		{
+			name:     "home dir in repo path",
+			repoName: "user/repo",
+			repoPath: "~/code/repo",
+			number:   5,
+			want:     filepath.Join(home, "code/repo-worktrees/5"),
+		},
+		{
+			name:     "home dir in pattern",
+			pattern:  "~/worktrees/:repo/:number",
+			repoName: "user/repo",
+			repoPath: "/path/to/repo",
+			number:   5,
+			want:     filepath.Join(home, "worktrees/repo/5"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := common.GetWorktreePath(tt.pattern, tt.repoName, tt.repoPath, tt.number)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package alertrow
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/table"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/utils"
+)
+
+type Alert struct {
+	Ctx  *context.ProgramContext
+	Data data.SecurityAlert
+}
+
+func (alert *Alert) ToTableRow() table.Row {
+	return table.Row{
+		alert.renderKind(),
+		alert.renderRepoName(),
+		alert.renderTitle(),
+		alert.renderSeverity(),
+		alert.renderEcosystem(),
+		alert.renderPackage(),
+		alert.renderUpdatedAt(),
+	}
+}
+
+func (alert *Alert) getTextStyle() lipgloss.Style {
+	style := components.GetIssueTextStyle(alert.Ctx)
+	if !alert.Data.IsOpen() {
+		style = style.Foreground(alert.Ctx.Theme.FaintText)
+	}
+	return style
+}
+
+// KindIcon returns the icon of the feature that raised an alert
+func KindIcon(kind data.AlertKind) string {
+	switch kind {
+	case data.AlertKindDependabot:
+		return constants.DependabotAlertIcon
+	case data.AlertKindCodeScanning:
+		return constants.CodeScanningAlertIcon
+	case data.AlertKindSecretScanning:
+		return constants.SecretScanningAlertIcon
+	default:
+		return constants.SecurityIcon
+	}
+}
+
+// RenderSeverity renders a severity in the color of how urgent it is
+func RenderSeverity(ctx *context.ProgramContext, severity string) string {
+	style := lipgloss.NewStyle().Foreground(ctx.Theme.FaintText)
+	switch data.SeverityRank(severity) {
+	case 0, 1:
+		style = style.Foreground(ctx.Theme.ErrorText)
+	case 2:
+		style = style.Foreground(ctx.Theme.WarningText)
+	case 3:
+		style = style.Foreground(ctx.Theme.SecondaryText)
+	}
+	return style.Render(severity)
+}
+
+func (alert *Alert) renderKind() string {
+	style := lipgloss.NewStyle().Foreground(alert.Ctx.Theme.FaintText)
+	if alert.Data.IsOpen() {
+		style = style.Foreground(alert.Ctx.Styles.Colors.OpenIssue)
+	}
+	return style.Render(KindIcon(alert.Data.Kind))
+}
+
+func (alert *Alert) renderRepoName() string {
+	return alert.getTextStyle().Render(alert.Data.Repo)
+}
+
+func (alert *Alert) renderTitle() string {
+	number := lipgloss.NewStyle().Foreground(alert.Ctx.Theme.SecondaryText).
+		Render(fmt.Sprintf("#%d", alert.Data.Number))
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		number, " ", alert.getTextStyle().Render(alert.Data.Title))
+}
+
+func (alert *Alert) renderSeverity() string {
+	if !alert.Data.IsOpen() {
+		return alert.getTextStyle().Render(alert.Data.Severity)
+	}
+	return RenderSeverity(alert.Ctx, alert.Data.Severity)
+}
+
+func (alert *Alert) renderEcosystem() string {
+	return alert.getTextStyle().Render(alert.Data.Ecosystem)
+}
+
+func (alert *Alert) renderPackage() string {
+	return alert.getTextStyle().Render(alert.Data.Package)
+}
+
+func (alert *Alert) renderUpdatedAt() string {
+	timeFormat := alert.Ctx.Config.Defaults.DateFormat
+
+	updatedAtOutput := ""
+	if timeFormat == "" || timeFormat == "relative" {
+		updatedAtOutput = utils.TimeElapsed(alert.Data.UpdatedAt)
+	} else {
+		updatedAtOutput = alert.Data.UpdatedAt.Format(timeFormat)
+	}
+
+	return alert.getTextStyle().Render(updatedAtOutput)
+}
@@ -0,0 +1,428 @@
+package alertssection
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/alertrow"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/section"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/table"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/utils"
+)
+
+const SectionType = "alert"
+
+// defaultAlertsLimit is how many alerts a section shows when it has no limit
+const defaultAlertsLimit = 30
+
+type Model struct {
+	section.BaseModel
+	Alerts []data.SecurityAlert
+}
+
+func NewModel(
+	id int,
+	ctx *context.ProgramContext,
+	cfg config.AlertsSectionConfig,
+	lastUpdated time.Time,
+) Model {
+	m := Model{}
+	m.BaseModel = section.NewModel(
+		ctx,
+		section.NewSectionOptions{
+			Id:          id,
+			Config:      cfg.ToSectionConfig(),
+			Type:        SectionType,
+			Columns:     GetSectionColumns(),
+			Singular:    m.GetItemSingularForm(),
+			Plural:      m.GetItemPluralForm(),
+			LastUpdated: lastUpdated,
+			CreatedAt:   time.Now(),
+		},
+	)
+	m.Alerts = []data.SecurityAlert{}
+
+	return m
+}
+
+func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+
+		if m.IsSearchFocused() {
+			switch msg.String() {
+			case "ctrl+c", "esc":
+				m.SearchBar.SetValue(m.SearchValue)
+				blinkCmd := m.SetIsSearching(false)
+				return m, blinkCmd
+
+			case "enter":
+				m.SearchValue = m.SearchBar.Value()
+				m.SyncSmartFilterWithSearchValue()
+				m.SetIsSearching(false)
+				m.ResetRows()
+				return m, tea.Batch(m.FetchNextPageSectionRows()...)
+			}
+
+			break
+		}
+
+		if m.IsPromptConfirmationFocused() {
+			switch msg.String() {
+			case "ctrl+c", "esc":
+				m.PromptConfirmationBox.Reset()
+				cmd = m.SetIsPromptConfirmationShown(false)
+				return m, cmd
+
+			case "enter":
+				input := m.PromptConfirmationBox.Value()
+				action := m.GetPromptConfirmationAction()
+				if alert := m.getCurrAlert(); alert != nil {
+					switch {
+					case strings.HasPrefix(action, "dismiss_"):
+						cmd = m.dismiss(*alert, input)
+					case action == "reopen" && (input == "Y" || input == "y"):
+						cmd = m.reopen(*alert)
+					}
+				}
+
+				m.PromptConfirmationBox.Reset()
+				blinkCmd := m.SetIsPromptConfirmationShown(false)
+
+				return m, tea.Batch(cmd, blinkCmd)
+			}
+			break
+		}
+
+	case UpdateAlertMsg:
+		for i, alert := range m.Alerts {
+			if alert.Kind == msg.Kind && alert.Repo == msg.Repo && alert.Number == msg.Number {
+				alert.State = msg.State
+				alert.DismissedReason = msg.DismissedReason
+				alert.DismissedComment = msg.DismissedComment
+				alert.UpdatedAt = time.Now()
+				m.Alerts[i] = alert
+				m.SetIsLoading(false)
+				m.Table.SetRows(m.BuildRows())
+				break
+			}
+		}
+
+	case SectionAlertsFetchedMsg:
+		if m.LastFetchTaskId == msg.TaskId {
+			m.Alerts = msg.Alerts
+			m.TotalCount = len(msg.Alerts)
+			m.SetIsLoading(false)
+			// Only the first page of alerts is fetched, sorted by severity
+			m.PageInfo = &data.PageInfo{HasNextPage: false}
+			m.Table.SetRows(m.BuildRows())
+			m.UpdateLastUpdated(time.Now())
+			m.UpdateTotalItemsCount(m.TotalCount)
+		}
+	}
+
+	search, searchCmd := m.SearchBar.Update(msg)
+	m.SearchBar = search
+
+	prompt, promptCmd := m.PromptConfirmationBox.Update(msg)
+	m.PromptConfirmationBox = prompt
+
+	table, tableCmd := m.Table.Update(msg)
+	m.Table = table
+
+	return m, tea.Batch(cmd, searchCmd, promptCmd, tableCmd)
+}
+
+func GetSectionColumns() []table.Column {
+	return []table.Column{
+		{
+			Title: "",
+			Width: utils.IntPtr(kindCellWidth),
+		},
+		{
+			Title: "",
+			Width: utils.IntPtr(repoCellWidth),
+		},
+		{
+			Title: "Title",
+			Grow:  utils.BoolPtr(true),
+		},
+		{
+			Title: "Severity",
+			Width: utils.IntPtr(severityCellWidth),
+		},
+		{
+			Title: "Ecosystem",
+			Width: utils.IntPtr(ecosystemCellWidth),
+		},
+		{
+			Title: "Package",
+			Width: utils.IntPtr(packageCellWidth),
+		},
+		{
+			Title: "󱦻",
+			Width: utils.IntPtr(updatedAtCellWidth),
+		},
+	}
+}
+
+func (m Model) BuildRows() []table.Row {
+	var rows []table.Row
+	for _, currAlert := range m.Alerts {
+		alertModel := alertrow.Alert{Ctx: m.Ctx, Data: currAlert}
+		rows = append(rows, alertModel.ToTableRow())
+	}
+
+	if rows == nil {
+		rows = []table.Row{}
+	}
+
+	return rows
+}
+
+func (m *Model) NumRows() int {
+	return len(m.Alerts)
+}
+
+func (m *Model) GetCurrRow() data.RowData {
+	alert := m.getCurrAlert()
+	if alert == nil {
+		return nil
+	}
+	return alert
+}
+
+func (m *Model) getCurrAlert() *data.SecurityAlert {
+	idx := m.Table.GetCurrItem()
+	if idx < 0 || idx >= len(m.Alerts) {
+		return nil
+	}
+	alert := m.Alerts[idx]
+	return &alert
+}
+
+func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
+	if m == nil {
+		return nil
+	}
+
+	if m.PageInfo != nil && !m.PageInfo.HasNextPage {
+		return nil
+	}
+
+	taskId, cmds := m.startFetch()
+	limit := defaultAlertsLimit
+	if m.Config.Limit != nil {
+		limit = *m.Config.Limit
+	}
+	filters, host := m.GetFilters(), m.Config.Host
+	fetchCmd := func() tea.Msg {
+		req, err := parseAlertFilters(filters, limit)
+		req.Host = host
+		var alerts []data.SecurityAlert
+		if err == nil {
+			alerts, err = data.FetchSecurityAlerts(req)
+		}
+		return m.fetchedMsg(taskId, alerts, err)
+	}
+	cmds = append(cmds, fetchCmd)
+
+	return cmds
+}
+
+// startFetch starts the task tracking the fetch of the section's alerts.
+func (m *Model) startFetch() (string, []tea.Cmd) {
+	taskId := fmt.Sprintf("fetching_alerts_%d_%s", m.Id, time.Now().String())
+	m.LastFetchTaskId = taskId
+	m.SetIsLoading(true)
+	task := context.Task{
+		Id:        taskId,
+		StartText: fmt.Sprintf(`Fetching alerts for "%s"`, m.Config.Title),
+		FinishedText: fmt.Sprintf(
+			`Alerts for "%s" have been fetched`,
+			m.Config.Title,
+		),
+		State: context.TaskStart,
+		Error: nil,
+	}
+	return taskId, []tea.Cmd{m.Ctx.StartTask(task)}
+}
+
+func (m *Model) fetchedMsg(taskId string, alerts []data.SecurityAlert, err error) tea.Msg {
+	if err != nil {
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: m.Type,
+			TaskId:      taskId,
+			Err:         err,
+		}
+	}
+
+	return constants.TaskFinishedMsg{
+		SectionId:   m.Id,
+		SectionType: m.Type,
+		TaskId:      taskId,
+		Msg: SectionAlertsFetchedMsg{
+			Alerts: alerts,
+			TaskId: taskId,
+		},
+	}
+}
+
+// dismiss dismisses an alert with the reason and comment given in the
+// dismiss prompt.
+func (m *Model) dismiss(alert data.SecurityAlert, input string) tea.Cmd {
+	reason, comment, err := parseDismissInput(alert.Kind, input)
+	if err != nil {
+		return func() tea.Msg { return constants.ErrMsg{Err: err} }
+	}
+
+	state := data.AlertStateDismissed
+	if alert.Kind == data.AlertKindSecretScanning {
+		state = data.AlertStateResolved
+	}
+	taskId := fmt.Sprintf("alert_dismiss_%s_%s_%d", alert.Kind, alert.Repo, alert.Number)
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Dismissing alert #%d", alert.Number),
+		FinishedText: fmt.Sprintf("Alert #%d has been dismissed", alert.Number),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		err := data.SetAlertState(alert, reason, comment)
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Err:         err,
+			Msg: UpdateAlertMsg{
+				Kind:             alert.Kind,
+				Repo:             alert.Repo,
+				Number:           alert.Number,
+				State:            state,
+				DismissedReason:  reason,
+				DismissedComment: comment,
+			},
+		}
+	})
+}
+
+// reopen reopens a dismissed or resolved alert.
+func (m *Model) reopen(alert data.SecurityAlert) tea.Cmd {
+	taskId := fmt.Sprintf("alert_reopen_%s_%s_%d", alert.Kind, alert.Repo, alert.Number)
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Reopening alert #%d", alert.Number),
+		FinishedText: fmt.Sprintf("Alert #%d has been reopened", alert.Number),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		err := data.SetAlertState(alert, "", "")
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Err:         err,
+			Msg: UpdateAlertMsg{
+				Kind:   alert.Kind,
+				Repo:   alert.Repo,
+				Number: alert.Number,
+				State:  data.AlertStateOpen,
+			},
+		}
+	})
+}
+
+func (m *Model) UpdateLastUpdated(t time.Time) {
+	m.Table.UpdateLastUpdated(t)
+}
+
+func (m *Model) ResetRows() {
+	m.Alerts = nil
+	m.BaseModel.ResetRows()
+}
+
+func FetchAllSections(
+	ctx *context.ProgramContext,
+) (sections []section.Section, fetchAllCmd tea.Cmd) {
+	sectionConfigs := ctx.Config.AlertsSections
+	fetchAlertsCmds := make([]tea.Cmd, 0, len(sectionConfigs))
+	sections = make([]section.Section, 0, len(sectionConfigs))
+	for i, sectionConfig := range sectionConfigs {
+		sectionModel := NewModel(
+			i+1,
+			ctx,
+			sectionConfig,
+			time.Now(),
+		) // 0 is the search section
+		sections = append(sections, &sectionModel)
+		fetchAlertsCmds = append(fetchAlertsCmds, sectionModel.FetchNextPageSectionRows()...)
+	}
+
+	return sections, tea.Batch(fetchAlertsCmds...)
+}
+
+type SectionAlertsFetchedMsg struct {
+	Alerts []data.SecurityAlert
+	TaskId string
+}
+
+// UpdateAlertMsg is sent when an alert is dismissed or reopened
+type UpdateAlertMsg struct {
+	Kind             data.AlertKind
+	Repo             string
+	Number           int
+	State            string
+	DismissedReason  string
+	DismissedComment string
+}
+
+func (m Model) GetItemSingularForm() string {
+	return "Alert"
+}
+
+func (m Model) GetItemPluralForm() string {
+	return "Alerts"
+}
+
+func (m Model) GetTotalCount() int {
+	return m.TotalCount
+}
+
+func (m *Model) GetIsLoading() bool {
+	return m.IsLoading
+}
+
+func (m *Model) SetIsLoading(val bool) {
+	m.IsLoading = val
+	m.Table.SetIsLoading(val)
+}
+
+func (m Model) GetPagerContent() string {
+	pagerContent := ""
+	if m.TotalCount > 0 {
+		pagerContent = fmt.Sprintf(
+			"%v %v • %v %v/%v",
+			constants.WaitingIcon,
+			m.LastUpdated().Format("01/02 15:04:05"),
+			m.SingularForm,
+			m.Table.GetCurrItem()+1,
+			m.TotalCount,
+		)
+	}
+	pager := m.Ctx.Styles.ListViewPort.PagerStyle.Render(pagerContent)
+	return pager
+}
@@ -0,0 +1,10 @@
+package alertssection
+
+const (
+	kindCellWidth      = 3
+	repoCellWidth      = 20
+	severityCellWidth  = 10
+	ecosystemCellWidth = 11
+	packageCellWidth   = 20
+	updatedAtCellWidth = 5
+)
@@ -0,0 +1,78 @@
+package alertssection
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+// alertKinds maps the values of kind: to alert kinds
+var alertKinds = map[string]data.AlertKind{
+	"dependabot":      data.AlertKindDependabot,
+	"code-scanning":   data.AlertKindCodeScanning,
+	"codescanning":    data.AlertKindCodeScanning,
+	"code":            data.AlertKindCodeScanning,
+	"secret-scanning": data.AlertKindSecretScanning,
+	"secretscanning":  data.AlertKindSecretScanning,
+	"secret":          data.AlertKindSecretScanning,
+}
+
+// parseAlertFilters turns a section's filters into a request for its alerts.
+// Every qualifier takes comma-separated values and can be repeated:
+// repo:owner/name, org:login, kind:dependabot|code-scanning|secret-scanning,
+// state:open|dismissed|fixed|all, severity:critical|high|medium|low and
+// ecosystem:npm. Unknown tokens are ignored.
+func parseAlertFilters(search string, limit int) (data.AlertsRequest, error) {
+	req := data.AlertsRequest{Limit: limit}
+	for token := range strings.FieldsSeq(search) {
+		qualifier, value, ok := strings.Cut(token, ":")
+		if !ok || value == "" {
+			continue
+		}
+		values := strings.Split(strings.ToLower(value), ",")
+		switch strings.ToLower(qualifier) {
+		case "repo":
+			req.Repos = append(req.Repos, strings.Split(value, ",")...)
+		case "org":
+			req.Orgs = append(req.Orgs, strings.Split(value, ",")...)
+		case "kind":
+			for _, v := range values {
+				kind, ok := alertKinds[v]
+				if !ok {
+					return req, fmt.Errorf("unknown alert kind %q", v)
+				}
+				req.Kinds = append(req.Kinds, kind)
+			}
+		case "state", "is":
+			switch state := values[len(values)-1]; state {
+			case data.AlertStateOpen, data.AlertStateDismissed, data.AlertStateFixed:
+				req.State = state
+			case data.AlertStateResolved:
+				req.State = data.AlertStateDismissed
+			case "all":
+				req.State = ""
+			default:
+				return req, fmt.Errorf("unknown alert state %q", state)
+			}
+		case "severity":
+			req.Severities = append(req.Severities, values...)
+		case "ecosystem":
+			req.Ecosystems = append(req.Ecosystems, values...)
+		}
+	}
+	return req, nil
+}
+
+// parseDismissInput parses the answer to the dismiss prompt: the number of one
+// of the kind's dismiss reasons, optionally followed by a comment.
+func parseDismissInput(kind data.AlertKind, input string) (string, string, error) {
+	choice, comment, _ := strings.Cut(strings.TrimSpace(input), " ")
+	reasons := data.AlertDismissReasons(kind)
+	i, err := strconv.Atoi(choice)
+	if err != nil || i < 1 || i > len(reasons) {
+		return "", "", fmt.Errorf("invalid dismiss reason %q, expected 1-%d", choice, len(reasons))
+	}
+	return reasons[i-1], strings.TrimSpace(comment), nil
+}
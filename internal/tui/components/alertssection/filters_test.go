@@ -0,0 +1,58 @@
+package alertssection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+func TestParseAlertFilters(t *testing.T) {
+	req, err := parseAlertFilters(
+		"repo:o/a,o/b org:acme kind:dependabot,secret state:open severity:Critical,high ecosystem:npm archived:false",
+		30,
+	)
+	require.NoError(t, err)
+	require.Equal(t, data.AlertsRequest{
+		Repos:      []string{"o/a", "o/b"},
+		Orgs:       []string{"acme"},
+		Kinds:      []data.AlertKind{data.AlertKindDependabot, data.AlertKindSecretScanning},
+		State:      data.AlertStateOpen,
+		Severities: []string{"critical", "high"},
+		Ecosystems: []string{"npm"},
+		Limit:      30,
+	}, req)
+
+	req, err = parseAlertFilters("org:acme state:all", 10)
+	require.NoError(t, err)
+	require.Empty(t, req.State, "state:all matches every state")
+
+	req, err = parseAlertFilters("org:acme is:resolved", 10)
+	require.NoError(t, err)
+	require.Equal(t, data.AlertStateDismissed, req.State)
+
+	_, err = parseAlertFilters("org:acme kind:issues", 10)
+	require.Error(t, err)
+
+	_, err = parseAlertFilters("org:acme state:closed", 10)
+	require.Error(t, err)
+}
+
+func TestParseDismissInput(t *testing.T) {
+	reason, comment, err := parseDismissInput(data.AlertKindDependabot, " 5 only used in dev ")
+	require.NoError(t, err)
+	require.Equal(t, "tolerable_risk", reason)
+	require.Equal(t, "only used in dev", comment)
+
+	reason, comment, err = parseDismissInput(data.AlertKindCodeScanning, "3")
+	require.NoError(t, err)
+	require.Equal(t, "used in tests", reason)
+	require.Empty(t, comment)
+
+	_, _, err = parseDismissInput(data.AlertKindSecretScanning, "5")
+	require.Error(t, err)
+
+	_, _, err = parseDismissInput(data.AlertKindSecretScanning, "revoked")
+	require.Error(t, err)
+}
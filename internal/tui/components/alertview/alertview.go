@@ -0,0 +1,227 @@
+package alertview
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	log "charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/alertrow"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/markdown"
+	"github.com/dlvhdr/gh-dash/v4/internal/utils"
+)
+
+// fixPR is the fix pull request of a Dependabot alert, once it's fetched
+type fixPR struct {
+	loading bool
+	pr      *data.AlertFixPR
+}
+
+type Model struct {
+	ctx    *context.ProgramContext
+	alert  *data.SecurityAlert
+	width  int
+	fixPRs map[string]fixPR
+}
+
+func NewModel(ctx *context.ProgramContext) Model {
+	return Model{
+		ctx:    ctx,
+		fixPRs: map[string]fixPR{},
+	}
+}
+
+// FixPRFetchedMsg is sent when the fix pull request of a Dependabot alert
+// has been fetched.
+type FixPRFetchedMsg struct {
+	Host   string
+	Repo   string
+	Number int
+	PR     *data.AlertFixPR
+	Err    error
+}
+
+// fixPRKey names the alert of a repo on host, which is empty for gh's
+// default host
+func fixPRKey(host string, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", host, repo, number)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case FixPRFetchedMsg:
+		if msg.Err != nil {
+			log.Error("Failed fetching alert fix PR", "repo", msg.Repo, "number", msg.Number, "err", msg.Err)
+		}
+		// A failed fetch shows no fix PR rather than retrying on every render
+		m.fixPRs[fixPRKey(msg.Host, msg.Repo, msg.Number)] = fixPR{pr: msg.PR}
+	}
+	return m, nil
+}
+
+// SetRow shows an alert, and starts fetching its fix pull request if it's a
+// Dependabot alert that wasn't seen before.
+func (m *Model) SetRow(alert *data.SecurityAlert) tea.Cmd {
+	m.alert = alert
+	if alert == nil || alert.Kind != data.AlertKindDependabot {
+		return nil
+	}
+
+	key := fixPRKey(data.RowHost(alert), alert.Repo, alert.Number)
+	if _, ok := m.fixPRs[key]; ok {
+		return nil
+	}
+	m.fixPRs[key] = fixPR{loading: true}
+	host, repo, number := data.RowHost(alert), alert.Repo, alert.Number
+	return func() tea.Msg {
+		pr, err := data.FetchAlertFixPR(host, repo, number)
+		return FixPRFetchedMsg{Host: host, Repo: repo, Number: number, PR: pr, Err: err}
+	}
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+}
+
+func (m Model) View() string {
+	if m.alert == nil {
+		return ""
+	}
+
+	s := strings.Builder{}
+	s.WriteString(m.renderFullNameAndNumber())
+	s.WriteString("\n")
+	s.WriteString(common.RenderPreviewTitle(m.ctx.Theme, m.ctx.Styles.Common, m.width, m.alert.Title))
+	s.WriteString("\n\n")
+	s.WriteString(m.renderStatusPill())
+	s.WriteString("\n\n")
+	s.WriteString(m.renderDetails())
+	s.WriteString("\n\n")
+	if fix := m.renderFixPR(); fix != "" {
+		s.WriteString(fix)
+		s.WriteString("\n\n")
+	}
+	s.WriteString(m.renderDescription())
+	s.WriteString("\n\n")
+	s.WriteString(lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render(m.alert.Url))
+
+	return lipgloss.NewStyle().Padding(0, m.ctx.Styles.Sidebar.ContentPadding).Render(s.String())
+}
+
+func (m *Model) renderFullNameAndNumber() string {
+	header := fmt.Sprintf("%s #%d · %s", alertrow.KindIcon(m.alert.Kind), m.alert.Number, m.alert.Repo)
+	return common.RenderPreviewHeader(m.ctx.Theme, m.width, header)
+}
+
+func (m *Model) renderStatusPill() string {
+	if m.alert.State == "" {
+		return ""
+	}
+	bgColor := m.ctx.Styles.Colors.ClosedPR.Dark
+	content := " " + strings.ToUpper(m.alert.State[:1]) + m.alert.State[1:]
+	if m.alert.IsOpen() {
+		bgColor = m.ctx.Styles.Colors.OpenIssue.Dark
+	}
+	if m.alert.DismissedReason != "" {
+		content = fmt.Sprintf("%s as %s", content, strings.ReplaceAll(m.alert.DismissedReason, "_", " "))
+	}
+
+	return m.ctx.Styles.PrView.PillStyle.
+		BorderForeground(bgColor).
+		Background(bgColor).
+		Render(content)
+}
+
+func (m *Model) renderDetails() string {
+	labelStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	textStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.PrimaryText)
+
+	var lines []string
+	addLine := func(label, value string) {
+		if value == "" {
+			return
+		}
+		lines = append(lines, labelStyle.Render(fmt.Sprintf("%-11s", label))+value)
+	}
+	if m.alert.Severity != "" {
+		addLine("Severity", alertrow.RenderSeverity(m.ctx, m.alert.Severity))
+	}
+	switch m.alert.Kind {
+	case data.AlertKindDependabot:
+		addLine("Package", textStyle.Render(m.alert.Ecosystem+"/"+m.alert.Package))
+	case data.AlertKindCodeScanning:
+		addLine("Tool", textStyle.Render(m.alert.Ecosystem))
+		addLine("Rule", textStyle.Render(m.alert.Package))
+	case data.AlertKindSecretScanning:
+		addLine("Secret", textStyle.Render(m.alert.Package))
+	}
+	addLine("Affected", textStyle.Render(m.alert.VulnerableRange))
+	addLine("Patched", textStyle.Render(m.alert.PatchedVersion))
+	addLine("Location", textStyle.Render(m.alert.Location))
+	addLine("Advisory", textStyle.Render(strings.Join(m.alert.Identifiers, ", ")))
+	addLine("Opened", textStyle.Render(utils.TimeElapsed(m.alert.CreatedAt)+" ago"))
+	addLine("Comment", textStyle.Render(m.alert.DismissedComment))
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) renderFixPR() string {
+	if m.alert.Kind != data.AlertKindDependabot {
+		return ""
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	fix := m.fixPRs[fixPRKey(data.RowHost(m.alert), m.alert.Repo, m.alert.Number)]
+	switch {
+	case fix.loading:
+		return labelStyle.Render("Looking for a fix PR...")
+	case fix.pr == nil:
+		return labelStyle.Render("No fix PR")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top,
+			labelStyle.Render(fmt.Sprintf("%-11s", "Fix PR")),
+			lipgloss.NewStyle().Foreground(m.ctx.Theme.PrimaryText).Bold(true).Render(
+				fmt.Sprintf("#%d %s", fix.pr.Number, fix.pr.Title)),
+			labelStyle.Render(" · "+strings.ToLower(fix.pr.State)),
+		),
+		labelStyle.Render(fmt.Sprintf("%-11s", "")+fix.pr.Url),
+	)
+}
+
+func (m *Model) renderDescription() string {
+	width := m.getIndentedContentWidth()
+	description := strings.TrimSpace(m.alert.Description)
+	if description == "" {
+		return lipgloss.NewStyle().
+			Italic(true).
+			Foreground(m.ctx.Theme.FaintText).
+			Render("No description provided.")
+	}
+
+	markdownRenderer := markdown.GetMarkdownRenderer(width, m.ctx)
+	rendered, err := markdownRenderer.Render(description)
+	if err != nil {
+		return ""
+	}
+
+	return lipgloss.NewStyle().
+		Width(width).
+		MaxWidth(width).
+		Align(lipgloss.Left).
+		Render(rendered)
+}
+
+func (m *Model) getIndentedContentWidth() int {
+	return m.width - 6
+}
@@ -2,6 +2,8 @@ package branch
 
 import (
 	"fmt"
+	"os"
+	"path"
 	"strings"
 
 	"charm.land/lipgloss/v2"
@@ -20,6 +22,12 @@ type Branch struct {
 	PR      *data.PullRequestData
 	Data    git.Branch
 	Columns []table.Column
+	// RepoName is the name of the branch's repo, like dlvhdr/gh-dash, and
+	// RepoPath its local path
+	RepoName string
+	RepoPath string
+	// StackPrefix draws the branch's place in a stack of PRs, like "└─ "
+	StackPrefix string
 }
 
 func (b *Branch) getTextStyle() lipgloss.Style {
@@ -52,6 +60,10 @@ func (b *Branch) renderState() string {
 	mergeCellStyle := lipgloss.NewStyle()
 
 	if b.PR == nil {
+		if b.Data.IsMerged && b.Data.UpstreamGone {
+			return mergeCellStyle.Foreground(b.Ctx.Styles.Colors.MergedPR).
+				Render(constants.MergedIcon)
+		}
 		return mergeCellStyle.Foreground(b.Ctx.Theme.SuccessText).Render("󰜛")
 	}
 
@@ -168,11 +180,9 @@ func (b *Branch) renderAssignees() string {
 }
 
 func (b *Branch) renderRepoName() string {
-	repoName := ""
-	if !b.Ctx.Config.Theme.Ui.Table.Compact {
-		repoName = b.PR.Repository.NameWithOwner
-	} else {
-		repoName = b.PR.HeadRepository.Name
+	repoName := b.RepoName
+	if b.Ctx.Config.Theme.Ui.Table.Compact {
+		repoName = path.Base(repoName)
 	}
 	return b.getTextStyle().Foreground(b.Ctx.Theme.FaintText).Render(repoName)
 }
@@ -227,6 +237,7 @@ func (b *Branch) ToTableRow(isSelected bool) table.Row {
 	if !b.Ctx.Config.Theme.Ui.Table.Compact {
 		return table.Row{
 			b.renderState(),
+			b.renderRepoName(),
 			b.renderExtendedTitle(isSelected),
 			b.renderBaseName(),
 			b.renderAssignees(),
@@ -261,11 +272,27 @@ func (b *Branch) renderBranch(isSelected bool, width int) string {
 	}
 	return baseStyle.MaxHeight(1).Width(width).MaxWidth(width).Render(lipgloss.JoinHorizontal(
 		lipgloss.Top,
+		baseStyle.Foreground(b.Ctx.Theme.FaintText).Render(b.StackPrefix),
 		name,
 		b.renderCommitsAheadBehind(isSelected),
+		b.renderWorktree(isSelected),
 	))
 }
 
+func (b *Branch) renderWorktree(isSelected bool) string {
+	if b.Data.WorktreePath == "" {
+		return ""
+	}
+	path := b.Data.WorktreePath
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, ok := strings.CutPrefix(path, home); ok {
+			path = "~" + rel
+		}
+	}
+	return b.getBaseStyle(isSelected).Foreground(b.Ctx.Theme.FaintText).Render(
+		fmt.Sprintf(" %s %s", constants.WorktreeIcon, path))
+}
+
 func (b *Branch) getBaseStyle(isSelected bool) lipgloss.Style {
 	baseStyle := lipgloss.NewStyle()
 	if isSelected {
@@ -299,7 +326,12 @@ func (b *Branch) renderCommitsAheadBehind(isSelected bool) string {
 			fmt.Sprintf(" ↓%d", b.Data.CommitsBehind))
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, commitsAhead, commitsBehind)
+	gone := ""
+	if b.Data.UpstreamGone {
+		gone = baseStyle.Foreground(b.Ctx.Theme.FaintText).Render(" upstream gone")
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, commitsAhead, commitsBehind, gone)
 }
 
 func (b *Branch) renderLastCommitMsg(isSelected bool, width int) string {
@@ -308,6 +340,9 @@ func (b *Branch) renderLastCommitMsg(isSelected bool, width int) string {
 	if b.Data.LastCommitMsg != nil {
 		title = *b.Data.LastCommitMsg
 	}
+	if b.StackPrefix != "" {
+		title = strings.Repeat(" ", lipgloss.Width(b.StackPrefix)) + title
+	}
 	return baseStyle.Foreground(b.Ctx.Theme.SecondaryText).
 		Width(width).
 		MaxWidth(width).
@@ -10,6 +10,9 @@ import (
 type BranchData struct {
 	Data git.Branch
 	PR   *data.PullRequestData
+	// RepoName is the name of the branch's repo and RepoPath its local path
+	RepoName string
+	RepoPath string
 }
 
 func (b BranchData) GetRepoNameWithOwner() string {
@@ -43,3 +46,38 @@ func (b BranchData) GetUpdatedAt() time.Time {
 	}
 	return *b.Data.LastUpdatedAt
 }
+
+// IsMerged returns whether the branch was merged into the default branch,
+// either because the default branch has all its commits or because its PR
+// was merged, like with a squash merge
+func (b BranchData) IsMerged() bool {
+	return b.Data.IsMerged || (b.PR != nil && b.PR.State == "MERGED")
+}
+
+// IsPrunable returns whether the branch can be deleted as merged: its PR
+// was merged, or the default branch has its commits and its upstream was
+// deleted.
+func (b BranchData) IsPrunable() bool {
+	if b.Data.IsCheckedOut || b.Data.WorktreePath != "" {
+		return false
+	}
+	if b.PR != nil {
+		return b.PR.State == "MERGED"
+	}
+	return b.Data.IsMerged && b.Data.UpstreamGone
+}
+
+// IsSafeToForceDelete returns whether deleting the branch can't lose any
+// commits, even though git doesn't know it was merged: its tip is the head
+// commit of its merged PR, or the upstream it tracks has all its commits.
+// A branch with commits made after its PR merged, or a new branch reusing
+// the name of a merged PR's branch, isn't.
+func (b BranchData) IsSafeToForceDelete() bool {
+	if b.Data.IsMerged {
+		return true
+	}
+	if b.PR != nil && b.PR.HeadRefOid != "" && b.Data.Oid == b.PR.HeadRefOid {
+		return true
+	}
+	return b.Data.Upstream != "" && !b.Data.UpstreamGone && b.Data.CommitsAhead == 0
+}
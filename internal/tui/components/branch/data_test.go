@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/git"
 )
 
@@ -61,3 +62,112 @@ func TestBranchData_GetUpdatedAt_WithValue(t *testing.T) {
 
 	require.Equal(t, now, result, "should return the actual time")
 }
+
+func TestBranchData_IsMerged(t *testing.T) {
+	require.False(t, BranchData{}.IsMerged())
+	require.True(t, BranchData{Data: git.Branch{IsMerged: true}}.IsMerged())
+	require.True(t, BranchData{PR: &data.PullRequestData{State: "MERGED"}}.IsMerged(),
+		"squash merged branches are merged by their PR")
+	require.False(t, BranchData{PR: &data.PullRequestData{State: "CLOSED"}}.IsMerged())
+}
+
+func TestBranchData_IsPrunable(t *testing.T) {
+	tests := []struct {
+		name string
+		b    BranchData
+		want bool
+	}{
+		{
+			name: "merged PR",
+			b:    BranchData{PR: &data.PullRequestData{State: "MERGED"}},
+			want: true,
+		},
+		{
+			name: "open PR with merged commits",
+			b: BranchData{
+				Data: git.Branch{IsMerged: true, UpstreamGone: true},
+				PR:   &data.PullRequestData{State: "OPEN"},
+			},
+			want: false,
+		},
+		{
+			name: "merged and upstream deleted",
+			b:    BranchData{Data: git.Branch{IsMerged: true, UpstreamGone: true}},
+			want: true,
+		},
+		{
+			name: "new branch without commits",
+			b:    BranchData{Data: git.Branch{IsMerged: true}},
+			want: false,
+		},
+		{
+			name: "checked out",
+			b: BranchData{
+				Data: git.Branch{IsCheckedOut: true},
+				PR:   &data.PullRequestData{State: "MERGED"},
+			},
+			want: false,
+		},
+		{
+			name: "checked out in a worktree",
+			b: BranchData{
+				Data: git.Branch{WorktreePath: "/wt/1"},
+				PR:   &data.PullRequestData{State: "MERGED"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.b.IsPrunable())
+		})
+	}
+}
+
+func TestBranchData_IsSafeToForceDelete(t *testing.T) {
+	merged := &data.PullRequestData{State: "MERGED", HeadRefOid: "abc"}
+	tests := []struct {
+		name string
+		b    BranchData
+		want bool
+	}{
+		{
+			name: "tip is the merged PR's head",
+			b:    BranchData{Data: git.Branch{Oid: "abc", UpstreamGone: true}, PR: merged},
+			want: true,
+		},
+		{
+			name: "commits after the PR merged",
+			b:    BranchData{Data: git.Branch{Oid: "def", UpstreamGone: true}, PR: merged},
+			want: false,
+		},
+		{
+			name: "new branch reusing the name of a merged PR's branch",
+			b:    BranchData{Data: git.Branch{Oid: "def"}, PR: merged},
+			want: false,
+		},
+		{
+			name: "upstream has all its commits",
+			b:    BranchData{Data: git.Branch{Oid: "def", Upstream: "origin/feat"}, PR: merged},
+			want: true,
+		},
+		{
+			name: "ahead of its upstream",
+			b: BranchData{
+				Data: git.Branch{Oid: "def", Upstream: "origin/feat", CommitsAhead: 1},
+				PR:   merged,
+			},
+			want: false,
+		},
+		{
+			name: "merged into the default branch",
+			b:    BranchData{Data: git.Branch{IsMerged: true, UpstreamGone: true}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.b.IsSafeToForceDelete())
+		})
+	}
+}
@@ -6,17 +6,32 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
-	gitm "github.com/aymanbagabas/git-module"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/git"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/branch"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/inputbox"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prform"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/keys"
 )
 
 type Model struct {
 	ctx    *context.ProgramContext
 	branch *branch.BranchData
-	status *gitm.NameStatus
+	status *git.Status
+	// isFocused is true while the keys move between the changed files and
+	// act on the one under the cursor
+	isFocused bool
+	cursor    int
+	// confirmDiscard is true while asking whether to discard the changes of
+	// the file under the cursor
+	confirmDiscard bool
+	// commitBox is the commit message input, while committing
+	commitBox  *inputbox.Model
+	isAmending bool
+	// prForm is the form of the PR to create of the branch, while creating
+	// one
+	prForm *prform.Model
 }
 
 func NewModel(ctx *context.ProgramContext) Model {
@@ -28,32 +43,48 @@ func NewModel(ctx *context.ProgramContext) Model {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case updateBranchStatusMsg:
+		// The status of the repo of a branch that was selected before
+		if msg.path != m.repoPath() {
+			return m, nil
+		}
 		m.status = &msg.status
+		m.cursor = max(min(m.cursor, len(m.entries())-1), 0)
 		return m, nil
 	}
+
+	if m.prForm != nil {
+		return m.updatePRForm(msg)
+	}
+	if m.commitBox != nil {
+		return m.updateCommitBox(msg)
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok && m.isFocused {
+		return m.updateFocused(msg)
+	}
 	return m, nil
 }
 
 func (m Model) View() string {
+	if m.prForm != nil {
+		return m.prForm.View()
+	}
+
 	s := strings.Builder{}
 
 	s.WriteString(lipgloss.NewStyle().Bold(true).Render("STATUS\n"))
 	if m.status == nil {
 		s.WriteString("\nLoading...")
 	} else {
-		if len(m.status.Added) == 0 && len(m.status.Removed) == 0 && len(m.status.Modified) == 0 {
-			s.WriteString("\nNo changes")
-		}
-
-		for _, file := range m.status.Added {
-			fmt.Fprintf(&s, "\nA %s", file)
-		}
-		for _, file := range m.status.Removed {
-			fmt.Fprintf(&s, "\nD %s", file)
-		}
-		for _, file := range m.status.Modified {
-			fmt.Fprintf(&s, "\nM %s", file)
+		if m.status.Rebase != nil {
+			s.WriteString("\n")
+			s.WriteString(m.renderRebase())
 		}
+		s.WriteString("\n")
+		s.WriteString(m.renderChanges())
+	}
+	if m.commitBox != nil {
+		s.WriteString("\n\n")
+		s.WriteString(m.commitBox.View())
 	}
 
 	s.WriteString("\n\n")
@@ -67,16 +98,92 @@ func (m Model) View() string {
 	}
 
 	s.WriteString(m.branch.Data.Name)
+	if m.branch.Data.WorktreePath != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render(
+			"Checked out in " + m.branch.Data.WorktreePath))
+	}
 	if m.branch.PR != nil {
 		s.WriteString("\n")
 		fmt.Fprintf(&s, "#%d %s", m.branch.PR.GetNumber(), m.branch.PR.Title)
 	}
+	s.WriteString("\n\n")
+	s.WriteString(m.renderHealth())
 
 	return s.String()
 }
 
+// renderRebase shows the rebase in progress and how to go on with it
+func (m Model) renderRebase() string {
+	rebase := m.status.Rebase
+	warningStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.WarningText)
+	faintStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+
+	branch := rebase.Branch
+	if branch == "" {
+		branch = "HEAD"
+	}
+	title := fmt.Sprintf("Rebasing %s onto %s", branch, rebase.Onto)
+	if rebase.Total > 0 {
+		title += fmt.Sprintf(" (%d/%d)", rebase.Step, rebase.Total)
+	}
+
+	hint := fmt.Sprintf("%s to continue, %s to abort",
+		keys.BranchKeys.ContinueRebase.Help().Key, keys.BranchKeys.AbortRebase.Help().Key)
+	if len(m.status.Conflicted) > 0 {
+		hint = fmt.Sprintf("%d conflicted files, resolve and stage them, then ",
+			len(m.status.Conflicted)) + hint
+	}
+
+	return warningStyle.Render(title) + "\n" + faintStyle.Render(hint) + "\n"
+}
+
+// renderHealth shows how the branch compares with its upstream and the
+// repo's default branch, and whether it was merged
+func (m Model) renderHealth() string {
+	labelStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	textStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.PrimaryText)
+	warningStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.WarningText)
+	b := m.branch.Data
+
+	var lines []string
+	addLine := func(label, value string) {
+		lines = append(lines, labelStyle.Render(fmt.Sprintf("%-10s", label))+value)
+	}
+	divergence := func(ahead, behind int) string {
+		if ahead == 0 && behind == 0 {
+			return labelStyle.Render(" up to date")
+		}
+		return warningStyle.Render(fmt.Sprintf(" ↑%d ↓%d", ahead, behind))
+	}
+
+	switch {
+	case b.Upstream == "":
+		addLine("Upstream", labelStyle.Render("none"))
+	case b.UpstreamGone:
+		addLine("Upstream", textStyle.Render(b.Upstream)+labelStyle.Render(" deleted from remote"))
+	default:
+		addLine("Upstream", textStyle.Render(b.Upstream)+divergence(b.CommitsAhead, b.CommitsBehind))
+	}
+	if b.BaseBranch != "" {
+		addLine("Base", textStyle.Render(b.BaseBranch)+divergence(b.BaseAhead, b.BaseBehind))
+	}
+
+	switch {
+	case m.branch.PR != nil && m.branch.PR.State == "MERGED" && !b.IsMerged:
+		addLine("Merged", lipgloss.NewStyle().Foreground(m.ctx.Styles.Colors.MergedPR).Render(
+			fmt.Sprintf("squash merged in #%d", m.branch.PR.GetNumber())))
+	case m.branch.IsMerged():
+		addLine("Merged", lipgloss.NewStyle().Foreground(m.ctx.Styles.Colors.MergedPR).Render(
+			"into "+b.BaseBranch))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 type updateBranchStatusMsg struct {
-	status gitm.NameStatus
+	path   string
+	status git.Status
 }
 
 func (m *Model) SetRow(b *branch.BranchData) tea.Cmd {
@@ -85,15 +192,33 @@ func (m *Model) SetRow(b *branch.BranchData) tea.Cmd {
 }
 
 func (m *Model) refreshBranchStatusCmd() tea.Msg {
-	status, err := git.GetStatus(m.ctx.RepoPath)
+	return readStatus(m.repoPath())
+}
+
+// repoPath returns the path of the branch's repo, which is the repo gh-dash
+// runs in unless the repo view shows more than one
+func (m *Model) repoPath() string {
+	if m.branch != nil && m.branch.RepoPath != "" {
+		return m.branch.RepoPath
+	}
+	return m.ctx.RepoPath
+}
+
+func readStatus(dir string) tea.Msg {
+	status, err := git.GetStatus(dir)
 	if err != nil {
 		return nil
 	}
 	return updateBranchStatusMsg{
+		path:   dir,
 		status: status,
 	}
 }
 
 func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
 	m.ctx = ctx
+	if m.prForm != nil {
+		m.prForm.UpdateProgramContext(ctx)
+		m.prForm.SetWidth(max(ctx.DynamicPreviewWidth-5, 10))
+	}
 }
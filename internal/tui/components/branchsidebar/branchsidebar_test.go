@@ -3,12 +3,13 @@ package branchsidebar
 import (
 	"testing"
 
-	gitm "github.com/aymanbagabas/git-module"
+	tea "charm.land/bubbletea/v2"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/git"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/branch"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/theme"
 )
@@ -23,7 +24,7 @@ func testCtx() *context.ProgramContext {
 func TestView_NoBranch(t *testing.T) {
 	m := NewModel(testCtx())
 	m.ctx = testCtx()
-	m.status = &gitm.NameStatus{}
+	m.status = &git.Status{}
 
 	got := m.View()
 	require.Equal(t, "No branch selected", got)
@@ -43,7 +44,7 @@ func TestView_NoChanges(t *testing.T) {
 	m := NewModel(testCtx())
 	m.ctx = testCtx()
 	m.branch = &branch.BranchData{Data: git.Branch{Name: "main"}}
-	m.status = &gitm.NameStatus{}
+	m.status = &git.Status{}
 
 	got := m.View()
 	require.Contains(t, got, "No changes")
@@ -54,11 +55,11 @@ func TestView_WithFileChanges(t *testing.T) {
 	m := NewModel(testCtx())
 	m.ctx = testCtx()
 	m.branch = &branch.BranchData{Data: git.Branch{Name: "feature"}}
-	m.status = &gitm.NameStatus{
-		Added:    []string{"new.go"},
-		Removed:  []string{"old.go"},
-		Modified: []string{"changed.go"},
-	}
+	m.status = &git.Status{Files: []git.FileStatus{
+		{Path: "new.go", Staged: 'A', Unstaged: ' '},
+		{Path: "old.go", Staged: ' ', Unstaged: 'D'},
+		{Path: "changed.go", Staged: ' ', Unstaged: 'M'},
+	}}
 
 	got := m.View()
 	require.Contains(t, got, "A new.go")
@@ -67,6 +68,22 @@ func TestView_WithFileChanges(t *testing.T) {
 	require.Contains(t, got, "feature")
 }
 
+func TestView_Rebasing(t *testing.T) {
+	m := NewModel(testCtx())
+	m.ctx = testCtx()
+	m.branch = &branch.BranchData{Data: git.Branch{Name: "feature"}}
+	m.status = &git.Status{
+		Files:      []git.FileStatus{{Path: "conflict.go", Staged: 'U', Unstaged: 'U'}},
+		Conflicted: []string{"conflict.go"},
+		Rebase:     &git.RebaseState{Branch: "feature", Onto: "abc1234", Step: 2, Total: 3},
+	}
+
+	got := m.View()
+	require.Contains(t, got, "Rebasing feature onto abc1234 (2/3)")
+	require.Contains(t, got, "U conflict.go")
+	require.NotContains(t, got, "No changes")
+}
+
 func TestView_WithPR(t *testing.T) {
 	m := NewModel(testCtx())
 	m.ctx = testCtx()
@@ -74,9 +91,76 @@ func TestView_WithPR(t *testing.T) {
 		Data: git.Branch{Name: "feature"},
 		PR:   &data.PullRequestData{Number: 42, Title: "Add feature"},
 	}
-	m.status = &gitm.NameStatus{}
+	m.status = &git.Status{}
 
 	got := m.View()
 	require.Contains(t, got, "#42 Add feature")
 	require.Contains(t, got, "feature")
 }
+
+func keyPress(r rune) tea.KeyPressMsg {
+	return tea.KeyPressMsg{Code: r, Text: string(r)}
+}
+
+func TestChanges_Focused(t *testing.T) {
+	m := NewModel(testCtx())
+	m.ctx = testCtx()
+	m.branch = &branch.BranchData{Data: git.Branch{Name: "feature"}}
+	m.status = &git.Status{Files: []git.FileStatus{
+		{Path: "both.go", Staged: 'M', Unstaged: 'M'},
+		{Path: "new.go", Staged: '?', Unstaged: '?'},
+	}}
+
+	entries := m.entries()
+	require.Len(t, entries, 3)
+	require.True(t, entries[0].staged)
+	require.Equal(t, "both.go", entries[1].file.Path)
+	require.False(t, entries[1].staged)
+
+	require.False(t, m.IsFocused())
+	require.Contains(t, m.View(), "w to stage and commit")
+	m.Focus()
+	require.True(t, m.IsFocused())
+	require.Contains(t, m.View(), "Staged")
+	require.Contains(t, m.View(), "? new.go")
+
+	for range 5 {
+		m, _ = m.Update(keyPress('j'))
+	}
+	require.Equal(t, 2, m.cursor)
+	m, _ = m.Update(keyPress('k'))
+	require.Equal(t, 1, m.cursor)
+
+	m, _ = m.Update(keyPress('x'))
+	require.Contains(t, m.View(), "Discard the unstaged changes to both.go, keeping the staged ones?")
+	m, cmd := m.Update(keyPress('n'))
+	require.Nil(t, cmd)
+	require.NotContains(t, m.View(), "Discard the unstaged changes")
+
+	m, _ = m.Update(keyPress('k'))
+	m, _ = m.Update(keyPress('x'))
+	require.Contains(t, m.View(), "Unstage the changes to both.go, keeping them in the worktree?")
+	m, _ = m.Update(keyPress('n'))
+	m, _ = m.Update(keyPress('j'))
+	m, _ = m.Update(keyPress('j'))
+	m, _ = m.Update(keyPress('x'))
+	require.Contains(t, m.View(), "Delete the untracked file new.go?")
+	m, _ = m.Update(keyPress('n'))
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEsc})
+	require.False(t, m.IsFocused())
+}
+
+func TestStartCommit_NothingStaged(t *testing.T) {
+	m := NewModel(testCtx())
+	m.ctx = testCtx()
+	m.status = &git.Status{Files: []git.FileStatus{
+		{Path: "changed.go", Staged: ' ', Unstaged: 'M'},
+	}}
+
+	cmd := m.StartCommit(false)
+	require.NotNil(t, cmd)
+	_, ok := cmd().(constants.ErrMsg)
+	require.True(t, ok)
+	require.False(t, m.IsFocused())
+}
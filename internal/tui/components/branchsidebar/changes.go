@@ -0,0 +1,296 @@
+package branchsidebar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/inputbox"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/reposection"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/keys"
+)
+
+// fileEntry is a row of the changes list, the staged or the unstaged
+// changes of a file. A file with both is listed twice.
+type fileEntry struct {
+	file   git.FileStatus
+	staged bool
+}
+
+// code is the letter git status shows for the entry's changes
+func (e fileEntry) code() byte {
+	switch {
+	case e.file.IsConflicted():
+		return 'U'
+	case e.staged:
+		return e.file.Staged
+	default:
+		return e.file.Unstaged
+	}
+}
+
+// entries lists the staged changes first, then the conflicted files and
+// then the changes that aren't staged
+func (m Model) entries() []fileEntry {
+	if m.status == nil {
+		return nil
+	}
+	staged := make([]fileEntry, 0)
+	conflicted := make([]fileEntry, 0)
+	unstaged := make([]fileEntry, 0)
+	for _, file := range m.status.Files {
+		switch {
+		case file.IsConflicted():
+			conflicted = append(conflicted, fileEntry{file: file})
+		default:
+			if file.HasStaged() {
+				staged = append(staged, fileEntry{file: file, staged: true})
+			}
+			if file.HasUnstaged() {
+				unstaged = append(unstaged, fileEntry{file: file})
+			}
+		}
+	}
+	return append(append(staged, conflicted...), unstaged...)
+}
+
+// IsFocused returns whether the changes list, the commit message input or
+// the create PR form get the keys
+func (m *Model) IsFocused() bool {
+	return m.isFocused || m.commitBox != nil || m.prForm != nil
+}
+
+// Focus moves the keys to the changes list, to stage, unstage and discard
+// the changes of its files
+func (m *Model) Focus() {
+	m.isFocused = true
+	m.confirmDiscard = false
+}
+
+func (m Model) updateFocused(msg tea.KeyMsg) (Model, tea.Cmd) {
+	entries := m.entries()
+	if m.confirmDiscard {
+		m.confirmDiscard = false
+		if msg.String() == "y" && m.cursor < len(entries) {
+			return m, m.discard(entries[m.cursor])
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, keys.Keys.Up):
+		m.cursor = max(m.cursor-1, 0)
+	case key.Matches(msg, keys.Keys.Down):
+		m.cursor = max(min(m.cursor+1, len(entries)-1), 0)
+	case key.Matches(msg, keys.BranchKeys.StageFile):
+		if m.cursor >= len(entries) {
+			return m, nil
+		}
+		entry := entries[m.cursor]
+		if entry.staged {
+			return m, m.runGitCmd(func(dir string) error { return git.UnstageFile(dir, entry.file) })
+		}
+		return m, m.runGitCmd(func(dir string) error { return git.StageFile(dir, entry.file.Path) })
+	case key.Matches(msg, keys.BranchKeys.DiscardFile):
+		m.confirmDiscard = m.cursor < len(entries)
+	case key.Matches(msg, keys.BranchKeys.Commit):
+		return m, m.StartCommit(false)
+	case key.Matches(msg, keys.BranchKeys.Amend):
+		return m, m.StartCommit(true)
+	case msg.String() == "esc", key.Matches(msg, keys.BranchKeys.FocusChanges):
+		m.isFocused = false
+	}
+	return m, nil
+}
+
+// discard drops the entry's changes: a staged entry is unstaged, keeping its
+// changes in the worktree, while the changes of an unstaged one are restored
+// from the index
+func (m *Model) discard(entry fileEntry) tea.Cmd {
+	if entry.staged {
+		return m.runGitCmd(func(dir string) error { return git.UnstageFile(dir, entry.file) })
+	}
+	return m.runGitCmd(func(dir string) error { return git.DiscardFile(dir, entry.file) })
+}
+
+// discardPrompt asks whether to discard the entry's changes, naming what's
+// lost
+func (e fileEntry) discardPrompt() string {
+	path := e.file.Path
+	switch {
+	case e.staged:
+		return fmt.Sprintf("Unstage the changes to %s, keeping them in the worktree? (y/N)", path)
+	case e.file.IsUntracked():
+		return fmt.Sprintf("Delete the untracked file %s? (y/N)", path)
+	case e.file.IsConflicted():
+		return fmt.Sprintf("Discard both sides of the conflict in %s, restoring it from HEAD? (y/N)",
+			path)
+	case e.file.HasStaged():
+		return fmt.Sprintf("Discard the unstaged changes to %s, keeping the staged ones? (y/N)", path)
+	default:
+		return fmt.Sprintf("Discard the unstaged changes to %s? (y/N)", path)
+	}
+}
+
+// runGitCmd runs fn in the repo and reads the status again once it's done
+func (m *Model) runGitCmd(fn func(dir string) error) tea.Cmd {
+	dir := m.repoPath()
+	return func() tea.Msg {
+		if err := fn(dir); err != nil {
+			return constants.ErrMsg{Err: err}
+		}
+		return readStatus(dir)
+	}
+}
+
+// StartCommit shows the commit message input. Amending starts with the
+// message of the last commit.
+func (m *Model) StartCommit(amend bool) tea.Cmd {
+	if !amend && !m.hasStagedChanges() {
+		return func() tea.Msg {
+			return constants.ErrMsg{Err: fmt.Errorf(
+				"no staged changes to commit, stage them with %s in the changes list",
+				keys.BranchKeys.StageFile.Help().Key)}
+		}
+	}
+
+	ta := inputbox.DefaultTextArea(m.ctx)
+	box := inputbox.NewModel(m.ctx, inputbox.ModelOpts{TextArea: &ta})
+	box.SetWidth(max(m.ctx.DynamicPreviewWidth-5, 10))
+	if amend {
+		box.SetPrompt("Amend the last commit")
+		if message, err := git.LastCommitMessage(m.repoPath()); err == nil {
+			box.SetValue(message)
+		}
+	} else {
+		box.SetPrompt("Commit the staged changes")
+	}
+	m.commitBox = &box
+	m.isAmending = amend
+	m.confirmDiscard = false
+	return m.commitBox.Focus()
+}
+
+func (m *Model) hasStagedChanges() bool {
+	for _, entry := range m.entries() {
+		if entry.staged {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Model) updateCommitBox(msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "ctrl+d":
+			message, amend := m.commitBox.Value(), m.isAmending
+			m.commitBox = nil
+			return m, m.commit(message, amend)
+		case "ctrl+c", "esc":
+			m.commitBox = nil
+			return m, nil
+		}
+	}
+
+	box, cmd := m.commitBox.Update(msg)
+	m.commitBox = &box
+	return m, cmd
+}
+
+func (m *Model) commit(message string, amend bool) tea.Cmd {
+	startText, finishedText := "Committing the staged changes", "The staged changes have been committed"
+	if amend {
+		startText, finishedText = "Amending the last commit", "The last commit has been amended"
+	}
+	taskId := fmt.Sprintf("commit_%d", time.Now().Unix())
+	task := context.Task{
+		Id:           taskId,
+		StartText:    startText,
+		FinishedText: finishedText,
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.ctx.StartTask(task)
+	dir := m.repoPath()
+	return tea.Batch(startCmd, func() tea.Msg {
+		// The repo view reads the branches again once the commit changed
+		// their refs, and the sidebar its status once the task finished
+		return constants.TaskFinishedMsg{
+			SectionId:   0,
+			SectionType: reposection.SectionType,
+			TaskId:      taskId,
+			Err:         git.Commit(dir, message, amend),
+		}
+	})
+}
+
+// renderChanges lists the staged, conflicted and unstaged changes, with the
+// cursor on one of them while the list is focused
+func (m Model) renderChanges() string {
+	entries := m.entries()
+	if len(entries) == 0 {
+		return "No changes"
+	}
+
+	faintStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	headings := map[string]bool{}
+	lines := make([]string, 0, len(entries)+3)
+	for i, entry := range entries {
+		heading, style := "Changes", lipgloss.NewStyle().Foreground(m.ctx.Theme.PrimaryText)
+		switch {
+		case entry.file.IsConflicted():
+			heading, style = "Conflicts", lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText)
+		case entry.staged:
+			heading, style = "Staged", lipgloss.NewStyle().Foreground(m.ctx.Theme.SuccessText)
+		}
+		if !headings[heading] {
+			headings[heading] = true
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, faintStyle.Render(heading))
+		}
+
+		path := entry.file.Path
+		if entry.file.OrigPath != "" {
+			path = entry.file.OrigPath + " → " + path
+		}
+		if m.isFocused && i == m.cursor {
+			style = style.Background(m.ctx.Theme.SelectedBackground).Bold(true)
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%c %s", entry.code(), path)))
+	}
+
+	switch {
+	case m.confirmDiscard && m.cursor < len(entries):
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(m.ctx.Theme.WarningText).Render(
+			entries[m.cursor].discardPrompt()))
+	case m.isFocused:
+		lines = append(lines, "", faintStyle.Render(m.changesHelp()))
+	case m.commitBox == nil:
+		lines = append(lines, "", faintStyle.Render(fmt.Sprintf(
+			"%s to stage and commit", keys.BranchKeys.FocusChanges.Help().Key)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) changesHelp() string {
+	help := make([]string, 0)
+	for _, b := range []key.Binding{
+		keys.BranchKeys.StageFile,
+		keys.BranchKeys.DiscardFile,
+		keys.BranchKeys.Commit,
+		keys.BranchKeys.Amend,
+	} {
+		help = append(help, fmt.Sprintf("%s %s", b.Help().Key, b.Help().Desc))
+	}
+	return strings.Join(append(help, "esc back"), " • ")
+}
@@ -0,0 +1,42 @@
+package branchsidebar
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prform"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/reposection"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
+)
+
+// StartCreatePR shows the form a PR of the branch is created with
+func (m *Model) StartCreatePR(opts prform.Options) tea.Cmd {
+	form := prform.NewModel(m.ctx, opts)
+	form.SetWidth(max(m.ctx.DynamicPreviewWidth-5, 10))
+	m.prForm = &form
+	m.commitBox = nil
+	m.confirmDiscard = false
+	return m.prForm.Focus()
+}
+
+// IsCreatingPR returns whether the create PR form is shown
+func (m *Model) IsCreatingPR() bool {
+	return m.prForm != nil
+}
+
+func (m Model) updatePRForm(msg tea.Msg) (Model, tea.Cmd) {
+	form, cmd := m.prForm.Update(msg)
+	switch {
+	case form.IsSubmitted():
+		m.prForm = nil
+		return m, tasks.CreatePR(
+			m.ctx,
+			tasks.SectionIdentifier{Id: 0, Type: reposection.SectionType},
+			form.PR(),
+		)
+	case form.IsCancelled():
+		m.prForm = nil
+		return m, nil
+	}
+	m.prForm = &form
+	return m, cmd
+}
@@ -28,6 +28,8 @@ const (
 	ModeUnassign
 	ModeLabel
 	ModeSearch
+	// ModeCreatePR is the create PR form, whose fields switch the source
+	ModeCreatePR
 )
 
 type FetchPolicy int
@@ -368,7 +370,7 @@ func (c *Controller) resetAutocompleteState() {
 
 func (c Controller) usesAutocomplete() bool {
 	switch c.mode {
-	case ModeComment, ModeApprove, ModeAssign, ModeLabel, ModeSearch:
+	case ModeComment, ModeApprove, ModeAssign, ModeLabel, ModeSearch, ModeCreatePR:
 		return true
 	default:
 		return false
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path"
 	"strings"
+	"time"
 
 	bbHelp "charm.land/bubbles/v2/help"
 	"charm.land/lipgloss/v2"
@@ -11,6 +12,7 @@ import (
 	zone "github.com/lrstanley/bubblezone/v2"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/git"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
@@ -70,6 +72,7 @@ func (m Model) View() string {
 		if m.rightSection != nil {
 			rightSection = *m.rightSection
 		}
+		rightSection += m.renderRateLimit()
 		spacing := lipgloss.NewStyle().
 			Background(m.ctx.Theme.SelectedBackground).
 			Render(
@@ -135,6 +138,9 @@ func (m *Model) renderViewButton(view config.ViewType) string {
 	case config.IssuesView:
 		icon = ""
 		label = " Issues"
+	case config.AlertsView:
+		icon = constants.SecurityIcon
+		label = " Alerts"
 	}
 
 	if isActive {
@@ -171,6 +177,15 @@ func (m *Model) renderViewSwitcher(ctx *context.ProgramContext) string {
 		repo = ctx.Styles.Common.FooterStyle.Render(fmt.Sprintf(" %s", name))
 	}
 
+	// The alerts view is only part of the cycle when it has sections
+	var alerts string
+	if ctx.Config != nil && len(ctx.Config.AlertsSections) > 0 {
+		alerts = lipgloss.JoinHorizontal(lipgloss.Top,
+			ctx.Styles.ViewSwitcher.ViewsSeparator.Render(viewSeparator),
+			m.renderViewButton(config.AlertsView),
+		)
+	}
+
 	var user string
 	if ctx.User != "" {
 		user = ctx.Styles.Common.FooterStyle.Render("@" + ctx.User)
@@ -184,6 +199,7 @@ func (m *Model) renderViewSwitcher(ctx *context.ProgramContext) string {
 		m.renderViewButton(config.PRsView),
 		ctx.Styles.ViewSwitcher.ViewsSeparator.Render(viewSeparator),
 		m.renderViewButton(config.IssuesView),
+		alerts,
 		lipgloss.NewStyle().Background(ctx.Styles.Common.FooterStyle.GetBackground()).Foreground(
 			ctx.Styles.ViewSwitcher.ViewsSeparator.GetBackground()).Render(" "),
 		repo,
@@ -195,6 +211,29 @@ func (m *Model) renderViewSwitcher(ctx *context.ProgramContext) string {
 	return ctx.Styles.ViewSwitcher.Root.Render(view)
 }
 
+// renderRateLimit shows the remaining budget of the most depleted GitHub API
+// rate limit, and when it resets once it runs low.
+func (m *Model) renderRateLimit() string {
+	rl, ok := data.GetLowestRateLimit()
+	if !ok {
+		return ""
+	}
+
+	style := lipgloss.NewStyle().
+		Background(m.ctx.Theme.SelectedBackground).
+		Foreground(m.ctx.Theme.FaintText).
+		Padding(0, 1)
+	text := fmt.Sprintf("%s %s", constants.RateLimitIcon, utils.ShortNumber(rl.Remaining))
+	if rl.IsLow() {
+		style = style.Foreground(m.ctx.Theme.WarningText)
+		// TimeElapsed measures from a point in the past, so mirror the reset time.
+		resetIn := utils.TimeElapsed(time.Now().Add(-time.Until(rl.ResetAt)))
+		text = fmt.Sprintf("%s (resets in %s)", text, resetIn)
+	}
+
+	return style.Render(text)
+}
+
 func (m *Model) SetLeftSection(leftSection string) {
 	*m.leftSection = leftSection
 }
@@ -0,0 +1,49 @@
+package fuzzyselect
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// BranchSource suggests the branches to pick a single one from, like the
+// base branch of a PR. The whole input is the branch.
+type BranchSource struct {
+	Branches []string
+}
+
+func (*BranchSource) ExtractContext(input string, cursorPos tea.Position) Context {
+	line := lines(input)[0]
+	return Context{
+		Start:   tea.Position{},
+		End:     tea.Position{X: len([]rune(line))},
+		Content: strings.TrimSpace(line),
+	}
+}
+
+func (src *BranchSource) Suggestions(input string, cursorPos tea.Position) []Suggestion {
+	suggestions := make([]Suggestion, 0, len(src.Branches))
+	for _, branch := range src.Branches {
+		suggestions = append(suggestions, Suggestion{Value: branch})
+	}
+	return suggestions
+}
+
+func (*BranchSource) InsertSuggestion(
+	input string,
+	suggestion string,
+	contextStart tea.Position,
+	contextEnd tea.Position,
+) (newInput string, newCursorPos tea.Position) {
+	return suggestion, tea.Position{X: len([]rune(suggestion))}
+}
+
+func (*BranchSource) ItemsToExclude(input string, cursorPos tea.Position) []string {
+	return nil
+}
+
+// LoadSuggestions loads nothing, the branches are read from the local clone
+// beforehand
+func (*BranchSource) LoadSuggestions(ctx LoaderContext) error {
+	return nil
+}
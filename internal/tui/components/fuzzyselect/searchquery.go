@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
@@ -16,8 +17,14 @@ type SearchQuerySource struct {
 	LabelsErr error
 	Users     []data.User
 	UsersErr  error
+	// Notifications completes the qualifiers of notification searches
+	Notifications bool
 }
 
+// notificationQualifiers are the qualifiers of notification searches that
+// have a fixed set of values.
+var notificationQualifiers = []string{"is:", "type:", "reason:", "sort:", "updated:"}
+
 func authorPrefix(info WordInfo) (string, bool) {
 	if strings.HasPrefix(info.Word, "author:") {
 		return "author:", true
@@ -42,8 +49,78 @@ func labelPrefix(info WordInfo) (string, bool) {
 	return "", false
 }
 
-func (*SearchQuerySource) ExtractContext(input string, cursorPos tea.Position) Context {
+func notificationQualifierPrefix(info WordInfo) (string, bool) {
+	for _, qualifier := range notificationQualifiers {
+		if strings.HasPrefix(info.Word, qualifier) {
+			return qualifier, true
+		}
+	}
+	return "", false
+}
+
+func notificationQualifierSuggestions(qualifier string) []Suggestion {
+	switch qualifier {
+	case "is:":
+		return []Suggestion{
+			{Value: "unread", Detail: "Unread notifications"},
+			{Value: "read", Detail: "Read notifications"},
+			{Value: "all", Detail: "Read and unread notifications"},
+			{Value: "bookmarked", Detail: "Bookmarked notifications"},
+			{Value: "snoozed", Detail: "Snoozed notifications"},
+		}
+	case "type:":
+		return []Suggestion{
+			{Value: "pr", Detail: "Pull requests"},
+			{Value: "issue", Detail: "Issues"},
+			{Value: "release", Detail: "Releases"},
+			{Value: "discussion", Detail: "Discussions"},
+			{Value: "checksuite", Detail: "CI activity"},
+			{Value: "commit", Detail: "Commits"},
+		}
+	case "reason:":
+		return []Suggestion{
+			{Value: "participating", Detail: "Threads you're participating in"},
+			{Value: "review-requested", Detail: "Your review was requested"},
+			{Value: "mention", Detail: "You were mentioned"},
+			{Value: "team-mention", Detail: "Your team was mentioned"},
+			{Value: "author", Detail: "Threads you created"},
+			{Value: "comment", Detail: "Threads you commented on"},
+			{Value: "assign", Detail: "You were assigned"},
+			{Value: "state-change", Detail: "You changed the thread's state"},
+			{Value: "subscribed", Detail: "Repositories you watch"},
+			{Value: "ci-activity", Detail: "Workflow runs you triggered"},
+			{Value: "security-alert", Detail: "Security alerts"},
+		}
+	case "sort:":
+		return []Suggestion{
+			{Value: "updated", Detail: "Most recently updated first"},
+			{Value: "repo", Detail: "By repository"},
+		}
+	case "updated:":
+		now := time.Now()
+		since := func(days int) string {
+			return ">=" + now.AddDate(0, 0, -days).Format(time.DateOnly)
+		}
+		return []Suggestion{
+			{Value: since(1), Detail: "Updated in the last day"},
+			{Value: since(7), Detail: "Updated in the last week"},
+			{Value: since(30), Detail: "Updated in the last month"},
+			{Value: "<" + now.AddDate(0, 0, -30).Format(time.DateOnly), Detail: "Not updated in a month"},
+		}
+	}
+	return nil
+}
+
+func (src *SearchQuerySource) ExtractContext(input string, cursorPos tea.Position) Context {
 	info := ExtractWordAtCursor(input, cursorPos)
+	if prefix, ok := notificationQualifierPrefix(info); ok && src.Notifications {
+		c, _ := strings.CutPrefix(info.Word, prefix)
+		return Context{
+			Start:   tea.Position{X: info.StartIdx.X + len(prefix), Y: info.StartIdx.Y},
+			End:     info.EndIdx,
+			Content: c,
+		}
+	}
 	if prefix, ok := authorPrefix(info); ok {
 		c, _ := strings.CutPrefix(info.Word, prefix)
 		return Context{
@@ -84,6 +161,10 @@ func (src *SearchQuerySource) Suggestions(input string, cursorPos tea.Position)
 		return suggestions
 	}
 
+	if prefix, ok := notificationQualifierPrefix(wordInfo); ok && src.Notifications {
+		return notificationQualifierSuggestions(prefix)
+	}
+
 	if _, ok := labelPrefix(wordInfo); ok {
 		suggestions := make([]Suggestion, 0, len(src.Labels))
 		for _, label := range src.Labels {
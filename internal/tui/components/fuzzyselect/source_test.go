@@ -294,3 +294,37 @@ yes
 @octo `, newInput)
 	require.Equal(t, tea.Position{Y: 0, X: 6}, newCursor)
 }
+
+func TestSearchQuerySourceNotificationQualifiers(t *testing.T) {
+	input := "is:unread type:p"
+	cursor := tea.Position{X: len(input)}
+
+	src := &SearchQuerySource{Notifications: true}
+	ctx := src.ExtractContext(input, cursor)
+	require.Equal(t, "p", ctx.Content)
+	require.Equal(t, tea.Position{X: len("is:unread type:")}, ctx.Start)
+
+	values := make([]string, 0)
+	for _, s := range src.Suggestions(input, cursor) {
+		values = append(values, s.Value)
+	}
+	require.Contains(t, values, "pr")
+	require.Contains(t, values, "release")
+
+	// Other searches don't complete notification qualifiers
+	require.Empty(t, (&SearchQuerySource{}).Suggestions(input, cursor))
+}
+
+func TestBranchSource(t *testing.T) {
+	src := &BranchSource{Branches: []string{"main", "release/v4"}}
+
+	ctx := src.ExtractContext(" rel", tea.Position{X: 2})
+	require.Equal(t, Context{Start: tea.Position{}, End: tea.Position{X: 4}, Content: "rel"}, ctx)
+
+	newValue, newCursorPos := src.InsertSuggestion(" rel", "release/v4", ctx.Start, ctx.End)
+	require.Equal(t, "release/v4", newValue)
+	require.Equal(t, tea.Position{X: 10}, newCursorPos)
+
+	require.Len(t, src.Suggestions("", tea.Position{}), 2)
+	require.Nil(t, src.ItemsToExclude("main", tea.Position{}))
+}
@@ -17,6 +17,8 @@ type Issue struct {
 	Ctx            *context.ProgramContext
 	Data           data.IssueData
 	ShowAuthorIcon bool
+	// SeenIn holds the titles of the other sections showing this issue.
+	SeenIn []string
 }
 
 func (issue *Issue) ToTableRow() table.Row {
@@ -74,7 +76,8 @@ func (issue *Issue) renderTitle() string {
 		issue.Data.State,
 		issue.Data.Title,
 		issue.Data.Number,
-	)
+	) + components.RenderHostBadge(issue.Ctx, data.RowHost(issue.Data)) +
+		components.RenderSeenElsewhere(issue.Ctx, issue.SeenIn)
 }
 
 func (issue *Issue) renderOpenedBy() string {
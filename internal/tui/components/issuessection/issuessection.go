@@ -123,7 +123,7 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 
 	case tasks.UpdateIssueMsg:
 		for i, currIssue := range m.Issues {
-			if currIssue.Number == msg.IssueNumber {
+			if msg.IsFor(currIssue.Number, currIssue.Url) {
 				if msg.IsClosed != nil {
 					if *msg.IsClosed {
 						currIssue.State = "CLOSED"
@@ -164,7 +164,7 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			m.PageInfo = &msg.PageInfo
 			m.Table.SetRows(m.BuildRows())
 			m.UpdateLastUpdated(time.Now())
-			m.UpdateTotalItemsCount(m.TotalCount)
+			m.UpdateTotalItemsCount(m.GetTotalCount())
 		}
 	}
 
@@ -263,8 +263,13 @@ func GetSectionColumns(
 
 func (m Model) BuildRows() []table.Row {
 	var rows []table.Row
-	for _, currIssue := range m.Issues {
-		issueModel := issuerow.Issue{Ctx: m.Ctx, Data: currIssue, ShowAuthorIcon: m.ShowAuthorIcon}
+	for _, currIssue := range m.visibleIssues() {
+		issueModel := issuerow.Issue{
+			Ctx:            m.Ctx,
+			Data:           currIssue,
+			ShowAuthorIcon: m.ShowAuthorIcon,
+			SeenIn:         m.seenElsewhere(currIssue.Url),
+		}
 		rows = append(rows, issueModel.ToTableRow())
 	}
 
@@ -276,15 +281,16 @@ func (m Model) BuildRows() []table.Row {
 }
 
 func (m *Model) NumRows() int {
-	return len(m.Issues)
+	return len(m.visibleIssues())
 }
 
 func (m *Model) GetCurrRow() data.RowData {
 	idx := m.Table.GetCurrItem()
-	if idx < 0 || idx >= len(m.Issues) {
+	issues := m.visibleIssues()
+	if idx < 0 || idx >= len(issues) {
 		return nil
 	}
-	issue := m.Issues[idx]
+	issue := issues[idx]
 	return &issue
 }
 
@@ -297,6 +303,18 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 		return nil
 	}
 
+	taskId, req, cmds := m.startFetch()
+	fetchCmd := func() tea.Msg {
+		res, err := data.FetchIssues(req.Host, req.Query, req.Limit, req.PageInfo)
+		return m.fetchedMsg(taskId, res, err)
+	}
+	cmds = append(cmds, fetchCmd)
+
+	return cmds
+}
+
+// startFetch starts the task tracking the fetch of the section's next page.
+func (m *Model) startFetch() (string, data.SearchRequest, []tea.Cmd) {
 	var cmds []tea.Cmd
 
 	startCursor := time.Now().String()
@@ -318,36 +336,41 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 	startCmd := m.Ctx.StartTask(task)
 	cmds = append(cmds, startCmd)
 
-	fetchCmd := func() tea.Msg {
-		limit := m.Config.Limit
-		if limit == nil {
-			limit = &m.Ctx.Config.Defaults.IssuesLimit
-		}
-		res, err := data.FetchIssues(m.GetFilters(), *limit, m.PageInfo)
-		if err != nil {
-			return constants.TaskFinishedMsg{
-				SectionId:   m.Id,
-				SectionType: m.Type,
-				TaskId:      taskId,
-				Err:         err,
-			}
-		}
+	limit := m.Config.Limit
+	if limit == nil {
+		limit = &m.Ctx.Config.Defaults.IssuesLimit
+	}
+	req := data.SearchRequest{
+		Host:     m.Config.Host,
+		Query:    m.GetFilters(),
+		Limit:    *limit,
+		PageInfo: m.PageInfo,
+	}
+
+	return taskId, req, cmds
+}
 
+func (m *Model) fetchedMsg(taskId string, res data.IssuesResponse, err error) tea.Msg {
+	if err != nil {
 		return constants.TaskFinishedMsg{
 			SectionId:   m.Id,
 			SectionType: m.Type,
 			TaskId:      taskId,
-			Msg: SectionIssuesFetchedMsg{
-				Issues:     res.Issues,
-				TotalCount: res.TotalCount,
-				PageInfo:   res.PageInfo,
-				TaskId:     taskId,
-			},
+			Err:         err,
 		}
 	}
-	cmds = append(cmds, fetchCmd)
 
-	return cmds
+	return constants.TaskFinishedMsg{
+		SectionId:   m.Id,
+		SectionType: m.Type,
+		TaskId:      taskId,
+		Msg: SectionIssuesFetchedMsg{
+			Issues:     res.Issues,
+			TotalCount: res.TotalCount,
+			PageInfo:   res.PageInfo,
+			TaskId:     taskId,
+		},
+	}
 }
 
 func (m *Model) UpdateLastUpdated(t time.Time) {
@@ -365,6 +388,9 @@ func FetchAllSections(
 	sectionConfigs := ctx.Config.IssuesSections
 	fetchIssuesCmds := make([]tea.Cmd, 0, len(sectionConfigs))
 	sections = make([]section.Section, 0, len(sectionConfigs))
+	sectionModels := make([]*Model, 0, len(sectionConfigs))
+	taskIds := make([]string, 0, len(sectionConfigs))
+	reqs := make([]data.SearchRequest, 0, len(sectionConfigs))
 	for i, sectionConfig := range sectionConfigs {
 		sectionModel := NewModel(
 			i+1,
@@ -377,10 +403,30 @@ func FetchAllSections(
 			sectionModel.ShowAuthorIcon = !*sectionConfig.Layout.CreatorIcon.Hidden
 		}
 		sections = append(sections, &sectionModel)
-		fetchIssuesCmds = append(
-			fetchIssuesCmds,
-			sectionModel.FetchNextPageSectionRows()...)
+
+		taskId, req, cmds := sectionModel.startFetch()
+		fetchIssuesCmds = append(fetchIssuesCmds, cmds...)
+		sectionModels = append(sectionModels, &sectionModel)
+		taskIds = append(taskIds, taskId)
+		reqs = append(reqs, req)
+	}
+
+	if len(reqs) == 0 {
+		return sections, tea.Batch(fetchIssuesCmds...)
 	}
+
+	// Fetch all sections together and fan the results back out to each
+	// section's own task.
+	fetchIssuesCmds = append(fetchIssuesCmds, func() tea.Msg {
+		results := data.FetchIssuesBatch(reqs)
+		msgs := make(tea.BatchMsg, 0, len(results))
+		for i, res := range results {
+			msg := sectionModels[i].fetchedMsg(taskIds[i], res.Response, res.Err)
+			msgs = append(msgs, func() tea.Msg { return msg })
+		}
+		return msgs
+	})
+
 	return sections, tea.Batch(fetchIssuesCmds...)
 }
 
@@ -428,7 +474,7 @@ func (m Model) GetItemPluralForm() string {
 }
 
 func (m Model) GetTotalCount() int {
-	return m.TotalCount
+	return m.TotalCount - m.hiddenCount()
 }
 
 func (m *Model) GetIsLoading() bool {
@@ -442,14 +488,14 @@ func (m *Model) SetIsLoading(val bool) {
 
 func (m Model) GetPagerContent() string {
 	pagerContent := ""
-	if m.TotalCount > 0 {
+	if totalCount := m.GetTotalCount(); totalCount > 0 {
 		pagerContent = fmt.Sprintf(
 			"%v %v • %v %v/%v • Fetched %v",
 			constants.WaitingIcon,
 			m.LastUpdated().Format("01/02 15:04:05"),
 			m.SingularForm,
 			m.Table.GetCurrItem()+1,
-			m.TotalCount,
+			totalCount,
 			len(m.Table.Rows),
 		)
 	}
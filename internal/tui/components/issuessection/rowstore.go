@@ -0,0 +1,73 @@
+package issuessection
+
+import (
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+func (m *Model) rowStoreKey() data.SectionKey {
+	return data.SectionKey{Type: m.Type, Id: m.Id}
+}
+
+// isDeduped returns true if the section hides issues already shown by an
+// earlier section. The search section never does.
+func (m *Model) isDeduped() bool {
+	return m.Id > 0 && m.Config.Dedupe != nil && *m.Config.Dedupe
+}
+
+// visibleIssues returns the issues displayed by the section.
+func (m *Model) visibleIssues() []data.IssueData {
+	if !m.isDeduped() {
+		return m.Issues
+	}
+
+	store := data.GetRowStore()
+	key := m.rowStoreKey()
+	issues := make([]data.IssueData, 0, len(m.Issues))
+	for _, issue := range m.Issues {
+		if !store.IsInEarlierSection(key, issue.Url) {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// hiddenCount returns the number of fetched issues hidden because an earlier
+// section shows them.
+func (m *Model) hiddenCount() int {
+	return len(m.Issues) - len(m.visibleIssues())
+}
+
+// seenElsewhere returns the titles of the other sections showing the issue.
+func (m *Model) seenElsewhere(url string) []string {
+	if m.Id == 0 || !m.Ctx.Config.Theme.Ui.Table.ShowSeenElsewhere {
+		return nil
+	}
+
+	var titles []string
+	for _, id := range data.GetRowStore().OtherSections(m.rowStoreKey(), url) {
+		if id > 0 && id <= len(m.Ctx.Config.IssuesSections) {
+			titles = append(titles, m.Ctx.Config.IssuesSections[id-1].Title)
+		}
+	}
+	return titles
+}
+
+// SyncRowStore records the issues displayed by the section in the shared row
+// store. Sections must be synced in order, as deduped sections depend on the
+// rows of the sections before them.
+func (m *Model) SyncRowStore() {
+	if m.Id == 0 {
+		return
+	}
+
+	issues := m.visibleIssues()
+	urls := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		urls = append(urls, issue.Url)
+	}
+	data.GetRowStore().SetSectionRows(m.rowStoreKey(), urls)
+}
+
+func (m *Model) RebuildRows() {
+	m.Table.SetRows(m.BuildRows())
+}
@@ -3,12 +3,11 @@ package issueview
 import (
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
-	"strings"
 
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
@@ -20,8 +19,8 @@ func (m *Model) Checkout() (tea.Cmd, error) {
 	}
 
 	issue := m.issue.Data
-	repoName := issue.GetRepoNameWithOwner()
-	repoPath, ok := common.GetRepoLocalPath(repoName, m.ctx.Config.RepoPaths)
+	repoName := data.RepoArg(issue)
+	repoPath, ok := common.ResolveRepoLocalPath(repoName, m.ctx.Config.RepoPaths)
 	if !ok {
 		return nil, errors.New(
 			"local path to repo not specified, set one in your config.yml under repoPaths",
@@ -29,6 +28,13 @@ func (m *Model) Checkout() (tea.Cmd, error) {
 	}
 
 	issueNumber := issue.GetNumber()
+	repoPath = common.ExpandHomeDir(repoPath)
+	worktrees := m.ctx.Config.Worktrees
+	checkoutPath := repoPath
+	if worktrees.Enabled {
+		checkoutPath = common.GetWorktreePath(worktrees.Dir, repoName, repoPath, issueNumber)
+	}
+
 	taskId := fmt.Sprintf("issue_checkout_%d", issueNumber)
 	task := context.Task{
 		Id:        taskId,
@@ -36,13 +42,18 @@ func (m *Model) Checkout() (tea.Cmd, error) {
 		FinishedText: fmt.Sprintf(
 			"Branch for issue #%d has been checked out at %s",
 			issueNumber,
-			repoPath,
+			checkoutPath,
 		),
 		State: context.TaskStart,
 		Error: nil,
 	}
 	startCmd := m.ctx.StartTask(task)
 	return tea.Batch(startCmd, func() tea.Msg {
+		if worktrees.Enabled {
+			err := common.CheckoutIssueWorktree(repoName, repoPath, checkoutPath, issueNumber)
+			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
+		}
+
 		c := exec.Command(
 			"gh",
 			"issue",
@@ -52,11 +63,6 @@ func (m *Model) Checkout() (tea.Cmd, error) {
 			repoName,
 			"--checkout",
 		)
-		userHomeDir, _ := os.UserHomeDir()
-		if strings.HasPrefix(repoPath, "~") {
-			repoPath = strings.Replace(repoPath, "~", userHomeDir, 1)
-		}
-
 		c.Dir = repoPath
 		err := c.Run()
 		return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
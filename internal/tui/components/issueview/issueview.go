@@ -165,8 +165,11 @@ func (m *Model) InputBoxLineFromButton() int {
 }
 
 func (m *Model) renderFullNameAndNumber() string {
-	return common.RenderPreviewHeader(m.ctx.Theme, m.width,
-		fmt.Sprintf("#%d · %s", m.issue.Data.GetNumber(), m.issue.Data.GetRepoNameWithOwner()))
+	header := fmt.Sprintf("#%d · %s", m.issue.Data.GetNumber(), m.issue.Data.GetRepoNameWithOwner())
+	if host := data.RowHost(m.issue.Data); host != "" {
+		header = fmt.Sprintf("%s · %s %s", header, constants.HostIcon, host)
+	}
+	return common.RenderPreviewHeader(m.ctx.Theme, m.width, header)
 }
 
 func (m *Model) renderTitle() string {
@@ -22,6 +22,7 @@ type Data struct {
 	SubjectState        string // State of the PR/Issue (OPEN, CLOSED, MERGED)
 	IsDraft             bool   // Whether PR is a draft
 	Actor               string // Username of the user who triggered the notification
+	Author              string // Username of the PR/Issue author
 	ActivityDescription string // Human-readable description of the activity (e.g., "@user commented on this PR")
 	ResolvedUrl         string // Async-resolved URL (e.g., for CheckSuite -> specific workflow run)
 }
@@ -102,6 +103,10 @@ func (d Data) GetId() string {
 	return d.Notification.Id
 }
 
+func (d Data) StoreKey() string {
+	return d.Notification.StoreKey()
+}
+
 func (d Data) GetSubjectType() string {
 	return d.Notification.Subject.Type
 }
@@ -131,7 +131,7 @@ func (n *Notification) renderType() string {
 }
 
 // renderTitleBlock returns a 3-line block:
-// Line 1: repo/name #number [bookmark icon if bookmarked]
+// Line 1: repo/name #number [bookmark icon if bookmarked] [snooze time if snoozed]
 // Line 2: Title (bold for unread)
 // Line 3: Activity description
 // Note: Truncation is handled dynamically by the table component based on actual column width
@@ -148,12 +148,21 @@ func (n *Notification) renderTitleBlock() string {
 		line1 = fmt.Sprintf("%s #%d", repo, number)
 	}
 	// Add bookmark icon if bookmarked (using raw ANSI for warning color)
-	if data.GetBookmarkStore().IsBookmarked(n.Data.GetId()) {
+	if data.GetBookmarkStore().IsBookmarked(n.Data.StoreKey()) {
 		bookmarkPrefix := utils.GetStylePrefix(
 			lipgloss.NewStyle().Foreground(n.Ctx.Theme.WarningText),
 		)
 		line1 = line1 + " " + bookmarkPrefix + ""
 	}
+	// Add snooze icon and time if snoozed (only visible under is:snoozed)
+	if until, ok := data.GetSnoozeStore().SnoozedUntil(
+		n.Data.StoreKey(), n.Data.GetUpdatedAt()); ok {
+		snoozePrefix := utils.GetStylePrefix(
+			lipgloss.NewStyle().Foreground(n.Ctx.Theme.FaintText),
+		)
+		line1 = line1 + " " + snoozePrefix + constants.SnoozeIcon + " " +
+			until.Local().Format("Mon Jan 2 15:04")
+	}
 	line1Rendered := repoPrefix + line1
 
 	// Line 2: Title (bold for unread)
@@ -190,9 +199,12 @@ func (n *Notification) renderTitleBlock() string {
 
 // getReasonDescription returns a fallback description based on notification reason
 func (n *Notification) getReasonDescription() string {
-	reason := n.Data.GetReason()
-	subjectType := n.Data.GetSubjectType()
+	return ReasonDescription(n.Data.GetReason(), n.Data.GetSubjectType())
+}
 
+// ReasonDescription describes a notification reason, e.g. "Review requested".
+// It's empty for reasons without a description.
+func ReasonDescription(reason, subjectType string) string {
 	switch reason {
 	case "review_requested":
 		return "Review requested"
@@ -4,9 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"os/exec"
-	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -29,6 +27,8 @@ func (m *Model) markAsDone() tea.Cmd {
 	}
 
 	notificationId := notification.GetId()
+	host := data.RowHost(notification.Notification)
+	storeKey := notification.StoreKey()
 	updatedAt := notification.Notification.UpdatedAt
 	taskId := fmt.Sprintf("notification_done_%s", notificationId)
 	task := context.Task{
@@ -40,10 +40,10 @@ func (m *Model) markAsDone() tea.Cmd {
 	}
 	startCmd := m.Ctx.StartTask(task)
 	return tea.Batch(startCmd, func() tea.Msg {
-		err := markNotificationDoneFunc(notificationId)
+		err := markNotificationDoneFunc(host, notificationId)
 		if err == nil {
 			// Persist to done store so it stays hidden across sessions
-			data.GetDoneStore().MarkDone(notificationId, updatedAt)
+			data.GetDoneStore().MarkDone(storeKey, updatedAt)
 		}
 		return constants.TaskFinishedMsg{
 			SectionId:   m.Id,
@@ -77,12 +77,16 @@ func (m *Model) markAllAsDone() tea.Cmd {
 	}
 
 	type doneEntry struct {
+		host      string
 		id        string
+		storeKey  string
 		updatedAt time.Time
 	}
 	entries := make([]doneEntry, 0, count)
 	for _, n := range m.Notifications {
-		entries = append(entries, doneEntry{n.GetId(), n.Notification.UpdatedAt})
+		entries = append(entries, doneEntry{
+			data.RowHost(n.Notification), n.GetId(), n.StoreKey(), n.Notification.UpdatedAt,
+		})
 	}
 
 	startCmd := m.Ctx.StartTask(task)
@@ -91,11 +95,11 @@ func (m *Model) markAllAsDone() tea.Cmd {
 		doneStore := data.GetDoneStore()
 		var lastErr error
 		for _, e := range entries {
-			if err := data.MarkNotificationDone(e.id); err != nil {
+			if err := data.MarkNotificationDone(e.host, e.id); err != nil {
 				lastErr = err
 			} else {
 				// Persist to done store so it stays hidden across sessions
-				doneStore.MarkDone(e.id, e.updatedAt)
+				doneStore.MarkDone(e.storeKey, e.updatedAt)
 			}
 		}
 
@@ -129,8 +133,9 @@ func (m *Model) markAllAsRead() tea.Cmd {
 		Error:        nil,
 	}
 	startCmd := m.Ctx.StartTask(task)
+	host := m.Config.Host
 	return tea.Batch(startCmd, func() tea.Msg {
-		err := data.MarkAllNotificationsRead()
+		err := data.MarkAllNotificationsRead(host)
 		if err != nil {
 			return constants.TaskFinishedMsg{
 				SectionId:   m.Id,
@@ -169,6 +174,7 @@ func (m *Model) markAsRead() tea.Cmd {
 	}
 
 	notificationId := notification.GetId()
+	host := data.RowHost(notification.Notification)
 	taskId := fmt.Sprintf("notification_read_%s", notificationId)
 	task := context.Task{
 		Id:           taskId,
@@ -179,7 +185,7 @@ func (m *Model) markAsRead() tea.Cmd {
 	}
 	startCmd := m.Ctx.StartTask(task)
 	return tea.Batch(startCmd, func() tea.Msg {
-		err := data.MarkNotificationRead(notificationId)
+		err := data.MarkNotificationRead(host, notificationId)
 		return constants.TaskFinishedMsg{
 			SectionId:   m.Id,
 			SectionType: SectionType,
@@ -200,6 +206,7 @@ func (m *Model) unsubscribe() tea.Cmd {
 	}
 
 	notificationId := notification.GetId()
+	host := data.RowHost(notification.Notification)
 	taskId := fmt.Sprintf("notification_unsubscribe_%s", notificationId)
 	task := context.Task{
 		Id:           taskId,
@@ -210,7 +217,7 @@ func (m *Model) unsubscribe() tea.Cmd {
 	}
 	startCmd := m.Ctx.StartTask(task)
 	return tea.Batch(startCmd, func() tea.Msg {
-		err := data.UnsubscribeFromThread(notificationId)
+		err := data.UnsubscribeFromThread(host, notificationId)
 		return constants.TaskFinishedMsg{
 			SectionId:   m.Id,
 			SectionType: SectionType,
@@ -242,11 +249,12 @@ func (m *Model) openInBrowser() tea.Cmd {
 	}
 
 	notificationId := notification.GetId()
+	host := data.RowHost(notification.Notification)
 	notificationUrl := notification.GetUrl()
 
 	return tea.Batch(
 		func() tea.Msg {
-			_ = data.MarkNotificationRead(notificationId)
+			_ = data.MarkNotificationRead(host, notificationId)
 			return UpdateNotificationReadStateMsg{
 				Id:     notificationId,
 				Unread: false,
@@ -269,34 +277,41 @@ func (m *Model) openInBrowser() tea.Cmd {
 // CheckoutPR checks out a PR. This is a standalone function that can be called
 // from ui.go with the PR details from the notification view.
 func CheckoutPR(ctx *context.ProgramContext, prNumber int, repoName string) (tea.Cmd, error) {
-	repoPath, ok := common.GetRepoLocalPath(repoName, ctx.Config.RepoPaths)
+	repoPath, ok := common.ResolveRepoLocalPath(repoName, ctx.Config.RepoPaths)
 	if !ok {
 		return nil, errors.New(
 			"local path to repo not specified, set one in your config.yml under repoPaths",
 		)
 	}
 
+	repoPath = common.ExpandHomeDir(repoPath)
+	worktrees := ctx.Config.Worktrees
+	checkoutPath := repoPath
+	if worktrees.Enabled {
+		checkoutPath = common.GetWorktreePath(worktrees.Dir, repoName, repoPath, prNumber)
+	}
+
 	taskId := fmt.Sprintf("checkout_%d", prNumber)
 	task := context.Task{
 		Id:           taskId,
 		StartText:    fmt.Sprintf("Checking out PR #%d", prNumber),
-		FinishedText: fmt.Sprintf("PR #%d has been checked out at %s", prNumber, repoPath),
+		FinishedText: fmt.Sprintf("PR #%d has been checked out at %s", prNumber, checkoutPath),
 		State:        context.TaskStart,
 		Error:        nil,
 	}
 	startCmd := ctx.StartTask(task)
 	return tea.Batch(startCmd, func() tea.Msg {
+		if worktrees.Enabled {
+			err := common.CheckoutPRWorktree(repoPath, checkoutPath, prNumber)
+			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
+		}
+
 		c := exec.Command(
 			"gh",
 			"pr",
 			"checkout",
 			fmt.Sprint(prNumber),
 		)
-		userHomeDir, _ := os.UserHomeDir()
-		if strings.HasPrefix(repoPath, "~") {
-			repoPath = strings.Replace(repoPath, "~", userHomeDir, 1)
-		}
-
 		c.Dir = repoPath
 		err := c.Run()
 		return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
@@ -128,7 +128,7 @@ func TestCheckoutPRErrorMessage(t *testing.T) {
 func TestMarkAsDoneStoresCorrectTimestamp(t *testing.T) {
 	// Mock the API call to succeed without network access.
 	origFunc := markNotificationDoneFunc
-	markNotificationDoneFunc = func(string) error { return nil }
+	markNotificationDoneFunc = func(string, string) error { return nil }
 	defer func() { markNotificationDoneFunc = origFunc }()
 
 	// Set up a DoneStore backed by a temp file so we don't touch real state.
@@ -253,3 +253,42 @@ func TestUpdateNotificationKeepsCursorOnNewLastItem(t *testing.T) {
 		t.Fatalf("GetCurrNotification().GetId() = %q, want %q", got, "notif-B")
 	}
 }
+
+func TestNotModifiedKeepsRows(t *testing.T) {
+	cfg, err := config.ParseConfig(config.Location{
+		ConfigFlag:       "../../../config/testdata/test-config.yml",
+		SkipGlobalConfig: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	ctx := &context.ProgramContext{
+		Config: &cfg,
+	}
+	ctx.Theme = theme.ParseTheme(ctx.Config)
+	ctx.Styles = context.InitStyles(ctx.Theme)
+
+	m := NewModel(0, ctx, config.NotificationsSectionConfig{}, time.Now())
+	m.LastFetchTaskId = "first"
+	m.Update(SectionNotificationsFetchedMsg{
+		TaskId: "first",
+		Notifications: []notificationrow.Data{
+			{Notification: data.NotificationData{Id: "notif-A"}},
+			{Notification: data.NotificationData{Id: "notif-B"}},
+		},
+		PageInfo: data.PageInfo{HasNextPage: true, EndCursor: "2"},
+	})
+
+	// A background refresh resets the page info and finds nothing changed
+	m.ResetPageInfo()
+	m.LastFetchTaskId = "refresh"
+	m.Update(SectionNotificationsFetchedMsg{TaskId: "refresh", NotModified: true})
+
+	if len(m.Notifications) != 2 {
+		t.Errorf("got %d notifications, want the 2 fetched before", len(m.Notifications))
+	}
+	if m.PageInfo == nil || m.PageInfo.EndCursor != "2" {
+		t.Errorf("PageInfo = %+v, want the page info of the kept rows", m.PageInfo)
+	}
+}
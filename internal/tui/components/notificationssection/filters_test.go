@@ -211,6 +211,11 @@ func TestParseReasonFilters(t *testing.T) {
 			search:   "reason:subscribed reason:comment",
 			expected: []string{data.ReasonSubscribed, data.ReasonComment},
 		},
+		{
+			name:     "comma separated reasons",
+			search:   "reason:review-requested,mention,",
+			expected: []string{data.ReasonReviewRequested, data.ReasonMention},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,219 @@
+package notificationssection
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/notificationrow"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/table"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/utils"
+)
+
+// groupRowKind is the kind of a row in the grouped layout
+type groupRowKind int
+
+const (
+	repoGroupRow    groupRowKind = iota // Repository header
+	subjectGroupRow                     // All notifications about one subject
+	threadGroupRow                      // One notification under an expanded subject
+)
+
+// groupRow is a row of the grouped layout. Notifications are nested under
+// their repository, and notifications about the same PR, issue or other
+// subject are merged into a single row that can be expanded.
+type groupRow struct {
+	kind          groupRowKind
+	key           string // Repository name for headers, subject key otherwise
+	notifications []int  // Indices into Model.Notifications
+}
+
+// subjectKey identifies the subject of a notification. Notifications without
+// a subject URL, e.g. CheckSuites, are never merged.
+func subjectKey(n *notificationrow.Data) string {
+	if url := n.Notification.Subject.Url; url != "" {
+		return url
+	}
+	return n.GetId()
+}
+
+// buildGroupRows lays out the notifications for the grouped layout.
+// Repositories and subjects keep the order of their first notification, so
+// the layout follows the section's sort order.
+func (m *Model) buildGroupRows() []groupRow {
+	var repos []string
+	subjectsByRepo := make(map[string][]string)
+	notificationsBySubject := make(map[string][]int)
+	for i := range m.Notifications {
+		n := &m.Notifications[i]
+		repo := n.GetRepoNameWithOwner()
+		subject := subjectKey(n)
+		if _, ok := subjectsByRepo[repo]; !ok {
+			repos = append(repos, repo)
+		}
+		if _, ok := notificationsBySubject[subject]; !ok {
+			subjectsByRepo[repo] = append(subjectsByRepo[repo], subject)
+		}
+		notificationsBySubject[subject] = append(notificationsBySubject[subject], i)
+	}
+
+	rows := make([]groupRow, 0, len(repos)+len(m.Notifications))
+	for _, repo := range repos {
+		var indices []int
+		for _, subject := range subjectsByRepo[repo] {
+			indices = append(indices, notificationsBySubject[subject]...)
+		}
+		rows = append(rows, groupRow{kind: repoGroupRow, key: repo, notifications: indices})
+		if m.collapsedRepos[repo] {
+			continue
+		}
+
+		for _, subject := range subjectsByRepo[repo] {
+			indices := notificationsBySubject[subject]
+			rows = append(rows, groupRow{kind: subjectGroupRow, key: subject, notifications: indices})
+			if len(indices) < 2 || !m.expandedSubjects[subject] {
+				continue
+			}
+			for _, i := range indices {
+				rows = append(rows, groupRow{kind: threadGroupRow, key: subject, notifications: []int{i}})
+			}
+		}
+	}
+	return rows
+}
+
+// buildGroupedTableRows renders the grouped layout, keeping its rows so the
+// selected row can be mapped back to its notifications.
+func (m *Model) buildGroupedTableRows() []table.Row {
+	m.groupRows = m.buildGroupRows()
+	rows := make([]table.Row, 0, len(m.groupRows))
+	for _, row := range m.groupRows {
+		switch row.kind {
+		case repoGroupRow:
+			rows = append(rows, m.renderRepoGroupRow(row))
+		case subjectGroupRow:
+			rows = append(rows, m.renderSubjectGroupRow(row))
+		case threadGroupRow:
+			notificationModel := notificationrow.Notification{
+				Ctx:  m.Ctx,
+				Data: &m.Notifications[row.notifications[0]],
+			}
+			rows = append(rows, notificationModel.ToTableRow())
+		}
+	}
+	return rows
+}
+
+// renderRepoGroupRow renders a repository header with its unread count.
+// Uses raw ANSI codes without resets to preserve the row's background.
+func (m *Model) renderRepoGroupRow(row groupRow) table.Row {
+	icon := constants.ExpandedIcon
+	if m.collapsedRepos[row.key] {
+		icon = constants.CollapsedIcon
+	}
+	unread := 0
+	for _, i := range row.notifications {
+		if m.Notifications[i].IsUnread() {
+			unread++
+		}
+	}
+
+	faintPrefix := utils.GetStylePrefix(lipgloss.NewStyle().Foreground(m.Ctx.Theme.FaintText))
+	repoPrefix := utils.GetStylePrefix(
+		lipgloss.NewStyle().Foreground(m.Ctx.Theme.PrimaryText).Bold(true),
+	)
+	title := repoPrefix + row.key + faintPrefix +
+		fmt.Sprintf("  %d unread · %d total", unread, len(row.notifications))
+
+	return table.Row{faintPrefix + icon, title, "", ""}
+}
+
+// renderSubjectGroupRow renders the most recent notification of a subject.
+// When several notifications were merged, it's shown as unread if any of
+// them is, and the activity line lists the reasons of all of them.
+func (m *Model) renderSubjectGroupRow(row groupRow) table.Row {
+	latest := m.Notifications[row.notifications[0]]
+	if len(row.notifications) > 1 {
+		var reasons []string
+		for _, i := range row.notifications {
+			n := m.Notifications[i]
+			if n.IsUnread() {
+				latest.Notification.Unread = true
+			}
+			reason := strings.ReplaceAll(n.GetReason(), "_", " ")
+			if !slices.Contains(reasons, reason) {
+				reasons = append(reasons, reason)
+			}
+		}
+		icon := constants.CollapsedIcon
+		if m.expandedSubjects[row.key] {
+			icon = constants.ExpandedIcon
+		}
+		latest.ActivityDescription = fmt.Sprintf("%s %d notifications: %s",
+			icon, len(row.notifications), strings.Join(reasons, ", "))
+	}
+
+	notificationModel := notificationrow.Notification{Ctx: m.Ctx, Data: &latest}
+	return notificationModel.ToTableRow()
+}
+
+// currGroupRow returns the selected row of the grouped layout
+func (m *Model) currGroupRow() *groupRow {
+	idx := m.Table.GetCurrItem()
+	if idx < 0 || idx >= len(m.groupRows) {
+		return nil
+	}
+	row := &m.groupRows[idx]
+	for _, i := range row.notifications {
+		if i >= len(m.Notifications) {
+			// The notifications changed since the rows were built
+			return nil
+		}
+	}
+	return row
+}
+
+// toggleGrouping switches between the flat and the grouped layout
+func (m *Model) toggleGrouping() {
+	m.Grouped = !m.Grouped
+	m.Table.ResetCurrItem()
+	m.Table.SetRows(m.BuildRows())
+}
+
+// toggleCurrGroup expands or collapses the group of the selected row: the
+// repository of a header, the subject of a merged row or of one of its
+// notifications, and otherwise the repository the notification is in.
+func (m *Model) toggleCurrGroup() {
+	row := m.currGroupRow()
+	if row == nil {
+		return
+	}
+
+	idx := m.Table.GetCurrItem()
+	switch {
+	case row.kind == repoGroupRow:
+		m.collapsedRepos[row.key] = !m.collapsedRepos[row.key]
+	case row.kind == subjectGroupRow && len(row.notifications) > 1:
+		m.expandedSubjects[row.key] = !m.expandedSubjects[row.key]
+	case row.kind == threadGroupRow:
+		m.expandedSubjects[row.key] = false
+		m.selectGroupRow(idx, subjectGroupRow)
+	default:
+		repo := m.Notifications[row.notifications[0]].GetRepoNameWithOwner()
+		m.collapsedRepos[repo] = true
+		m.selectGroupRow(idx, repoGroupRow)
+	}
+	m.Table.SetRows(m.BuildRows())
+}
+
+// selectGroupRow moves the selection up from idx to the closest row of the
+// given kind, i.e. the subject or repository the row belongs to.
+func (m *Model) selectGroupRow(idx int, kind groupRowKind) {
+	for idx > 0 && m.groupRows[idx].kind != kind {
+		idx--
+		m.PrevRow()
+	}
+}
@@ -0,0 +1,151 @@
+package notificationssection
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/notificationrow"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/theme"
+)
+
+func newGroupedModel(t *testing.T) *Model {
+	t.Helper()
+	cfg, err := config.ParseConfig(config.Location{
+		ConfigFlag:       "../../../config/testdata/test-config.yml",
+		SkipGlobalConfig: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	ctx := &context.ProgramContext{Config: &cfg}
+	ctx.Theme = theme.ParseTheme(ctx.Config)
+	ctx.Styles = context.InitStyles(ctx.Theme)
+
+	withUrl := func(n notificationrow.Data, url string) notificationrow.Data {
+		n.Notification.Subject.Url = url
+		return n
+	}
+	read := newRuleNotification("3", "comment", "Issue", "o/a", "Bug")
+	read.Notification.Unread = false
+
+	m := NewModel(0, ctx, config.NotificationsSectionConfig{Grouped: true}, time.Now())
+	m.Notifications = []notificationrow.Data{
+		withUrl(newRuleNotification("1", "mention", "Issue", "o/a", "Bug"),
+			"https://api.github.com/repos/o/a/issues/1"),
+		withUrl(newRuleNotification("2", "review_requested", "PullRequest", "o/b", "Feature"),
+			"https://api.github.com/repos/o/b/pulls/2"),
+		withUrl(read, "https://api.github.com/repos/o/a/issues/1"),
+		newRuleNotification("4", "ci_activity", "CheckSuite", "o/a", "CI failed"),
+	}
+	m.TotalCount = len(m.Notifications)
+	m.Table.SetRows(m.BuildRows())
+	return &m
+}
+
+// groupRowIds describes the grouped layout as one string per row: repository
+// headers by name and other rows by their notification ids.
+func groupRowIds(m *Model) []string {
+	var rows []string
+	for _, row := range m.groupRows {
+		switch row.kind {
+		case repoGroupRow:
+			rows = append(rows, row.key)
+		case subjectGroupRow, threadGroupRow:
+			ids := make([]string, 0, len(row.notifications))
+			for _, i := range row.notifications {
+				ids = append(ids, m.Notifications[i].GetId())
+			}
+			prefix := ""
+			if row.kind == threadGroupRow {
+				prefix = "  "
+			}
+			rows = append(rows, prefix+strings.Join(ids, ","))
+		}
+	}
+	return rows
+}
+
+func requireGroupRows(t *testing.T, m *Model, want ...string) {
+	t.Helper()
+	got := groupRowIds(m)
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("grouped rows = %q, want %q", got, want)
+	}
+	if len(m.Table.Rows) != len(want) {
+		t.Fatalf("table has %d rows, want %d", len(m.Table.Rows), len(want))
+	}
+}
+
+func TestBuildGroupRows(t *testing.T) {
+	m := newGroupedModel(t)
+	requireGroupRows(t, m, "o/a", "1,3", "4", "o/b", "2")
+
+	header := m.Table.Rows[0][1]
+	if !strings.Contains(header, "2 unread · 3 total") {
+		t.Errorf("repository header = %q, want the unread count", header)
+	}
+	merged := m.Table.Rows[1][1]
+	if !strings.Contains(merged, "2 notifications: mention, comment") {
+		t.Errorf("merged row = %q, want the reasons of both notifications", merged)
+	}
+
+	m.Grouped = false
+	m.Table.SetRows(m.BuildRows())
+	if len(m.Table.Rows) != len(m.Notifications) || m.NumRows() != len(m.Notifications) {
+		t.Errorf("the flat layout should have a row per notification")
+	}
+}
+
+func TestToggleCurrGroup(t *testing.T) {
+	m := newGroupedModel(t)
+
+	// Repository headers have no notification
+	if m.GetCurrNotification() != nil || m.GetCurrRow() != nil {
+		t.Error("repository headers shouldn't select a notification")
+	}
+
+	// Expanding a merged subject lists its notifications
+	m.NextRow()
+	if got := m.GetCurrNotification(); got == nil || got.GetId() != "1" {
+		t.Fatalf("merged row should select its most recent notification, got %v", got)
+	}
+	m.toggleCurrGroup()
+	requireGroupRows(t, m, "o/a", "1,3", "  1", "  3", "4", "o/b", "2")
+
+	// Collapsing from one of them selects the merged row again
+	m.NextRow()
+	m.NextRow()
+	if got := m.GetCurrNotification(); got == nil || got.GetId() != "3" {
+		t.Fatalf("expanded rows should select their notification, got %v", got)
+	}
+	m.toggleCurrGroup()
+	requireGroupRows(t, m, "o/a", "1,3", "4", "o/b", "2")
+	if m.CurrRow() != 1 {
+		t.Errorf("CurrRow() = %d, want the merged row", m.CurrRow())
+	}
+
+	// Collapsing from a single notification collapses its repository
+	m.NextRow()
+	m.toggleCurrGroup()
+	requireGroupRows(t, m, "o/a", "o/b", "2")
+	if m.CurrRow() != 0 {
+		t.Errorf("CurrRow() = %d, want the repository header", m.CurrRow())
+	}
+
+	m.toggleCurrGroup()
+	requireGroupRows(t, m, "o/a", "1,3", "4", "o/b", "2")
+}
+
+func TestGroupedRemoveNotification(t *testing.T) {
+	m := newGroupedModel(t)
+	m.LastItem()
+
+	m.Update(UpdateNotificationMsg{Id: "2", IsRemoved: true})
+	requireGroupRows(t, m, "o/a", "1,3", "4")
+	if got := m.GetCurrNotification(); got == nil || got.GetId() != "4" {
+		t.Errorf("removing the last row should select the new last notification, got %v", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,8 +29,9 @@ const SectionType = "notification"
 // repoFilterRegex matches "repo:owner/name" patterns in search strings
 var repoFilterRegex = regexp.MustCompile(`repo:([^\s]+)`)
 
-// stateFilterRegex matches "is:unread", "is:read", "is:done", "is:all" patterns
-var stateFilterRegex = regexp.MustCompile(`is:(unread|read|done|all)`)
+// stateFilterRegex matches "is:unread", "is:read", "is:done", "is:snoozed", "is:bookmarked",
+// "is:all" patterns
+var stateFilterRegex = regexp.MustCompile(`is:(unread|read|done|snoozed|bookmarked|all)`)
 
 // reasonFilterRegex matches "reason:value" patterns in search strings
 var reasonFilterRegex = regexp.MustCompile(`reason:([^\s]+)`)
@@ -51,21 +53,31 @@ type NotificationFilters struct {
 	RepoFilters       []string
 	ReasonFilters     []string // Notification reasons to filter by (e.g., "author", "mention")
 	ReadState         data.NotificationReadState
-	IsDone            bool // If true, user asked for is:done which is not retrievable
-	ExplicitUnread    bool // If true, user explicitly typed "is:unread" (excludes bookmarked+read)
-	IncludeBookmarked bool // If true, include bookmarked items even if read (default view)
+	IsDone            bool        // If true, user asked for is:done which is not retrievable
+	IsSnoozed         bool        // If true, only show snoozed notifications
+	IsBookmarked      bool        // If true, only show bookmarked notifications
+	ExplicitUnread    bool        // If true, user explicitly typed "is:unread" (excludes bookmarked+read)
+	IncludeBookmarked bool        // If true, include bookmarked items even if read (default view)
+	SubjectTypes      []string    // Subject types to filter by (e.g., "PullRequest", "Release")
+	OrgFilters        []string    // Repository owners to filter by
+	AuthorFilters     []string    // Logins of who triggered the notification
+	UpdatedRanges     []dateRange // Ranges the notification's update time must be in
+	TitleTerms        []string    // Lowercased words and phrases the title must contain
+	SortOrder         *SortOrder  // Sort order given by sort:, if any
 }
 
 // parseReasonFilters extracts reason:value patterns from a search string
-// Handles "reason:participating" as a meta-filter and normalizes hyphenated names
+// Handles "reason:participating" as a meta-filter, comma separated lists like
+// "reason:review-requested,mention" and normalizes hyphenated names
 func parseReasonFilters(search string) []string {
-	matches := reasonFilterRegex.FindAllStringSubmatch(search, -1)
-	reasons := make([]string, 0, len(matches))
-	for _, match := range matches {
-		if len(match) > 1 {
-			reason := match[1]
-			// Expand "participating" meta-filter to multiple reasons
-			if reason == "participating" {
+	var reasons []string
+	for _, match := range parseSubmatches(reasonFilterRegex, search) {
+		for reason := range strings.SplitSeq(match, ",") {
+			switch reason {
+			case "":
+				continue
+			case "participating":
+				// Expand "participating" meta-filter to multiple reasons
 				reasons = append(reasons,
 					data.ReasonAuthor,
 					data.ReasonComment,
@@ -74,22 +86,19 @@ func parseReasonFilters(search string) []string {
 					data.ReasonAssign,
 					data.ReasonStateChange,
 				)
-			} else {
+			case "review-requested":
 				// Normalize hyphenated names to match GitHub API values
-				switch reason {
-				case "review-requested":
-					reasons = append(reasons, data.ReasonReviewRequested)
-				case "team-mention":
-					reasons = append(reasons, data.ReasonTeamMention)
-				case "ci-activity":
-					reasons = append(reasons, data.ReasonCIActivity)
-				case "security-alert":
-					reasons = append(reasons, data.ReasonSecurityAlert)
-				case "state-change":
-					reasons = append(reasons, data.ReasonStateChange)
-				default:
-					reasons = append(reasons, reason)
-				}
+				reasons = append(reasons, data.ReasonReviewRequested)
+			case "team-mention":
+				reasons = append(reasons, data.ReasonTeamMention)
+			case "ci-activity":
+				reasons = append(reasons, data.ReasonCIActivity)
+			case "security-alert":
+				reasons = append(reasons, data.ReasonSecurityAlert)
+			case "state-change":
+				reasons = append(reasons, data.ReasonStateChange)
+			default:
+				reasons = append(reasons, reason)
 			}
 		}
 	}
@@ -109,14 +118,24 @@ func parseNotificationFilters(search string, includeRead bool) NotificationFilte
 		ReasonFilters:     parseReasonFilters(search),
 		ReadState:         defaultReadState,
 		IsDone:            false,
+		IsSnoozed:         false,
+		IsBookmarked:      false,
 		ExplicitUnread:    false,
 		IncludeBookmarked: !includeRead, // Only auto-include bookmarks when filtering to unread
+		SubjectTypes:      parseTypeFilters(search),
+		OrgFilters:        parseSubmatches(orgFilterRegex, search),
+		AuthorFilters:     parseSubmatches(authorFilterRegex, search),
+		UpdatedRanges:     parseUpdatedFilters(search),
+		TitleTerms:        parseTitleTerms(search),
+		SortOrder:         parseSortFilter(search),
 	}
 
 	matches := stateFilterRegex.FindAllStringSubmatch(search, -1)
 	hasUnread := false
 	hasRead := false
 	hasDone := false
+	hasSnoozed := false
+	hasBookmarked := false
 	hasAll := false
 
 	for _, match := range matches {
@@ -128,6 +147,10 @@ func parseNotificationFilters(search string, includeRead bool) NotificationFilte
 				hasRead = true
 			case "done":
 				hasDone = true
+			case "snoozed":
+				hasSnoozed = true
+			case "bookmarked":
+				hasBookmarked = true
 			case "all":
 				hasAll = true
 			}
@@ -138,6 +161,20 @@ func parseNotificationFilters(search string, includeRead bool) NotificationFilte
 		filters.IsDone = true
 	}
 
+	if hasSnoozed {
+		// Snoozed notifications are shown whether they were read or not
+		filters.IsSnoozed = true
+		filters.ReadState = data.NotificationStateAll
+		filters.IncludeBookmarked = false
+	}
+
+	if hasBookmarked {
+		// Bookmarked notifications are shown whether they were read or not
+		filters.IsBookmarked = true
+		filters.ReadState = data.NotificationStateAll
+		filters.IncludeBookmarked = false
+	}
+
 	if hasAll || (hasUnread && hasRead) {
 		filters.ReadState = data.NotificationStateAll
 		filters.IncludeBookmarked = false // Explicit filter, don't auto-include bookmarks
@@ -167,8 +204,15 @@ type Model struct {
 	Notifications     []notificationrow.Data
 	SortOrder         SortOrder
 	lastSidebarOpen   bool
-	sessionMarkedRead map[string]bool // IDs of notifications marked as read this session (kept visible until manual refresh)
-	sessionMarkedDone map[string]bool // IDs of notifications marked as done this session (excluded until manual refresh)
+	sessionMarkedRead map[string]bool    // IDs of notifications marked as read this session (kept visible until manual refresh)
+	sessionMarkedDone map[string]bool    // IDs of notifications marked as done this session (excluded until manual refresh)
+	fetchedPageInfo   *data.PageInfo     // Page info of the rows, kept when a refresh finds nothing changed
+	Grouped           bool               // If true, nest notifications under repositories and merge them by subject
+	collapsedRepos    map[string]bool    // Repositories collapsed in the grouped layout
+	expandedSubjects  map[string]bool    // Merged subjects expanded in the grouped layout
+	groupRows         []groupRow         // Rows of the grouped layout, rebuilt by BuildRows
+	showSubscriptions bool               // If true, show the subscription manager instead of the notifications
+	subscriptions     []repoSubscription // Rows of the subscription manager
 }
 
 func NewModel(
@@ -202,6 +246,9 @@ func NewModel(
 	m.Notifications = []notificationrow.Data{}
 	m.sessionMarkedRead = make(map[string]bool)
 	m.sessionMarkedDone = make(map[string]bool)
+	m.Grouped = cfg.Grouped
+	m.collapsedRepos = make(map[string]bool)
+	m.expandedSubjects = make(map[string]bool)
 
 	return m
 }
@@ -239,7 +286,11 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			case "enter":
 				input := m.PromptConfirmationBox.Value()
 				action := m.GetPromptConfirmationAction()
-				if input == "Y" || input == "y" {
+				if action == "snooze" {
+					cmd = m.snooze(input)
+				} else if action == "unsubscribe" {
+					cmd = m.unsubscribeFrom(input)
+				} else if input == "Y" || input == "y" {
 					switch action {
 					case "done":
 						cmd = m.markAsDone()
@@ -256,6 +307,11 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			break
 		}
 
+		if m.showSubscriptions {
+			cmd, _ = m.updateSubscriptions(msg)
+			return m, cmd
+		}
+
 		switch {
 		case key.Matches(msg, keys.NotificationKeys.MarkAsDone):
 			if m.GetCurrRow() != nil {
@@ -275,10 +331,14 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			return m, cmd
 
 		case key.Matches(msg, keys.NotificationKeys.Unsubscribe):
-			if m.GetCurrRow() != nil {
-				cmd = m.unsubscribe()
+			if m.GetCurrRow() == nil {
+				return m, nil
 			}
-			return m, cmd
+			m.SetPromptConfirmationAction("unsubscribe")
+			return m, m.SetIsPromptConfirmationShown(true)
+
+		case key.Matches(msg, keys.NotificationKeys.Subscriptions):
+			return m, m.toggleSubscriptions()
 
 		case key.Matches(msg, keys.NotificationKeys.Open):
 			if m.GetCurrRow() != nil {
@@ -288,17 +348,37 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 
 		case key.Matches(msg, keys.NotificationKeys.ToggleBookmark):
 			if notification := m.GetCurrNotification(); notification != nil {
-				data.GetBookmarkStore().ToggleBookmark(notification.GetId())
+				data.GetBookmarkStore().ToggleBookmark(notification.StoreKey())
 				// Rebuild rows to update bookmark indicator
 				m.Table.SetRows(m.BuildRows())
 			}
 			return m, nil
 
+		case key.Matches(msg, keys.NotificationKeys.Snooze):
+			if m.GetCurrRow() == nil {
+				return m, nil
+			}
+			if m.isCurrNotificationSnoozed() {
+				return m, m.unsnooze()
+			}
+			m.SetPromptConfirmationAction("snooze")
+			return m, m.SetIsPromptConfirmationShown(true)
+
 		case key.Matches(msg, keys.NotificationKeys.SortByRepo):
 			m.toggleSortOrder()
 			m.Table.SetRows(m.BuildRows())
 			return m, nil
 
+		case key.Matches(msg, keys.NotificationKeys.ToggleGrouping):
+			m.toggleGrouping()
+			return m, nil
+
+		case key.Matches(msg, keys.NotificationKeys.ToggleExpand):
+			if m.Grouped {
+				m.toggleCurrGroup()
+			}
+			return m, nil
+
 		case key.Matches(msg, keys.NotificationKeys.ToggleSmartFiltering):
 			if m.HasCurrentRepoNameInConfiguredFilter() || !m.HasRepoNameInConfiguredFilter() {
 				m.IsFilteredByCurrentRemote = !m.IsFilteredByCurrentRemote
@@ -315,24 +395,34 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 
 	case UpdateNotificationMsg:
 		if msg.IsRemoved {
-			for i, n := range m.Notifications {
-				if n.GetId() == msg.Id {
-					m.Notifications = append(m.Notifications[:i], m.Notifications[i+1:]...)
-					break
-				}
-			}
 			// Track as done so it doesn't reappear on refresh (GitHub API still returns it with all=true)
 			m.sessionMarkedDone[msg.Id] = true
 			// Also remove from sessionMarkedRead
 			delete(m.sessionMarkedRead, msg.Id)
-			m.TotalCount = len(m.Notifications)
+			m.removeNotification(msg.Id)
 			m.SetIsLoading(false)
+		}
+
+	case NotificationsMutedMsg:
+		if msg.Muted {
+			m.removeMutedNotifications(msg.Rule)
+		}
+		if m.showSubscriptions {
 			m.Table.SetRows(m.BuildRows())
-			m.UpdateTotalItemsCount(m.TotalCount)
-			// If the removed item was the last one, move the current row to the new last item.
-			if m.TotalCount > 0 && m.CurrRow() >= m.TotalCount {
-				m.LastItem()
-			}
+		}
+
+	case WatchedReposFetchedMsg:
+		m.setWatchedRepos(msg.Repos)
+
+	case RepoSubscriptionUpdatedMsg:
+		m.setRepoSubscriptionState(msg.Repo, msg.State)
+
+	case NotificationSnoozedMsg:
+		// Snoozed notifications move to is:snoozed sections, and unsnoozed
+		// ones move back on the next fetch
+		filters := parseNotificationFilters(m.GetSearchValue(), m.Ctx.Config.IncludeReadNotifications)
+		if msg.Snoozed != filters.IsSnoozed {
+			m.removeNotification(msg.Id)
 		}
 
 	case UpdateNotificationReadStateMsg:
@@ -359,6 +449,7 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 				m.Notifications[i].SubjectState = msg.SubjectState
 				m.Notifications[i].IsDraft = msg.IsDraft
 				m.Notifications[i].Actor = msg.Actor
+				m.Notifications[i].Author = msg.Author
 				// Generate activity description based on reason, type, and actor
 				m.Notifications[i].ActivityDescription = notificationrow.GenerateActivityDescription(
 					m.Notifications[i].GetReason(),
@@ -368,6 +459,8 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 				m.Table.SetRows(m.BuildRows())
 				log.Debug("Updated notification", "id", msg.Id, "count",
 					msg.NewCommentsCount, "state", msg.SubjectState, "actor", msg.Actor)
+				// Rules matching on the state or author can only apply now
+				cmd = tea.Batch(m.applyNotificationRules(m.Notifications[i : i+1])...)
 				break
 			}
 		}
@@ -385,7 +478,12 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 		}
 
 	case SectionNotificationsFetchedMsg:
-		if m.LastFetchTaskId == msg.TaskId {
+		if m.LastFetchTaskId == msg.TaskId && msg.NotModified {
+			// A refresh found nothing new, keep the rows and the pages loaded so far
+			m.PageInfo = m.fetchedPageInfo
+			m.SetIsLoading(false)
+			m.UpdateLastUpdated(time.Now())
+		} else if m.LastFetchTaskId == msg.TaskId {
 			if m.PageInfo != nil {
 				// Append to existing notifications (pagination)
 				m.Notifications = append(m.Notifications, msg.Notifications...)
@@ -395,6 +493,13 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			}
 			m.TotalCount = len(m.Notifications)
 			m.PageInfo = &msg.PageInfo
+			m.fetchedPageInfo = m.PageInfo
+			// sort: in the filters overrides the sort order picked with the key
+			filters := parseNotificationFilters(m.GetSearchValue(), m.Ctx.Config.IncludeReadNotifications)
+			if filters.SortOrder != nil {
+				m.SortOrder = *filters.SortOrder
+			}
+			m.sortNotifications()
 			m.SetIsLoading(false)
 			m.Table.SetRows(m.BuildRows())
 			m.UpdateLastUpdated(time.Now())
@@ -402,6 +507,7 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 
 			// Start background fetches for comment counts (only for new notifications)
 			fetchCmds := m.fetchCommentCountsForNotifications(msg.Notifications)
+			fetchCmds = append(fetchCmds, m.applyNotificationRules(msg.Notifications)...)
 			cmd = tea.Batch(fetchCmds...)
 		}
 
@@ -438,6 +544,23 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 	return m, tea.Batch(cmd, searchCmd, promptCmd, tableCmd)
 }
 
+// removeNotification removes the notification from the section's rows.
+func (m *Model) removeNotification(id string) {
+	for i, n := range m.Notifications {
+		if n.GetId() == id {
+			m.Notifications = append(m.Notifications[:i], m.Notifications[i+1:]...)
+			break
+		}
+	}
+	m.TotalCount = len(m.Notifications)
+	m.Table.SetRows(m.BuildRows())
+	m.UpdateTotalItemsCount(m.TotalCount)
+	// If the removed item was the last one, move the current row to the new last item.
+	if m.TotalCount > 0 && m.CurrRow() >= m.NumRows() {
+		m.LastItem()
+	}
+}
+
 func GetSectionColumns(ctx *context.ProgramContext) []table.Column {
 	return []table.Column{
 		{
@@ -505,7 +628,15 @@ func (m *Model) sortNotifications() {
 	}
 }
 
-func (m Model) BuildRows() []table.Row {
+func (m *Model) BuildRows() []table.Row {
+	if m.showSubscriptions {
+		return m.buildSubscriptionRows()
+	}
+	if m.Grouped {
+		return m.buildGroupedTableRows()
+	}
+	m.groupRows = nil
+
 	var rows []table.Row
 	for i := range m.Notifications {
 		notification := &m.Notifications[i]
@@ -521,18 +652,37 @@ func (m Model) BuildRows() []table.Row {
 }
 
 func (m *Model) NumRows() int {
+	if m.showSubscriptions {
+		return len(m.subscriptions)
+	}
+	if m.Grouped {
+		return len(m.groupRows)
+	}
 	return len(m.Notifications)
 }
 
 func (m *Model) GetCurrRow() data.RowData {
-	idx := m.Table.GetCurrItem()
-	if idx < 0 || idx >= len(m.Notifications) {
-		return nil
+	if notification := m.GetCurrNotification(); notification != nil {
+		return notification
 	}
-	return &m.Notifications[idx]
+	return nil
 }
 
+// GetCurrNotification returns the selected notification. In the grouped
+// layout, repository headers have none and merged subjects return their most
+// recent notification. The subscription manager has none.
 func (m *Model) GetCurrNotification() *notificationrow.Data {
+	if m.showSubscriptions {
+		return nil
+	}
+	if m.Grouped {
+		row := m.currGroupRow()
+		if row == nil || row.kind == repoGroupRow {
+			return nil
+		}
+		return &m.Notifications[row.notifications[0]]
+	}
+
 	idx := m.Table.GetCurrItem()
 	if idx < 0 || idx >= len(m.Notifications) {
 		return nil
@@ -592,9 +742,20 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 
 	// Capture current page info for pagination
 	pageInfo := m.PageInfo
+	hasRows := len(m.Notifications) > 0
 
-	// Capture config limit for the closure
+	// Capture config limit and host for the closure
 	limit := m.Ctx.Config.Defaults.NotificationsLimit
+	host := m.Config.Host
+
+	// Resolve @me in author: filters to the signed-in user
+	authorFilters := make([]string, 0, len(filters.AuthorFilters))
+	for _, author := range filters.AuthorFilters {
+		if author == "@me" {
+			author = m.Ctx.User
+		}
+		authorFilters = append(authorFilters, author)
+	}
 
 	// Build reason filter map for O(1) lookup
 	reasonFilterMap := make(map[string]bool, len(filters.ReasonFilters))
@@ -606,19 +767,24 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 		// Check if we need to include bookmarked items
 		// Build a map for O(1) lookups in the filter loop
 		bookmarkStore := data.GetBookmarkStore()
-		bookmarkedIds := bookmarkStore.GetBookmarkedIds()
-		hasBookmarks := len(bookmarkedIds) > 0
-		bookmarkedIdMap := make(map[string]bool, len(bookmarkedIds))
-		for _, id := range bookmarkedIds {
-			bookmarkedIdMap[id] = true
+		bookmarkedKeys := bookmarkStore.GetBookmarkedIds()
+		hasBookmarks := len(bookmarkedKeys) > 0
+		bookmarkedKeyMap := make(map[string]bool, len(bookmarkedKeys))
+		for _, key := range bookmarkedKeys {
+			bookmarkedKeyMap[key] = true
 		}
 
 		// Use the filter's read state directly - don't switch to "all" just for bookmarks/session items
 		// Bookmarked and session-marked-read items will be fetched separately by thread ID
 		readState := filters.ReadState
 
-		// Initialize done store for filtering
+		// Initialize done, snooze and mute stores for filtering
 		doneStore := data.GetDoneStore()
+		snoozeStore := data.GetSnoozeStore()
+		muteStore := data.GetMuteStore()
+		// Expired snoozes make the next fetch skip the conditional poll, so
+		// the rows kept on a 304 don't miss them
+		snoozeStore.PruneExpired()
 
 		// Track accumulated notifications across multiple pages.
 		// We may need to fetch additional pages if many notifications are filtered out
@@ -630,6 +796,7 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 		isFirstPage := pageInfo == nil
 		for {
 			res, err := data.FetchNotifications(
+				host,
 				limit,
 				filters.RepoFilters,
 				readState,
@@ -645,6 +812,20 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 			}
 			lastPageInfo = res.PageInfo
 
+			// Nothing changed since the section's rows were fetched, keep them
+			if isFirstPage && hasRows && res.NotModified {
+				log.Debug("Notifications not modified, keeping rows", "section", m.Id)
+				return constants.TaskFinishedMsg{
+					SectionId:   m.Id,
+					SectionType: m.Type,
+					TaskId:      taskId,
+					Msg: SectionNotificationsFetchedMsg{
+						TaskId:      taskId,
+						NotModified: true,
+					},
+				}
+			}
+
 			// Build a set of IDs we fetched from the API
 			fetchedIds := make(map[string]bool, len(res.Notifications))
 			for _, n := range res.Notifications {
@@ -656,14 +837,26 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 			if isFirstPage {
 				isFirstPage = false
 
-				// Collect all missing IDs that need to be fetched
+				// Collect all missing IDs that need to be fetched. The stores
+				// key the ids by host, only the ones of this section's host
+				// can be fetched from it
 				missingIds := make([]string, 0)
-				if filters.IncludeBookmarked && hasBookmarks {
-					for _, bookmarkId := range bookmarkedIds {
-						if !fetchedIds[bookmarkId] {
-							missingIds = append(missingIds, bookmarkId)
-							fetchedIds[bookmarkId] = true // Mark as fetched to avoid duplicates
-						}
+				addMissing := func(key string) {
+					keyHost, id := data.SplitHostKey(key)
+					if data.SameHost(keyHost, host) && !fetchedIds[id] {
+						missingIds = append(missingIds, id)
+						fetchedIds[id] = true // Mark as fetched to avoid duplicates
+					}
+				}
+				if (filters.IncludeBookmarked || filters.IsBookmarked) && hasBookmarks {
+					for _, key := range bookmarkedKeys {
+						addMissing(key)
+					}
+				}
+				if filters.IsSnoozed {
+					// Snoozed notifications may have aged out of the list as well
+					for _, key := range snoozeStore.GetSnoozedIds() {
+						addMissing(key)
 					}
 				}
 				if hasSessionMarkedRead {
@@ -694,7 +887,7 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 						wg.Add(1)
 						go func(threadId string) {
 							defer wg.Done()
-							notification, err := data.FetchNotificationByThreadId(threadId)
+							notification, err := data.FetchNotificationByThreadId(host, threadId)
 							results <- fetchResult{notification: notification, err: err}
 						}(id)
 					}
@@ -725,10 +918,22 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 			}
 
 			// Filter notifications based on bookmark settings and session state
+			matches := make([]data.NotificationData, 0, len(res.Notifications))
 			for _, n := range res.Notifications {
+				key := n.StoreKey()
 				// Skip notifications marked as done (GitHub API still returns them with all=true)
 				// Check both persistent store and session state
-				if doneStore.IsDone(n.Id, n.UpdatedAt) || sessionMarkedDone[n.Id] {
+				if doneStore.IsDone(key, n.UpdatedAt) || sessionMarkedDone[n.Id] {
+					continue
+				}
+
+				// Snoozed notifications only show up under is:snoozed, and
+				// is:snoozed only shows snoozed notifications
+				if snoozeStore.IsSnoozed(key, n.UpdatedAt) != filters.IsSnoozed {
+					continue
+				}
+
+				if muteStore.IsMuted(data.RowHost(n), n.Repository.FullName, n.Reason) {
 					continue
 				}
 
@@ -739,7 +944,7 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 					include = true
 				} else if filters.IncludeBookmarked && hasBookmarks {
 					// Default view: include if unread OR bookmarked (O(1) map lookup)
-					include = n.Unread || bookmarkedIdMap[n.Id]
+					include = n.Unread || bookmarkedKeyMap[key]
 				} else {
 					// Explicit filter: follow the ReadState filter
 					switch filters.ReadState {
@@ -757,19 +962,36 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 					include = reasonFilterMap[n.Reason]
 				}
 
-				if include {
-					notifications = append(notifications, notificationrow.Data{
-						Notification: n,
-						// Generate initial activity description (will be updated with actor later)
-						ActivityDescription: notificationrow.GenerateActivityDescription(
-							n.Reason,
-							n.Subject.Type,
-							"",
-						),
-					})
+				if include && filters.IsBookmarked {
+					include = bookmarkedKeyMap[key]
+				}
+
+				if include && filters.matchesQualifiers(n) {
+					matches = append(matches, n)
 				}
 			}
 
+			// author: filters match who triggered the notification, which
+			// takes a request per notification to find out
+			var actors map[string]string
+			if len(authorFilters) > 0 {
+				matches, actors = matchNotificationAuthors(matches, authorFilters,
+					limit-len(notifications))
+			}
+			for _, n := range matches {
+				actor := actors[n.Id]
+				notifications = append(notifications, notificationrow.Data{
+					Notification: n,
+					Actor:        actor,
+					// Generate initial activity description (will be updated with actor later)
+					ActivityDescription: notificationrow.GenerateActivityDescription(
+						n.Reason,
+						n.Subject.Type,
+						actor,
+					),
+				})
+			}
+
 			// Check if we have enough notifications or if we've run out of pages
 			if len(notifications) >= limit || !lastPageInfo.HasNextPage {
 				break
@@ -809,6 +1031,10 @@ func (m *Model) ResetRows() {
 	// Clear session state on manual refresh - user explicitly wants fresh data
 	m.sessionMarkedRead = make(map[string]bool)
 	m.sessionMarkedDone = make(map[string]bool)
+	m.fetchedPageInfo = nil
+	m.groupRows = nil
+	// Don't wait for GitHub's poll interval either
+	data.ExpireNotificationPolls()
 	m.BaseModel.ResetRows()
 }
 
@@ -820,6 +1046,7 @@ func FetchAllSections(
 ) (sections []section.Section, fetchAllCmd tea.Cmd) {
 	sectionConfigs := ctx.Config.NotificationsSections
 	fetchCmds := make([]tea.Cmd, 0, len(sectionConfigs))
+	data.ExpireNotificationPolls()
 	sections = make([]section.Section, 0, len(sectionConfigs))
 
 	for i, sectionConfig := range sectionConfigs {
@@ -837,6 +1064,11 @@ func FetchAllSections(
 				sectionModel.LastFetchTaskId = oldSection.LastFetchTaskId
 				sectionModel.sessionMarkedRead = oldSection.sessionMarkedRead
 				sectionModel.sessionMarkedDone = oldSection.sessionMarkedDone
+				sectionModel.Grouped = oldSection.Grouped
+				sectionModel.collapsedRepos = oldSection.collapsedRepos
+				sectionModel.expandedSubjects = oldSection.expandedSubjects
+				sectionModel.showSubscriptions = oldSection.showSubscriptions
+				sectionModel.subscriptions = oldSection.subscriptions
 				// Preserve user's filter state - don't reset on refresh
 				sectionModel.IsFilteredByCurrentRemote = oldSection.IsFilteredByCurrentRemote
 				sectionModel.SearchValue = oldSection.SearchValue
@@ -858,6 +1090,7 @@ type SectionNotificationsFetchedMsg struct {
 	TotalCount    int
 	TaskId        string
 	PageInfo      data.PageInfo
+	NotModified   bool // If true, nothing changed since the section's rows were fetched
 }
 
 // UpdateNotificationMsg signals that a notification's state has changed.
@@ -875,6 +1108,7 @@ type UpdateNotificationCommentsMsg struct {
 	SubjectState     string // OPEN, CLOSED, MERGED
 	IsDraft          bool
 	Actor            string // Username who triggered the notification
+	Author           string // Username of the PR/Issue author
 }
 
 // UpdateNotificationUrlMsg carries a resolved URL for notifications where the URL
@@ -907,14 +1141,16 @@ func (m *Model) SetIsLoading(val bool) {
 
 func (m Model) GetPagerContent() string {
 	pagerContent := ""
-	if m.TotalCount > 0 {
+	if m.showSubscriptions {
+		pagerContent = subscriptionsHelp()
+	} else if m.TotalCount > 0 {
 		pagerContent = fmt.Sprintf(
 			"%v %v • %v %v/%v",
 			constants.WaitingIcon,
 			m.LastUpdated().Format("01/02 15:04:05"),
 			m.SingularForm,
 			m.Table.GetCurrItem()+1,
-			m.TotalCount,
+			m.NumRows(),
 		)
 	}
 	pager := m.Ctx.Styles.ListViewPort.PagerStyle.Render(pagerContent)
@@ -941,6 +1177,12 @@ func (m *Model) fetchCommentCountsForNotifications(notifications []notificationr
 
 	log.Debug("fetchCommentCountsForNotifications called", "numNotifications", len(notifications))
 
+	// Enrichment is cosmetic, so don't spend the remaining API budget on it
+	if low, resetAt := data.IsRateLimitLow(); low {
+		log.Info("Skipping notification enrichment, rate limit is low", "resetAt", resetAt)
+		return cmds
+	}
+
 	for _, notif := range notifications {
 		// Copy values for closure capture
 		notifId := notif.GetId()
@@ -997,6 +1239,7 @@ func (m *Model) fetchCommentCountsForNotifications(notifications []notificationr
 					SubjectState:     pr.State,
 					IsDraft:          pr.IsDraft,
 					Actor:            actor,
+					Author:           pr.Author.Login,
 				}
 			})
 		case "Issue":
@@ -1030,6 +1273,7 @@ func (m *Model) fetchCommentCountsForNotifications(notifications []notificationr
 					NewCommentsCount: count,
 					SubjectState:     issue.State,
 					Actor:            actor,
+					Author:           issue.Author.Login,
 				}
 			})
 		case "CheckSuite":
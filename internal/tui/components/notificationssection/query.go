@@ -0,0 +1,283 @@
+package notificationssection
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+// maxConcurrentAuthorFetches bounds how many notification authors are looked
+// up at once for author: filters.
+const maxConcurrentAuthorFetches = 8
+
+// qualifierRegex matches any "qualifier:value" token, known or not
+var qualifierRegex = regexp.MustCompile(`^-?[a-zA-Z-]+:`)
+
+// typeFilterRegex matches "type:value" patterns in search strings
+var typeFilterRegex = regexp.MustCompile(`(?:^|\s)type:([^\s]+)`)
+
+// authorFilterRegex matches "author:login" patterns in search strings
+var authorFilterRegex = regexp.MustCompile(`(?:^|\s)author:([^\s]+)`)
+
+// orgFilterRegex matches "org:login" patterns in search strings
+var orgFilterRegex = regexp.MustCompile(`(?:^|\s)org:([^\s]+)`)
+
+// updatedFilterRegex matches "updated:range" patterns in search strings
+var updatedFilterRegex = regexp.MustCompile(`(?:^|\s)updated:([^\s]+)`)
+
+// sortFilterRegex matches "sort:repo" and "sort:updated" patterns
+var sortFilterRegex = regexp.MustCompile(`(?:^|\s)sort:(repo|updated)\b`)
+
+// subjectTypes maps the values of type: to notification subject types
+var subjectTypes = map[string]string{
+	"pr":          data.SubjectTypePullRequest,
+	"pullrequest": data.SubjectTypePullRequest,
+	"issue":       data.SubjectTypeIssue,
+	"release":     data.SubjectTypeRelease,
+	"discussion":  data.SubjectTypeDiscussion,
+	"checksuite":  data.SubjectTypeCheckSuite,
+	"ci":          data.SubjectTypeCheckSuite,
+	"commit":      data.SubjectTypeCommit,
+}
+
+func parseSubmatches(re *regexp.Regexp, search string) []string {
+	matches := re.FindAllStringSubmatch(search, -1)
+	values := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if len(match) > 1 {
+			values = append(values, match[1])
+		}
+	}
+	return values
+}
+
+// parseTypeFilters extracts type:value patterns as notification subject types
+func parseTypeFilters(search string) []string {
+	types := parseSubmatches(typeFilterRegex, search)
+	for i, t := range types {
+		if subjectType, ok := subjectTypes[strings.ToLower(t)]; ok {
+			types[i] = subjectType
+		}
+	}
+	return types
+}
+
+// parseSortFilter returns the sort order given by sort:repo or sort:updated
+func parseSortFilter(search string) *SortOrder {
+	matches := parseSubmatches(sortFilterRegex, search)
+	if len(matches) == 0 {
+		return nil
+	}
+	order := SortByUpdated
+	if matches[len(matches)-1] == "repo" {
+		order = SortByRepo
+	}
+	return &order
+}
+
+// tokenizeSearch splits a search string into tokens, keeping "quoted phrases"
+// together.
+func tokenizeSearch(search string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range search {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t' || r == '\n') && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+// parseTitleTerms returns the free text of a search string, i.e. the words
+// and "quoted phrases" that aren't qualifiers, lowercased.
+func parseTitleTerms(search string) []string {
+	var terms []string
+	for _, token := range tokenizeSearch(search) {
+		if !strings.HasPrefix(token, `"`) && qualifierRegex.MatchString(token) {
+			continue
+		}
+		if term := strings.Trim(token, `"`); term != "" {
+			terms = append(terms, strings.ToLower(term))
+		}
+	}
+	return terms
+}
+
+// dateRange is a range of times, from inclusive to exclusive. Zero times
+// leave the range open on that side.
+type dateRange struct {
+	from time.Time
+	to   time.Time
+}
+
+func (r dateRange) contains(t time.Time) bool {
+	return (r.from.IsZero() || !t.Before(r.from)) && (r.to.IsZero() || t.Before(r.to))
+}
+
+// parseDate parses a date or date-time and returns it along with the start of
+// the next date or second, so ranges include the whole date.
+func parseDate(s string) (time.Time, time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	return t, t.Add(time.Second), nil
+}
+
+// parseDateRange parses GitHub's date range syntax: >D, >=D, <D, <=D, D..D or
+// D, where D is a date like 2026-01-15 or a date-time like
+// 2026-01-15T10:00:00Z. Relative dates can be written with the template
+// helpers, e.g. >={{ nowModify "-1w" }}. Either end of D..D can be *.
+func parseDateRange(s string) (dateRange, error) {
+	var r dateRange
+	var err error
+	switch {
+	case strings.HasPrefix(s, ">="):
+		r.from, _, err = parseDate(s[2:])
+	case strings.HasPrefix(s, ">"):
+		_, r.from, err = parseDate(s[1:])
+	case strings.HasPrefix(s, "<="):
+		_, r.to, err = parseDate(s[2:])
+	case strings.HasPrefix(s, "<"):
+		r.to, _, err = parseDate(s[1:])
+	case strings.Contains(s, ".."):
+		from, to, _ := strings.Cut(s, "..")
+		if from != "*" {
+			if r.from, _, err = parseDate(from); err != nil {
+				return dateRange{}, err
+			}
+		}
+		if to != "*" {
+			_, r.to, err = parseDate(to)
+		}
+	default:
+		r.from, r.to, err = parseDate(s)
+	}
+	if err != nil {
+		return dateRange{}, err
+	}
+	return r, nil
+}
+
+// parseUpdatedFilters parses the updated: qualifiers of a search string,
+// skipping invalid ones.
+func parseUpdatedFilters(search string) []dateRange {
+	var ranges []dateRange
+	for _, value := range parseSubmatches(updatedFilterRegex, search) {
+		r, err := parseDateRange(value)
+		if err != nil {
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// matchesQualifiers returns true if the notification matches the type:,
+// org:, updated: and free text filters. author: filters need the actor and
+// are matched separately.
+func (f NotificationFilters) matchesQualifiers(n data.NotificationData) bool {
+	if !matchesAny(f.SubjectTypes, n.Subject.Type, strings.EqualFold) {
+		return false
+	}
+
+	owner, _, _ := strings.Cut(n.Repository.FullName, "/")
+	if !matchesAny(f.OrgFilters, owner, strings.EqualFold) {
+		return false
+	}
+
+	for _, r := range f.UpdatedRanges {
+		if !r.contains(n.UpdatedAt) {
+			return false
+		}
+	}
+
+	title := strings.ToLower(n.Subject.Title)
+	for _, term := range f.TitleTerms {
+		if !strings.Contains(title, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesFilters returns true if the notification matches the repo:,
+// reason:, type:, org:, updated: and free text filters of a search. is: and
+// author: filters are ignored.
+func MatchesFilters(search string, n data.NotificationData) bool {
+	filters := parseNotificationFilters(search, true)
+	return matchesAny(filters.RepoFilters, n.Repository.FullName, strings.EqualFold) &&
+		matchesAny(filters.ReasonFilters, n.Reason, strings.EqualFold) &&
+		filters.matchesQualifiers(n)
+}
+
+// matchNotificationAuthors returns up to limit notifications triggered by one
+// of the authors, and who triggered each one. As it takes a request per
+// notification to find out, notifications are looked up a batch at a time
+// until enough of them match, and the authors are cached by comment URL.
+func matchNotificationAuthors(
+	notifications []data.NotificationData,
+	authors []string,
+	limit int,
+) ([]data.NotificationData, map[string]string) {
+	matched := make([]data.NotificationData, 0, limit)
+	actors := make(map[string]string, limit)
+	for batch := range slices.Chunk(notifications, maxConcurrentAuthorFetches) {
+		if len(matched) >= limit {
+			break
+		}
+		resolved := resolveNotificationActors(batch)
+		for _, n := range batch {
+			if len(matched) < limit && matchesAny(authors, resolved[n.Id], matchAuthor) {
+				matched = append(matched, n)
+				actors[n.Id] = resolved[n.Id]
+			}
+		}
+	}
+	return matched, actors
+}
+
+// resolveNotificationActors looks up who triggered each notification: the
+// author of its latest comment, or of its subject if it has no comments.
+func resolveNotificationActors(notifications []data.NotificationData) map[string]string {
+	actors := make(map[string]string, len(notifications))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentAuthorFetches)
+	for _, n := range notifications {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			actor, _ := data.FetchCommentAuthor(n.Subject.LatestCommentUrl)
+			if actor == "" {
+				actor, _ = data.FetchCommentAuthor(n.Subject.Url)
+			}
+			mu.Lock()
+			actors[n.Id] = actor
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	return actors
+}
@@ -0,0 +1,155 @@
+package notificationssection
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+func TestParseTitleTerms(t *testing.T) {
+	require.Equal(t,
+		[]string{"fix", "memory leak", "crash"},
+		parseTitleTerms(`is:unread Fix repo:o/r "Memory Leak" type:pr crash -author:bot`),
+	)
+	require.Empty(t, parseTitleTerms("is:unread reason:mention"))
+}
+
+func TestParseTypeFilters(t *testing.T) {
+	require.Equal(t,
+		[]string{data.SubjectTypePullRequest, data.SubjectTypeCheckSuite, "Unknown"},
+		parseTypeFilters("type:pr type:CheckSuite type:Unknown"),
+	)
+}
+
+func TestParseSortFilter(t *testing.T) {
+	require.Nil(t, parseSortFilter("is:unread"))
+	require.Equal(t, SortByRepo, *parseSortFilter("is:unread sort:repo"))
+	require.Equal(t, SortByUpdated, *parseSortFilter("sort:repo sort:updated"))
+}
+
+func TestParseDateRange(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 1, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		input   string
+		want    dateRange
+		wantErr bool
+	}{
+		{input: ">2026-01-15", want: dateRange{from: day(16)}},
+		{input: ">=2026-01-15", want: dateRange{from: day(15)}},
+		{input: "<2026-01-15", want: dateRange{to: day(15)}},
+		{input: "<=2026-01-15", want: dateRange{to: day(16)}},
+		{input: "2026-01-15", want: dateRange{from: day(15), to: day(16)}},
+		{input: "2026-01-10..2026-01-15", want: dateRange{from: day(10), to: day(16)}},
+		{input: "2026-01-10..*", want: dateRange{from: day(10)}},
+		{input: "*..2026-01-15", want: dateRange{to: day(16)}},
+		{
+			input: ">2026-01-15T10:00:00Z",
+			want:  dateRange{from: time.Date(2026, 1, 15, 10, 0, 1, 0, time.UTC)},
+		},
+		{input: ">last-week", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseDateRange(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, got.from.Equal(tt.want.from), "from = %v, want %v", got.from, tt.want.from)
+			require.True(t, got.to.Equal(tt.want.to), "to = %v, want %v", got.to, tt.want.to)
+		})
+	}
+}
+
+func TestMatchesQualifiers(t *testing.T) {
+	n := data.NotificationData{
+		UpdatedAt:  time.Date(2026, 1, 15, 12, 0, 0, 0, time.Local),
+		Subject:    data.NotificationSubject{Title: "Fix memory leak in parser", Type: "PullRequest"},
+		Repository: data.NotificationRepository{FullName: "dlvhdr/gh-dash"},
+	}
+
+	tests := []struct {
+		search string
+		want   bool
+	}{
+		{search: "", want: true},
+		{search: "type:pr", want: true},
+		{search: "type:issue type:pr", want: true},
+		{search: "type:release", want: false},
+		{search: "org:DLVHDR", want: true},
+		{search: "org:cli", want: false},
+		{search: "updated:>=2026-01-15", want: true},
+		{search: "updated:<2026-01-15", want: false},
+		{search: "updated:2026-01-01..2026-01-15", want: true},
+		{search: `memory "in parser"`, want: true},
+		{search: "memory crash", want: false},
+		{search: "is:unread type:pr leak", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			filters := parseNotificationFilters(tt.search, false)
+			require.Equal(t, tt.want, filters.matchesQualifiers(n))
+		})
+	}
+}
+
+func TestMatchesFilters(t *testing.T) {
+	n := data.NotificationData{
+		Reason:     data.ReasonReviewRequested,
+		Subject:    data.NotificationSubject{Title: "Add desktop notifications", Type: "PullRequest"},
+		Repository: data.NotificationRepository{FullName: "dlvhdr/gh-dash"},
+	}
+
+	require.True(t, MatchesFilters("", n))
+	require.True(t, MatchesFilters("reason:review-requested,mention", n))
+	require.False(t, MatchesFilters("reason:mention", n))
+	require.True(t, MatchesFilters("repo:dlvhdr/gh-dash reason:review-requested", n))
+	require.False(t, MatchesFilters("repo:cli/cli reason:review-requested", n))
+	require.False(t, MatchesFilters("reason:review-requested type:issue", n))
+	require.True(t, MatchesFilters("is:read reason:review-requested", n), "is: filters are ignored")
+}
+
+func TestParseNotificationFiltersBookmarked(t *testing.T) {
+	filters := parseNotificationFilters("is:bookmarked", false)
+	require.True(t, filters.IsBookmarked)
+	require.Equal(t, data.NotificationStateAll, filters.ReadState)
+	require.False(t, filters.IncludeBookmarked)
+
+	filters = parseNotificationFilters("author:@me author:dependabot", false)
+	require.Equal(t, []string{"@me", "dependabot"}, filters.AuthorFilters)
+}
+
+func TestMatchNotificationAuthors(t *testing.T) {
+	notifications := make([]data.NotificationData, 0, 20)
+	for i := range 20 {
+		n := data.NotificationData{Id: fmt.Sprint(i)}
+		n.Subject.LatestCommentUrl = fmt.Sprintf("https://api.github.com/repos/o/r/issues/comments/%d", i)
+		author := "alice"
+		if i%2 == 1 {
+			author = "bob"
+		}
+		data.CacheCommentAuthorForTesting(n.Subject.LatestCommentUrl, author)
+		notifications = append(notifications, n)
+	}
+
+	matched, actors := matchNotificationAuthors(notifications, []string{"bob"}, 3)
+	ids := make([]string, 0, len(matched))
+	for _, n := range matched {
+		ids = append(ids, n.Id)
+	}
+	require.Equal(t, []string{"1", "3", "5"}, ids, "stops at the limit")
+	require.Equal(t, map[string]string{"1": "bob", "3": "bob", "5": "bob"}, actors)
+
+	matched, _ = matchNotificationAuthors(notifications, []string{"Alice"}, 20)
+	require.Len(t, matched, 10)
+}
@@ -0,0 +1,253 @@
+package notificationssection
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/notificationrow"
+)
+
+type ruleMatch int
+
+const (
+	ruleNoMatch ruleMatch = iota
+	ruleMatches
+	// ruleNeedsEnrichment means the rule matches so far but depends on the
+	// subject's state or author, which aren't known until the notification
+	// is enriched.
+	ruleNeedsEnrichment
+)
+
+// appliedRules records the notifications a rule was applied to, along with
+// their update time, so rules only run once per notification update even if
+// it shows up in several sections or fetches.
+var appliedRules = struct {
+	sync.Mutex
+	ids map[string]time.Time
+}{ids: make(map[string]time.Time)}
+
+var titleRegexps sync.Map
+
+func matchesAny(values []string, value string, match func(pattern, value string) bool) bool {
+	if len(values) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(values, func(pattern string) bool {
+		return match(pattern, value)
+	})
+}
+
+func matchRepo(pattern, repo string) bool {
+	matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(repo))
+	return err == nil && matched
+}
+
+// matchAuthor compares logins ignoring case and the "[bot]" suffix, as the
+// REST and GraphQL APIs disagree on whether app logins have it.
+func matchAuthor(pattern, author string) bool {
+	normalize := func(login string) string {
+		return strings.TrimSuffix(strings.ToLower(login), "[bot]")
+	}
+	return normalize(pattern) == normalize(author)
+}
+
+func matchState(isDraft bool) func(pattern, state string) bool {
+	return func(pattern, state string) bool {
+		if strings.EqualFold(pattern, "draft") {
+			return isDraft
+		}
+		return strings.EqualFold(pattern, state)
+	}
+}
+
+func matchTitle(pattern, title string) bool {
+	if pattern == "" {
+		return true
+	}
+	re, ok := titleRegexps.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Error("Invalid notification rule title", "title", pattern, "err", err)
+			return false
+		}
+		re, _ = titleRegexps.LoadOrStore(pattern, compiled)
+	}
+	return re.(*regexp.Regexp).MatchString(title)
+}
+
+func matchRule(rule config.NotificationRule, n notificationrow.Data) ruleMatch {
+	match := rule.Match
+	if !matchesAny(match.Reason, n.GetReason(), strings.EqualFold) ||
+		!matchesAny(match.Repo, n.GetRepoNameWithOwner(), matchRepo) ||
+		!matchesAny(match.SubjectType, n.GetSubjectType(), strings.EqualFold) ||
+		!matchTitle(match.Title, n.GetTitle()) {
+		return ruleNoMatch
+	}
+
+	if len(match.State) == 0 && len(match.Author) == 0 {
+		return ruleMatches
+	}
+
+	// Only PRs and issues are enriched with their state and author
+	subjectType := n.GetSubjectType()
+	if subjectType != "PullRequest" && subjectType != "Issue" {
+		return ruleNoMatch
+	}
+	if n.SubjectState == "" {
+		return ruleNeedsEnrichment
+	}
+	if !matchesAny(match.State, n.SubjectState, matchState(n.IsDraft)) ||
+		!matchesAny(match.Author, n.Author, matchAuthor) {
+		return ruleNoMatch
+	}
+	return ruleMatches
+}
+
+// findRule returns the index of the first rule matching the notification, or
+// -1 if none does. Rules apply in order, so if an earlier rule can't be
+// decided until the notification is enriched, findRule returns -1 and waits
+// for the enrichment.
+func findRule(rules []config.NotificationRule, n notificationrow.Data) int {
+	for i, rule := range rules {
+		switch matchRule(rule, n) {
+		case ruleMatches:
+			return i
+		case ruleNeedsEnrichment:
+			return -1
+		}
+	}
+	return -1
+}
+
+// markRuleApplied returns false if a rule was already applied to the
+// notification since it was last updated.
+func markRuleApplied(n notificationrow.Data) bool {
+	appliedRules.Lock()
+	defer appliedRules.Unlock()
+
+	key, updatedAt := n.StoreKey(), n.GetUpdatedAt()
+	if last, ok := appliedRules.ids[key]; ok && !last.Before(updatedAt) {
+		return false
+	}
+	appliedRules.ids[key] = updatedAt
+	return true
+}
+
+// forgetRuleApplied undoes markRuleApplied once the rule's action failed, so
+// it's tried again on the next fetch
+func forgetRuleApplied(key string, updatedAt time.Time) {
+	appliedRules.Lock()
+	defer appliedRules.Unlock()
+
+	if last, ok := appliedRules.ids[key]; ok && last.Equal(updatedAt) {
+		delete(appliedRules.ids, key)
+	}
+}
+
+func ruleName(rules []config.NotificationRule, index int) string {
+	if rules[index].Name != "" {
+		return rules[index].Name
+	}
+	return fmt.Sprintf("#%d", index+1)
+}
+
+// applyNotificationRules runs the configured notification rules against the
+// notifications and returns the commands carrying out their actions.
+func (m *Model) applyNotificationRules(notifications []notificationrow.Data) []tea.Cmd {
+	rules := m.Ctx.Config.NotificationRules
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	bookmarked := false
+	for _, n := range notifications {
+		i := findRule(rules, n)
+		if i < 0 || !markRuleApplied(n) {
+			continue
+		}
+
+		rule := rules[i]
+		name := ruleName(rules, i)
+		log.Info("Applying notification rule",
+			"rule", name,
+			"action", rule.Action,
+			"id", n.GetId(),
+			"repo", n.GetRepoNameWithOwner(),
+			"reason", n.GetReason(),
+			"type", n.GetSubjectType(),
+			"title", n.GetTitle(),
+		)
+
+		if rule.Action == config.NotificationRuleBookmark {
+			if !data.GetBookmarkStore().IsBookmarked(n.StoreKey()) {
+				data.GetBookmarkStore().Add(n.StoreKey())
+				bookmarked = true
+			}
+			continue
+		}
+		cmds = append(cmds, ruleActionCmd(name, rule.Action, n))
+	}
+
+	if bookmarked {
+		m.Table.SetRows(m.BuildRows())
+	}
+	return cmds
+}
+
+func ruleActionCmd(
+	name string,
+	action config.NotificationRuleAction,
+	n notificationrow.Data,
+) tea.Cmd {
+	id := n.GetId()
+	host := data.RowHost(n.Notification)
+	storeKey := n.StoreKey()
+	updatedAt := n.Notification.UpdatedAt
+	failed := func(err error) tea.Msg {
+		log.Error("Notification rule failed", "rule", name, "action", action, "id", id, "err", err)
+		forgetRuleApplied(storeKey, updatedAt)
+		return nil
+	}
+	switch action {
+	case config.NotificationRuleDone:
+		return func() tea.Msg {
+			if err := markNotificationDoneFunc(host, id); err != nil {
+				return failed(err)
+			}
+			data.GetDoneStore().MarkDone(storeKey, updatedAt)
+			return UpdateNotificationMsg{Id: id, IsRemoved: true}
+		}
+
+	case config.NotificationRuleRead:
+		if !n.Notification.Unread {
+			return nil
+		}
+		return func() tea.Msg {
+			if err := data.MarkNotificationRead(host, id); err != nil {
+				return failed(err)
+			}
+			return UpdateNotificationReadStateMsg{Id: id, Unread: false}
+		}
+
+	case config.NotificationRuleUnsubscribe:
+		return func() tea.Msg {
+			if err := data.UnsubscribeFromThread(host, id); err != nil {
+				return failed(err)
+			}
+			return UnsubscribedMsg{Id: id}
+		}
+	}
+	return nil
+}
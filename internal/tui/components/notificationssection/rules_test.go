@@ -0,0 +1,164 @@
+package notificationssection
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/notificationrow"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+)
+
+func newRuleNotification(id, reason, subjectType, repo, title string) notificationrow.Data {
+	return notificationrow.Data{
+		Notification: data.NotificationData{
+			Id:         id,
+			Unread:     true,
+			Reason:     reason,
+			UpdatedAt:  time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC),
+			Subject:    data.NotificationSubject{Title: title, Type: subjectType},
+			Repository: data.NotificationRepository{FullName: repo},
+		},
+	}
+}
+
+func TestFindRule(t *testing.T) {
+	rules := []config.NotificationRule{
+		{
+			Name:   "ci",
+			Match:  config.NotificationRuleMatch{Reason: []string{"ci_activity"}},
+			Action: config.NotificationRuleDone,
+		},
+		{
+			Name: "merged bot PRs",
+			Match: config.NotificationRuleMatch{
+				SubjectType: []string{"PullRequest"},
+				Author:      []string{"dependabot[bot]"},
+				State:       []string{"merged", "closed"},
+			},
+			Action: config.NotificationRuleDone,
+		},
+		{
+			Name: "releases",
+			Match: config.NotificationRuleMatch{
+				Repo:  []string{"dlvhdr/*"},
+				Title: `^v\d+\.\d+`,
+			},
+			Action: config.NotificationRuleRead,
+		},
+	}
+
+	merged := newRuleNotification("4", "subscribed", "PullRequest", "o/r", "Bump x")
+	merged.SubjectState = notificationrow.StateMerged
+	merged.Author = "dependabot"
+
+	open := merged
+	open.SubjectState = notificationrow.StateOpen
+
+	tests := []struct {
+		name         string
+		notification notificationrow.Data
+		want         int
+	}{
+		{
+			name:         "matches reason",
+			notification: newRuleNotification("1", "ci_activity", "CheckSuite", "o/r", "CI"),
+			want:         0,
+		},
+		{
+			name:         "waits for enrichment before later rules",
+			notification: newRuleNotification("2", "subscribed", "PullRequest", "dlvhdr/gh-dash", "v4.0"),
+			want:         -1,
+		},
+		{
+			name:         "skips rules needing enrichment for other subjects",
+			notification: newRuleNotification("3", "subscribed", "Release", "dlvhdr/gh-dash", "v4.0"),
+			want:         2,
+		},
+		{
+			name:         "matches enriched state and author",
+			notification: merged,
+			want:         1,
+		},
+		{
+			name:         "doesn't match other states",
+			notification: open,
+			want:         -1,
+		},
+		{
+			name:         "doesn't match other repos",
+			notification: newRuleNotification("5", "subscribed", "Release", "other/gh-dash", "v4.0"),
+			want:         -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findRule(rules, tt.notification); got != tt.want {
+				t.Errorf("findRule() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyNotificationRules(t *testing.T) {
+	var marked []string
+	origFunc := markNotificationDoneFunc
+	markNotificationDoneFunc = func(_ string, id string) error {
+		marked = append(marked, id)
+		return nil
+	}
+	defer func() { markNotificationDoneFunc = origFunc }()
+
+	defer data.OverrideStateStoresForTesting("")()
+	store := data.GetDoneStore()
+
+	cfg := config.Config{NotificationRules: []config.NotificationRule{{
+		Match:  config.NotificationRuleMatch{Reason: []string{"ci_activity"}},
+		Action: config.NotificationRuleDone,
+	}}}
+	m := Model{}
+	m.Ctx = &context.ProgramContext{Config: &cfg}
+
+	notifications := []notificationrow.Data{
+		newRuleNotification("rule-1", "ci_activity", "CheckSuite", "o/r", "CI"),
+		newRuleNotification("rule-2", "mention", "Issue", "o/r", "Bug"),
+	}
+
+	cmds := m.applyNotificationRules(notifications)
+	if len(cmds) != 1 {
+		t.Fatalf("applyNotificationRules() returned %d cmds, want 1", len(cmds))
+	}
+	msg := cmds[0]()
+	if got, ok := msg.(UpdateNotificationMsg); !ok || got.Id != "rule-1" || !got.IsRemoved {
+		t.Fatalf("action returned %#v, want the removal of rule-1", msg)
+	}
+	if len(marked) != 1 || marked[0] != "rule-1" {
+		t.Fatalf("marked %v as done, want [rule-1]", marked)
+	}
+	if !store.IsDone("rule-1", notifications[0].Notification.UpdatedAt) {
+		t.Error("rule-1 should be persisted as done")
+	}
+
+	// Rules only apply once per notification update
+	if cmds := m.applyNotificationRules(notifications); len(cmds) != 0 {
+		t.Errorf("applyNotificationRules() reapplied %d rules", len(cmds))
+	}
+
+	notifications[0].Notification.UpdatedAt = notifications[0].Notification.UpdatedAt.Add(time.Hour)
+	cmds = m.applyNotificationRules(notifications)
+	if len(cmds) != 1 {
+		t.Fatalf("applyNotificationRules() returned %d cmds after an update, want 1", len(cmds))
+	}
+
+	// Failed actions are tried again on the next fetch
+	markNotificationDoneFunc = func(string, string) error { return errors.New("offline") }
+	if msg := cmds[0](); msg != nil {
+		t.Fatalf("failed action returned %#v, want nil", msg)
+	}
+	if cmds := m.applyNotificationRules(notifications); len(cmds) != 1 {
+		t.Errorf("applyNotificationRules() returned %d cmds after a failure, want 1", len(cmds))
+	}
+}
@@ -0,0 +1,114 @@
+package notificationssection
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/utils"
+)
+
+// snoozeHour is the hour of the day the "tomorrow" and "next Monday" presets
+// snooze until.
+const snoozeHour = 9
+
+// NotificationSnoozedMsg is sent when a notification is snoozed or unsnoozed,
+// so every section showing it can update
+type NotificationSnoozedMsg struct {
+	Id      string
+	Snoozed bool
+}
+
+// parseSnoozeUntil returns the time to snooze until for the snooze prompt's
+// input: one of the presets, by number or name, or a duration like "3d".
+func parseSnoozeUntil(input string, now time.Time) (time.Time, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	atSnoozeHour := func(days int) time.Time {
+		day := now.AddDate(0, 0, days)
+		return time.Date(day.Year(), day.Month(), day.Day(), snoozeHour, 0, 0, 0, now.Location())
+	}
+
+	switch input {
+	case "":
+		return time.Time{}, errors.New("no snooze time given")
+	case "1":
+		return now.Add(time.Hour), nil
+	case "2", "tomorrow":
+		return atSnoozeHour(1), nil
+	case "3", "monday", "next monday":
+		days := (int(time.Monday) - int(now.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return atSnoozeHour(days), nil
+	}
+
+	duration, err := utils.ParseDuration(input)
+	if err != nil || duration <= 0 {
+		return time.Time{}, fmt.Errorf("invalid snooze duration %q", input)
+	}
+	return now.Add(duration), nil
+}
+
+// snooze hides the current notification until the time given in the snooze
+// prompt, or until it has new activity.
+func (m *Model) snooze(input string) tea.Cmd {
+	notification := m.GetCurrNotification()
+	if notification == nil {
+		return nil
+	}
+
+	until, err := parseSnoozeUntil(input, time.Now())
+	if err != nil {
+		return func() tea.Msg { return constants.ErrMsg{Err: err} }
+	}
+
+	notificationId := notification.GetId()
+	data.GetSnoozeStore().Snooze(notification.StoreKey(), notification.GetUpdatedAt(), until)
+
+	taskId := fmt.Sprintf("notification_snooze_%s", notificationId)
+	task := context.Task{
+		Id:           taskId,
+		StartText:    "Snoozing notification",
+		FinishedText: fmt.Sprintf("Notification snoozed until %s", until.Format("Mon Jan 2 15:04")),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: SectionType,
+			TaskId:      taskId,
+		}
+	}, func() tea.Msg {
+		return NotificationSnoozedMsg{Id: notificationId, Snoozed: true}
+	})
+}
+
+// unsnooze brings back the current notification if it's snoozed.
+func (m *Model) unsnooze() tea.Cmd {
+	notification := m.GetCurrNotification()
+	if notification == nil {
+		return nil
+	}
+
+	notificationId := notification.GetId()
+	data.GetSnoozeStore().Remove(notification.StoreKey())
+	return func() tea.Msg {
+		return NotificationSnoozedMsg{Id: notificationId, Snoozed: false}
+	}
+}
+
+// isCurrNotificationSnoozed returns true if the current notification is snoozed.
+func (m *Model) isCurrNotificationSnoozed() bool {
+	notification := m.GetCurrNotification()
+	return notification != nil &&
+		data.GetSnoozeStore().IsSnoozed(notification.StoreKey(), notification.GetUpdatedAt())
+}
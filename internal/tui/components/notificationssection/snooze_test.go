@@ -0,0 +1,122 @@
+package notificationssection
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/notificationrow"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/theme"
+)
+
+func TestParseSnoozeUntil(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2026, 1, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "1", want: now.Add(time.Hour)},
+		{input: "2", want: time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{input: "Tomorrow", want: time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{input: "3", want: time.Date(2026, 1, 19, 9, 0, 0, 0, time.UTC)},
+		{input: "next monday", want: time.Date(2026, 1, 19, 9, 0, 0, 0, time.UTC)},
+		{input: "90m", want: now.Add(90 * time.Minute)},
+		{input: " 3d ", want: now.Add(3 * 24 * time.Hour)},
+		{input: "", wantErr: true},
+		{input: "later", wantErr: true},
+		{input: "-1h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseSnoozeUntil(tt.input, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseSnoozeUntil(%q) = %v, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSnoozeUntil(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseSnoozeUntil(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	monday := time.Date(2026, 1, 19, 8, 0, 0, 0, time.UTC)
+	if got, _ := parseSnoozeUntil("monday", monday); !got.Equal(monday.AddDate(0, 0, 7).Add(time.Hour)) {
+		t.Errorf("parseSnoozeUntil(\"monday\") on a Monday = %v, want the next week's Monday", got)
+	}
+}
+
+func TestParseNotificationFiltersSnoozed(t *testing.T) {
+	filters := parseNotificationFilters("is:snoozed", false)
+	if !filters.IsSnoozed {
+		t.Error("IsSnoozed = false, want true")
+	}
+	if filters.ReadState != data.NotificationStateAll {
+		t.Errorf("ReadState = %v, want all", filters.ReadState)
+	}
+	if filters.IncludeBookmarked {
+		t.Error("IncludeBookmarked = true, want false")
+	}
+
+	filters = parseNotificationFilters("is:snoozed is:unread", false)
+	if !filters.IsSnoozed || filters.ReadState != data.NotificationStateUnread {
+		t.Errorf("is:snoozed is:unread = %+v, want snoozed unread notifications", filters)
+	}
+
+	if parseNotificationFilters("is:unread", false).IsSnoozed {
+		t.Error("IsSnoozed should only be set by is:snoozed")
+	}
+}
+
+func TestNotificationSnoozedMsg(t *testing.T) {
+	store := data.NewSnoozeStoreForTesting(filepath.Join(t.TempDir(), "snoozed.json"), time.Now)
+	defer data.OverrideSnoozeStoreForTesting(store)()
+
+	cfg, err := config.ParseConfig(config.Location{
+		ConfigFlag:       "../../../config/testdata/test-config.yml",
+		SkipGlobalConfig: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	ctx := &context.ProgramContext{Config: &cfg}
+	ctx.Theme = theme.ParseTheme(ctx.Config)
+	ctx.Styles = context.InitStyles(ctx.Theme)
+
+	newModel := func(filters string) *Model {
+		m := NewModel(0, ctx, config.NotificationsSectionConfig{Filters: filters}, time.Now())
+		m.Notifications = []notificationrow.Data{
+			newRuleNotification("1", "mention", "Issue", "o/r", "Bug"),
+			newRuleNotification("2", "mention", "Issue", "o/r", "Feature"),
+		}
+		return &m
+	}
+
+	inbox := newModel("is:unread")
+	inbox.Update(NotificationSnoozedMsg{Id: "1", Snoozed: true})
+	if len(inbox.Notifications) != 1 || inbox.Notifications[0].GetId() != "2" {
+		t.Errorf("snoozing should remove the notification, got %d rows", len(inbox.Notifications))
+	}
+
+	snoozed := newModel("is:snoozed")
+	snoozed.Update(NotificationSnoozedMsg{Id: "1", Snoozed: true})
+	if len(snoozed.Notifications) != 2 {
+		t.Errorf("is:snoozed sections should keep snoozed notifications, got %d rows",
+			len(snoozed.Notifications))
+	}
+	snoozed.Update(NotificationSnoozedMsg{Id: "2", Snoozed: false})
+	if len(snoozed.Notifications) != 1 || snoozed.Notifications[0].GetId() != "1" {
+		t.Errorf("unsnoozing should remove the notification from is:snoozed sections")
+	}
+}
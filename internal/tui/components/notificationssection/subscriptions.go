@@ -0,0 +1,377 @@
+package notificationssection
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/table"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/keys"
+	"github.com/dlvhdr/gh-dash/v4/internal/utils"
+)
+
+// unsubscribeScope is what the unsubscribe prompt unsubscribes from
+type unsubscribeScope int
+
+const (
+	unsubscribeThread unsubscribeScope = iota
+	unsubscribeRepo
+	unsubscribeRepoCI
+)
+
+// repoSubscription is a row of the subscription manager
+type repoSubscription struct {
+	repo  string
+	state data.SubscriptionState
+}
+
+// NotificationsMutedMsg is sent when a mute rule is added or removed, so
+// every section can hide the notifications it mutes.
+type NotificationsMutedMsg struct {
+	Rule  data.MuteRule
+	Muted bool
+}
+
+// WatchedReposFetchedMsg carries the repositories the user watches
+type WatchedReposFetchedMsg struct {
+	Repos []string
+}
+
+// RepoSubscriptionUpdatedMsg is sent when a repository is watched, ignored
+// or stopped being watched
+type RepoSubscriptionUpdatedMsg struct {
+	Repo  string
+	State data.SubscriptionState
+}
+
+// parseUnsubscribeScope returns the scope picked in the unsubscribe prompt,
+// by number or name.
+func parseUnsubscribeScope(input string) (unsubscribeScope, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "1", "thread":
+		return unsubscribeThread, nil
+	case "2", "repo":
+		return unsubscribeRepo, nil
+	case "3", "ci":
+		return unsubscribeRepoCI, nil
+	default:
+		return 0, fmt.Errorf("invalid unsubscribe option %q", input)
+	}
+}
+
+// unsubscribeFrom unsubscribes from the current notification's thread, or
+// mutes its repository or the repository's CI notifications.
+func (m *Model) unsubscribeFrom(input string) tea.Cmd {
+	notification := m.GetCurrNotification()
+	if notification == nil {
+		return nil
+	}
+
+	scope, err := parseUnsubscribeScope(input)
+	if err != nil {
+		return func() tea.Msg { return constants.ErrMsg{Err: err} }
+	}
+
+	host := data.RowHost(notification.Notification)
+	repo := notification.GetRepoNameWithOwner()
+	switch scope {
+	case unsubscribeRepo:
+		return m.setMuted(data.MuteRule{Host: host, Repo: repo}, true)
+	case unsubscribeRepoCI:
+		return m.setMuted(data.MuteRule{Host: host, Repo: repo, Reason: data.ReasonCIActivity}, true)
+	default:
+		return m.unsubscribe()
+	}
+}
+
+func muteRuleDescription(rule data.MuteRule) string {
+	repo := data.HostKey(rule.Host, rule.Repo)
+	if rule.Reason == data.ReasonCIActivity {
+		return "CI of " + repo
+	}
+	return repo
+}
+
+// setMuted adds or removes a mute rule and tells every section about it.
+func (m *Model) setMuted(rule data.MuteRule, muted bool) tea.Cmd {
+	store := data.GetMuteStore()
+	text := "Muted " + muteRuleDescription(rule)
+	if muted {
+		store.Mute(rule)
+	} else {
+		store.Unmute(rule)
+		text = "Unmuted " + muteRuleDescription(rule)
+	}
+
+	taskId := fmt.Sprintf("notification_mute_%s_%s", rule.Repo, rule.Reason)
+	task := context.Task{
+		Id:           taskId,
+		StartText:    text,
+		FinishedText: text,
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: SectionType,
+			TaskId:      taskId,
+		}
+	}, func() tea.Msg {
+		return NotificationsMutedMsg{Rule: rule, Muted: muted}
+	})
+}
+
+// removeMutedNotifications removes the notifications a new mute rule mutes.
+func (m *Model) removeMutedNotifications(rule data.MuteRule) {
+	var ids []string
+	for _, n := range m.Notifications {
+		if rule.Matches(data.RowHost(n.Notification), n.GetRepoNameWithOwner(), n.GetReason()) {
+			ids = append(ids, n.GetId())
+		}
+	}
+	for _, id := range ids {
+		m.removeNotification(id)
+	}
+}
+
+// IsShowingSubscriptions returns true if the section shows the subscription
+// manager instead of the notifications.
+func (m *Model) IsShowingSubscriptions() bool {
+	return m.showSubscriptions
+}
+
+// toggleSubscriptions shows or hides the subscription manager, which lists
+// the watched and muted repositories instead of the notifications.
+func (m *Model) toggleSubscriptions() tea.Cmd {
+	m.showSubscriptions = !m.showSubscriptions
+	m.Table.ResetCurrItem()
+	m.Table.SetRows(m.BuildRows())
+	if !m.showSubscriptions {
+		return nil
+	}
+
+	taskId := fmt.Sprintf("notification_subscriptions_%d", m.Id)
+	task := context.Task{
+		Id:           taskId,
+		StartText:    "Fetching watched repositories",
+		FinishedText: "Watched repositories have been fetched",
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		repos, err := data.FetchWatchedRepos()
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Err:         err,
+			Msg:         WatchedReposFetchedMsg{Repos: repos},
+		}
+	})
+}
+
+// setWatchedRepos lists the watched repositories, followed by the other
+// muted ones. GitHub doesn't list ignored repositories, so only the ones
+// ignored from the subscription manager keep showing as ignored.
+func (m *Model) setWatchedRepos(repos []string) {
+	ignored := make(map[string]bool)
+	for _, s := range m.subscriptions {
+		if s.state == data.SubscriptionIgnoring {
+			ignored[s.repo] = true
+		}
+	}
+
+	subscriptions := make([]repoSubscription, 0, len(repos))
+	listed := make(map[string]bool)
+	add := func(repo string, state data.SubscriptionState) {
+		if listed[strings.ToLower(repo)] {
+			return
+		}
+		listed[strings.ToLower(repo)] = true
+		if ignored[repo] {
+			state = data.SubscriptionIgnoring
+		}
+		subscriptions = append(subscriptions, repoSubscription{repo: repo, state: state})
+	}
+	for _, repo := range repos {
+		add(repo, data.SubscriptionWatching)
+	}
+	for repo := range ignored {
+		add(repo, data.SubscriptionIgnoring)
+	}
+	// The subscriptions are the default host's, so only its mutes are listed
+	for _, rule := range data.GetMuteStore().GetRules() {
+		if rule.Host == "" {
+			add(rule.Repo, data.SubscriptionParticipating)
+		}
+	}
+	m.subscriptions = subscriptions
+	m.Table.SetRows(m.BuildRows())
+}
+
+func (m *Model) setRepoSubscriptionState(repo string, state data.SubscriptionState) {
+	i := slices.IndexFunc(m.subscriptions, func(s repoSubscription) bool {
+		return s.repo == repo
+	})
+	if i < 0 {
+		return
+	}
+	m.subscriptions[i].state = state
+	m.Table.SetRows(m.BuildRows())
+}
+
+func (m *Model) currSubscription() *repoSubscription {
+	idx := m.Table.GetCurrItem()
+	if idx < 0 || idx >= len(m.subscriptions) {
+		return nil
+	}
+	return &m.subscriptions[idx]
+}
+
+// updateSubscriptions handles the keys of the subscription manager. It
+// returns false for keys it doesn't handle.
+func (m *Model) updateSubscriptions(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, keys.NotificationKeys.Subscriptions),
+		key.Matches(msg, keys.NotificationKeys.BackToNotification):
+		return m.toggleSubscriptions(), true
+
+	case key.Matches(msg, keys.NotificationKeys.ToggleWatching):
+		if s := m.currSubscription(); s != nil {
+			state := data.SubscriptionWatching
+			if s.state == data.SubscriptionWatching {
+				state = data.SubscriptionParticipating
+			}
+			return m.setRepoSubscription(s.repo, state), true
+		}
+		return nil, true
+
+	case key.Matches(msg, keys.NotificationKeys.ToggleIgnoring):
+		if s := m.currSubscription(); s != nil {
+			state := data.SubscriptionIgnoring
+			if s.state == data.SubscriptionIgnoring {
+				state = data.SubscriptionParticipating
+			}
+			return m.setRepoSubscription(s.repo, state), true
+		}
+		return nil, true
+
+	case key.Matches(msg, keys.NotificationKeys.ToggleRepoMute):
+		if s := m.currSubscription(); s != nil {
+			rule := data.MuteRule{Repo: s.repo}
+			return m.setMuted(rule, !slices.Contains(data.GetMuteStore().GetRules(), rule)), true
+		}
+		return nil, true
+
+	case key.Matches(msg, keys.NotificationKeys.ToggleCIMute):
+		if s := m.currSubscription(); s != nil {
+			rule := data.MuteRule{Repo: s.repo, Reason: data.ReasonCIActivity}
+			return m.setMuted(rule, !slices.Contains(data.GetMuteStore().GetRules(), rule)), true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+func subscriptionStateText(state data.SubscriptionState) string {
+	switch state {
+	case data.SubscriptionWatching:
+		return "Watching all activity"
+	case data.SubscriptionIgnoring:
+		return "Ignoring"
+	default:
+		return "Participating and @mentions"
+	}
+}
+
+// setRepoSubscription watches, ignores or stops watching a repository.
+func (m *Model) setRepoSubscription(repo string, state data.SubscriptionState) tea.Cmd {
+	taskId := fmt.Sprintf("notification_subscription_%s", repo)
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Updating the subscription to %s", repo),
+		FinishedText: fmt.Sprintf("%s: %s", repo, subscriptionStateText(state)),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		err := data.SetRepoSubscription(repo, state)
+		var msg tea.Msg
+		if err == nil {
+			msg = RepoSubscriptionUpdatedMsg{Repo: repo, State: state}
+		}
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Err:         err,
+			Msg:         msg,
+		}
+	})
+}
+
+// buildSubscriptionRows renders the subscription manager's rows.
+// Uses raw ANSI codes without resets to preserve the row's background.
+func (m *Model) buildSubscriptionRows() []table.Row {
+	faintPrefix := utils.GetStylePrefix(lipgloss.NewStyle().Foreground(m.Ctx.Theme.FaintText))
+	repoPrefix := utils.GetStylePrefix(
+		lipgloss.NewStyle().Foreground(m.Ctx.Theme.PrimaryText).Bold(true),
+	)
+	warningPrefix := utils.GetStylePrefix(lipgloss.NewStyle().Foreground(m.Ctx.Theme.WarningText))
+
+	rules := data.GetMuteStore().GetRules()
+	rows := make([]table.Row, 0, len(m.subscriptions))
+	for _, s := range m.subscriptions {
+		icon := constants.NotificationIcon
+		switch s.state {
+		case data.SubscriptionWatching:
+			icon = constants.WatchingIcon
+		case data.SubscriptionIgnoring:
+			icon = constants.IgnoringIcon
+		}
+
+		muted := ""
+		switch {
+		case slices.Contains(rules, data.MuteRule{Repo: s.repo}):
+			muted = "  " + constants.MutedIcon + " muted"
+		case slices.Contains(rules, data.MuteRule{Repo: s.repo, Reason: data.ReasonCIActivity}):
+			muted = "  " + constants.MutedIcon + " CI muted"
+		}
+
+		title := repoPrefix + s.repo + "\n" +
+			faintPrefix + subscriptionStateText(s.state) + warningPrefix + muted
+		rows = append(rows, table.Row{faintPrefix + icon, title, "", ""})
+	}
+	return rows
+}
+
+// subscriptionsHelp lists the keys of the subscription manager below it
+func subscriptionsHelp() string {
+	bindings := []key.Binding{
+		keys.NotificationKeys.ToggleWatching,
+		keys.NotificationKeys.ToggleIgnoring,
+		keys.NotificationKeys.ToggleRepoMute,
+		keys.NotificationKeys.ToggleCIMute,
+		keys.NotificationKeys.Subscriptions,
+	}
+	help := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		desc := b.Help().Desc
+		if b.Help().Key == keys.NotificationKeys.Subscriptions.Help().Key {
+			desc = "back"
+		}
+		help = append(help, b.Help().Key+" "+desc)
+	}
+	return strings.Join(help, " • ")
+}
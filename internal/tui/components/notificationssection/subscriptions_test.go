@@ -0,0 +1,94 @@
+package notificationssection
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+func TestParseUnsubscribeScope(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    unsubscribeScope
+		wantErr bool
+	}{
+		{input: "1", want: unsubscribeThread},
+		{input: "thread", want: unsubscribeThread},
+		{input: "2", want: unsubscribeRepo},
+		{input: " Repo ", want: unsubscribeRepo},
+		{input: "3", want: unsubscribeRepoCI},
+		{input: "ci", want: unsubscribeRepoCI},
+		{input: "", wantErr: true},
+		{input: "4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseUnsubscribeScope(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseUnsubscribeScope(%q) = %v, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseUnsubscribeScope(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseUnsubscribeScope(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveMutedNotifications(t *testing.T) {
+	m := newGroupedModel(t)
+
+	m.removeMutedNotifications(data.MuteRule{Repo: "O/A", Reason: data.ReasonCIActivity})
+	var ids []string
+	for _, n := range m.Notifications {
+		ids = append(ids, n.GetId())
+	}
+	if len(ids) != 3 || ids[0] != "1" || ids[1] != "2" || ids[2] != "3" {
+		t.Errorf("after muting o/a's CI, notifications = %v, want [1 2 3]", ids)
+	}
+
+	m.removeMutedNotifications(data.MuteRule{Repo: "o/a"})
+	if len(m.Notifications) != 1 || m.Notifications[0].GetId() != "2" {
+		t.Errorf("after muting o/a, got %d notifications, want only 2", len(m.Notifications))
+	}
+}
+
+func TestSetWatchedRepos(t *testing.T) {
+	store := data.NewMuteStoreForTesting(filepath.Join(t.TempDir(), "muted.json"))
+	defer data.OverrideMuteStoreForTesting(store)()
+	store.Mute(data.MuteRule{Repo: "o/muted"})
+	store.Mute(data.MuteRule{Repo: "o/watched", Reason: data.ReasonCIActivity})
+
+	m := newGroupedModel(t)
+	m.showSubscriptions = true
+	m.subscriptions = []repoSubscription{{repo: "o/ignored", state: data.SubscriptionIgnoring}}
+	m.setWatchedRepos([]string{"o/watched", "o/other"})
+
+	want := []repoSubscription{
+		{repo: "o/watched", state: data.SubscriptionWatching},
+		{repo: "o/other", state: data.SubscriptionWatching},
+		{repo: "o/ignored", state: data.SubscriptionIgnoring},
+		{repo: "o/muted", state: data.SubscriptionParticipating},
+	}
+	if len(m.subscriptions) != len(want) {
+		t.Fatalf("subscriptions = %v, want %v", m.subscriptions, want)
+	}
+	for i := range want {
+		if m.subscriptions[i] != want[i] {
+			t.Errorf("subscriptions[%d] = %v, want %v", i, m.subscriptions[i], want[i])
+		}
+	}
+	if got := m.NumRows(); got != len(want) {
+		t.Errorf("NumRows() = %d, want %d", got, len(want))
+	}
+	if m.GetCurrNotification() != nil {
+		t.Error("GetCurrNotification() returned a notification in the subscription manager")
+	}
+}
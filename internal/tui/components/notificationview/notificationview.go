@@ -3,6 +3,7 @@ package notificationview
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
@@ -24,6 +25,10 @@ type Model struct {
 	subjectIssue *data.IssueData
 	subjectId    string // ID of the notification whose subject is cached
 
+	// What happened since the notification was last read, for the timeline
+	lastReadAt  *time.Time
+	stateEvents []data.StateEvent
+
 	// Pending confirmation action for PR/Issue (e.g., "pr_close", "issue_reopen")
 	pendingAction string
 }
@@ -46,6 +51,7 @@ func (m *Model) ResetSubject() {
 	m.subjectPR = nil
 	m.subjectIssue = nil
 	m.subjectId = ""
+	m.clearTimeline()
 }
 
 func (m *Model) SetSubjectPR(pr *prrow.Data, notificationId string) {
@@ -76,6 +82,7 @@ func (m *Model) ClearSubject() {
 	m.subjectPR = nil
 	m.subjectIssue = nil
 	m.subjectId = ""
+	m.clearTimeline()
 }
 
 func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
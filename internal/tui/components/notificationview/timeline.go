@@ -0,0 +1,266 @@
+package notificationview
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/utils"
+)
+
+// timelineContext is how many events from before the notification was last
+// read are shown above the new ones
+const timelineContext = 2
+
+// timelineEvent is an entry of the "since you last read" timeline
+type timelineEvent struct {
+	icon   string
+	author string
+	text   string
+	at     time.Time
+}
+
+// SetTimeline sets when the notification was last read, before viewing it,
+// and the state changes of its subject.
+func (m *Model) SetTimeline(lastReadAt *time.Time, stateEvents []data.StateEvent) {
+	m.lastReadAt = lastReadAt
+	m.stateEvents = stateEvents
+}
+
+func (m *Model) clearTimeline() {
+	m.lastReadAt = nil
+	m.stateEvents = nil
+}
+
+// firstLine returns the first non-empty line of a comment body
+func firstLine(body string) string {
+	for line := range strings.Lines(body) {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func stateEventText(eventType string) string {
+	switch eventType {
+	case data.StateEventClosed:
+		return "closed this"
+	case data.StateEventReopened:
+		return "reopened this"
+	case data.StateEventMerged:
+		return "merged this"
+	case data.StateEventReadyForReview:
+		return "marked this as ready for review"
+	case data.StateEventConvertToDraft:
+		return "converted this to a draft"
+	default:
+		return eventType
+	}
+}
+
+func reviewText(state string) string {
+	switch state {
+	case "APPROVED":
+		return "approved"
+	case "CHANGES_REQUESTED":
+		return "requested changes"
+	case "DISMISSED":
+		return "had a review dismissed"
+	default:
+		return "reviewed"
+	}
+}
+
+func stateTimelineEvents(stateEvents []data.StateEvent) []timelineEvent {
+	events := make([]timelineEvent, 0, len(stateEvents))
+	for _, e := range stateEvents {
+		icon := constants.ClosedIcon
+		switch e.Type {
+		case data.StateEventMerged:
+			icon = constants.MergedIcon
+		case data.StateEventReopened, data.StateEventReadyForReview:
+			icon = constants.OpenIcon
+		case data.StateEventConvertToDraft:
+			icon = constants.DraftIcon
+		}
+		events = append(events, timelineEvent{
+			icon:   icon,
+			author: e.Actor,
+			text:   stateEventText(e.Type),
+			at:     e.CreatedAt,
+		})
+	}
+	return events
+}
+
+// prTimeline lists the comments, reviews, review comments, commits and state
+// changes of a PR, oldest first.
+func prTimeline(pr data.EnrichedPullRequestData, stateEvents []data.StateEvent) []timelineEvent {
+	events := stateTimelineEvents(stateEvents)
+	for _, c := range pr.Comments.Nodes {
+		events = append(events, timelineEvent{
+			icon:   constants.CommentIcon,
+			author: c.Author.Login,
+			text:   "commented: " + firstLine(c.Body),
+			at:     c.UpdatedAt,
+		})
+	}
+	for _, r := range pr.Reviews.Nodes {
+		if r.State == "PENDING" {
+			continue
+		}
+		text := reviewText(r.State)
+		if body := firstLine(r.Body); body != "" {
+			text += ": " + body
+		}
+		events = append(events, timelineEvent{
+			icon:   constants.CodeReviewIcon,
+			author: r.Author.Login,
+			text:   text,
+			at:     r.UpdatedAt,
+		})
+	}
+	for _, thread := range pr.ReviewThreads.Nodes {
+		for _, c := range thread.Comments.Nodes {
+			events = append(events, timelineEvent{
+				icon:   constants.CommentIcon,
+				author: c.Author.Login,
+				text:   fmt.Sprintf("commented on %s: %s", thread.Path, firstLine(c.Body)),
+				at:     c.UpdatedAt,
+			})
+		}
+	}
+	for _, node := range pr.AllCommits.Nodes {
+		commit := node.Commit
+		author := commit.Author.User.Login
+		if author == "" {
+			author = commit.Author.Name
+		}
+		events = append(events, timelineEvent{
+			icon:   constants.CommitIcon,
+			author: author,
+			text:   fmt.Sprintf("pushed %s %s", commit.AbbreviatedOid, commit.MessageHeadline),
+			at:     commit.CommittedDate,
+		})
+	}
+	sortTimeline(events)
+	return events
+}
+
+// issueTimeline lists the comments and state changes of an issue, oldest
+// first.
+func issueTimeline(issue data.IssueData, stateEvents []data.StateEvent) []timelineEvent {
+	events := stateTimelineEvents(stateEvents)
+	for _, c := range issue.Comments.Nodes {
+		events = append(events, timelineEvent{
+			icon:   constants.CommentIcon,
+			author: c.Author.Login,
+			text:   "commented: " + firstLine(c.Body),
+			at:     c.UpdatedAt,
+		})
+	}
+	sortTimeline(events)
+	return events
+}
+
+func sortTimeline(events []timelineEvent) {
+	slices.SortStableFunc(events, func(a, b timelineEvent) int {
+		return a.at.Compare(b.at)
+	})
+}
+
+// splitTimeline splits the events into the ones from before lastReadAt and
+// the new ones. Everything is new if the notification was never read.
+func splitTimeline(events []timelineEvent, lastReadAt *time.Time) ([]timelineEvent, []timelineEvent) {
+	if lastReadAt == nil {
+		return nil, events
+	}
+	i, _ := slices.BinarySearchFunc(events, *lastReadAt, func(e timelineEvent, t time.Time) int {
+		if e.at.After(t) {
+			return 1
+		}
+		return -1
+	})
+	return events[:i], events[i:]
+}
+
+// ViewTimeline renders what happened on the notification's PR or issue since
+// it was last read, below the latest older events and a line marking where
+// the new activity starts. It's empty until the subject has been fetched.
+func (m Model) ViewTimeline() string {
+	var events []timelineEvent
+	switch {
+	case m.subjectPR != nil && m.subjectPR.IsEnriched:
+		events = prTimeline(m.subjectPR.Enriched, m.stateEvents)
+	case m.subjectIssue != nil:
+		events = issueTimeline(*m.subjectIssue, m.stateEvents)
+	default:
+		return ""
+	}
+	old, recent := splitTimeline(events, m.lastReadAt)
+
+	headerStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+	faintStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+
+	s := strings.Builder{}
+	s.WriteString(headerStyle.Render("Since you last read"))
+	s.WriteString("\n\n")
+
+	for _, e := range old[max(len(old)-timelineContext, 0):] {
+		s.WriteString(m.renderTimelineEvent(e, true))
+		s.WriteString("\n")
+	}
+	if len(old) > 0 {
+		s.WriteString(m.renderNewActivityLine())
+		s.WriteString("\n")
+	}
+	if len(recent) == 0 {
+		s.WriteString(faintStyle.Render("No new activity"))
+		s.WriteString("\n")
+	}
+	for _, e := range recent {
+		s.WriteString(m.renderTimelineEvent(e, false))
+		s.WriteString("\n")
+	}
+
+	return lipgloss.NewStyle().PaddingBottom(1).Render(s.String())
+}
+
+// renderNewActivityLine renders the line separating old from new activity
+func (m Model) renderNewActivityLine() string {
+	label := fmt.Sprintf(" New since %s ", m.lastReadAt.Local().Format("Jan 2, 3:04 PM"))
+	width := max(m.width-lipgloss.Width(label), 4)
+	left := strings.Repeat(constants.HorizontalLineIcon, 2)
+	right := strings.Repeat(constants.HorizontalLineIcon, max(width-2, 2))
+	return lipgloss.NewStyle().Foreground(m.ctx.Theme.WarningText).Render(left + label + right)
+}
+
+func (m Model) renderTimelineEvent(e timelineEvent, isOld bool) string {
+	iconStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+	actorStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.ActorText)
+	textStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.PrimaryText)
+	if isOld {
+		iconStyle = iconStyle.Foreground(m.ctx.Theme.FaintText)
+		actorStyle = actorStyle.Foreground(m.ctx.Theme.FaintText)
+		textStyle = textStyle.Foreground(m.ctx.Theme.FaintText)
+	}
+	faintStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+
+	ago := " " + utils.TimeElapsed(e.at)
+	line := iconStyle.Render(e.icon) + " "
+	if e.author != "" {
+		line += actorStyle.Render("@"+e.author) + " "
+	}
+	line += textStyle.Render(e.text)
+	if m.width > 0 {
+		line = ansi.Truncate(line, max(m.width-lipgloss.Width(ago), 1), constants.Ellipsis)
+	}
+	return line + faintStyle.Render(ago)
+}
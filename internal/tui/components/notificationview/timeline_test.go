@@ -0,0 +1,89 @@
+package notificationview
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prrow"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/theme"
+)
+
+func day(d int) time.Time {
+	return time.Date(2026, 1, d, 10, 0, 0, 0, time.UTC)
+}
+
+func timelinePR() data.EnrichedPullRequestData {
+	pr := data.EnrichedPullRequestData{}
+	for i, d := range []int{10, 11, 12, 16} {
+		var c data.Comment
+		c.Author.Login = "alice"
+		c.Body = "\ncomment " + string(rune('a'+i)) + "\nmore details"
+		c.UpdatedAt = day(d)
+		pr.Comments.Nodes = append(pr.Comments.Nodes, c)
+	}
+	var review data.Review
+	review.Author.Login = "bob"
+	review.State = "APPROVED"
+	review.UpdatedAt = day(17)
+	pr.Reviews.Nodes = []data.Review{review, {State: "PENDING", UpdatedAt: day(18)}}
+	return pr
+}
+
+func TestPRTimeline(t *testing.T) {
+	events := prTimeline(timelinePR(), []data.StateEvent{
+		{Type: data.StateEventMerged, Actor: "bob", CreatedAt: day(18)},
+	})
+
+	texts := make([]string, 0, len(events))
+	for _, e := range events {
+		texts = append(texts, e.text)
+	}
+	require.Equal(t, []string{
+		"commented: comment a",
+		"commented: comment b",
+		"commented: comment c",
+		"commented: comment d",
+		"approved",
+		"merged this",
+	}, texts, "pending reviews are skipped and events are sorted oldest first")
+
+	lastReadAt := day(15)
+	old, recent := splitTimeline(events, &lastReadAt)
+	require.Len(t, old, 3)
+	require.Len(t, recent, 3)
+
+	old, recent = splitTimeline(events, nil)
+	require.Empty(t, old)
+	require.Len(t, recent, len(events), "everything is new if the notification was never read")
+}
+
+func TestViewTimeline(t *testing.T) {
+	m := NewModel(&context.ProgramContext{Theme: *theme.DefaultTheme})
+	m.SetWidth(80)
+	require.Empty(t, m.ViewTimeline(), "the timeline needs the subject")
+
+	m.SetSubjectPR(&prrow.Data{Enriched: timelinePR(), IsEnriched: true}, "notif-id")
+	lastReadAt := day(15)
+	m.SetTimeline(&lastReadAt, nil)
+
+	view := ansi.Strip(m.ViewTimeline())
+	require.NotContains(t, view, "comment a", "only the latest older events are shown")
+	require.Contains(t, view, "comment b")
+	newLine := strings.Index(view, "New since")
+	require.Positive(t, newLine)
+	require.Less(t, strings.Index(view, "comment c"), newLine)
+	require.Greater(t, strings.Index(view, "comment d"), newLine)
+	require.Contains(t, view, "@bob approved")
+
+	lastReadAt = day(20)
+	require.Contains(t, ansi.Strip(m.ViewTimeline()), "No new activity")
+
+	m.ClearSubject()
+	require.Empty(t, m.ViewTimeline())
+}
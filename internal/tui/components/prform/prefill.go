@@ -0,0 +1,109 @@
+package prform
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+)
+
+// templateDirs are the directories of a repo GitHub looks for PR templates
+// in
+var templateDirs = []string{".github", "", "docs"}
+
+const templateName = "pull_request_template"
+
+// Prefill returns the title and body a PR of the branch starts with, like gh
+// fills them in: the message of its only commit, or the branch's name and a
+// list of its commits. The repo's PR template is added after the body.
+func Prefill(branch string, commits []git.CommitMessage, template string) (title string, body string) {
+	switch len(commits) {
+	case 1:
+		title, body = commits[0].Subject, commits[0].Body
+	default:
+		title = humanize(branch)
+		subjects := make([]string, 0, len(commits))
+		for _, c := range commits {
+			subjects = append(subjects, "- "+c.Subject)
+		}
+		body = strings.Join(subjects, "\n")
+	}
+
+	template = strings.TrimSpace(template)
+	switch {
+	case template == "":
+	case body == "":
+		body = template
+	default:
+		body += "\n\n" + template
+	}
+	return title, body
+}
+
+// humanize turns a branch's name into a title, like fix-the-thing into "Fix
+// the thing"
+func humanize(branch string) string {
+	title := strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' {
+			return ' '
+		}
+		return r
+	}, branch)
+	if title == "" {
+		return ""
+	}
+	r, size := utf8.DecodeRuneInString(title)
+	return string(unicode.ToUpper(r)) + title[size:]
+}
+
+// FindTemplate returns the repo's PR template, or an empty string when it
+// has none. Names are matched regardless of their case, like GitHub does.
+// Of a directory of templates, the first one is used.
+func FindTemplate(repoPath string) string {
+	for _, dir := range templateDirs {
+		entries, err := os.ReadDir(filepath.Join(repoPath, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := strings.ToLower(entry.Name())
+			if entry.IsDir() || strings.TrimSuffix(strings.TrimSuffix(name, ".md"), ".txt") != templateName {
+				continue
+			}
+			if content, err := os.ReadFile(filepath.Join(repoPath, dir, entry.Name())); err == nil {
+				return string(content)
+			}
+		}
+	}
+
+	for _, dir := range templateDirs {
+		entries, err := os.ReadDir(filepath.Join(repoPath, dir))
+		if err != nil {
+			continue
+		}
+		i := slices.IndexFunc(entries, func(entry os.DirEntry) bool {
+			return entry.IsDir() && strings.ToLower(entry.Name()) == templateName
+		})
+		if i < 0 {
+			continue
+		}
+		templatesDir := filepath.Join(repoPath, dir, entries[i].Name())
+		templates, err := os.ReadDir(templatesDir)
+		if err != nil {
+			continue
+		}
+		for _, t := range templates {
+			if t.IsDir() || !strings.HasSuffix(strings.ToLower(t.Name()), ".md") {
+				continue
+			}
+			if content, err := os.ReadFile(filepath.Join(templatesDir, t.Name())); err == nil {
+				return string(content)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,406 @@
+// Package prform is the form a PR of a local branch is created with, from
+// the repo view
+package prform
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/cmpcontroller"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/fuzzyselect"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/inputbox"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/keys"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/markdown"
+)
+
+type field int
+
+const (
+	titleField field = iota
+	bodyField
+	baseField
+	draftField
+	reviewersField
+	assigneesField
+	labelsField
+	fieldCount
+)
+
+var fieldNames = map[field]string{
+	titleField:     "Title",
+	bodyField:      "Body",
+	baseField:      "Base",
+	draftField:     "Draft",
+	reviewersField: "Reviewers",
+	assigneesField: "Assignees",
+	labelsField:    "Labels",
+}
+
+var (
+	nextFieldKey = key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next field"))
+	prevFieldKey = key.NewBinding(
+		key.WithKeys("shift+tab"),
+		key.WithHelp("shift+tab", "previous field"),
+	)
+	toggleDraftKey = key.NewBinding(key.WithKeys("space", "x"), key.WithHelp("space", "toggle"))
+	previewKey     = key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("Ctrl+d", "preview"))
+	cancelKey      = key.NewBinding(
+		key.WithKeys("ctrl+c", "esc"),
+		key.WithHelp("Ctrl+c/esc", "cancel"),
+	)
+	submitKey = key.NewBinding(
+		key.WithKeys("enter", "y", "ctrl+d"),
+		key.WithHelp("enter", "create PR"),
+	)
+	editKey = key.NewBinding(key.WithKeys("esc", "n"), key.WithHelp("esc", "edit"))
+)
+
+// Options are what the form starts with
+type Options struct {
+	// PR is the PR to create, whose title, body, base and draft are the
+	// ones the form starts with
+	PR tasks.CreatePROptions
+	// Owner and Name name the repo to suggest its users and labels
+	Owner string
+	Name  string
+	// Bases are the branches the PR can be merged into
+	Bases []string
+}
+
+type Model struct {
+	ctx   *context.ProgramContext
+	opts  Options
+	title inputbox.Model
+	body  inputbox.Model
+	// picker is the input of whichever of the base branch, reviewers,
+	// assignees and labels is focused, with its suggestions. The values of
+	// the others are kept in values.
+	picker  cmpcontroller.Controller
+	values  map[field]string
+	draft   bool
+	focused field
+	help    help.Model
+	width   int
+	err     error
+	// isPreviewing is true while the PR is shown as it will be created,
+	// before creating it
+	isPreviewing bool
+	isSubmitted  bool
+	isCancelled  bool
+}
+
+func NewModel(ctx *context.ProgramContext, opts Options) Model {
+	ti := inputbox.DefaultTextInput(ctx)
+	title := inputbox.NewModel(ctx, inputbox.ModelOpts{TextInput: &ti})
+	title.SetValue(opts.PR.Title)
+	ta := inputbox.DefaultTextArea(ctx)
+	ta.SetHeight(inputbox.DefaultInputHeight * 2)
+	body := inputbox.NewModel(ctx, inputbox.ModelOpts{TextArea: &ta})
+	body.SetValue(opts.PR.Body)
+	body.Blur()
+
+	pickerInput := inputbox.DefaultTextInput(ctx)
+	picker := cmpcontroller.New(ctx, inputbox.ModelOpts{TextInput: &pickerInput})
+	picker.SetSelectStyles(ctx.Styles.Select)
+
+	h := help.New()
+	h.Styles = ctx.Styles.Help.BubbleStyles
+	return Model{
+		ctx:    ctx,
+		opts:   opts,
+		title:  title,
+		body:   body,
+		picker: picker,
+		values: map[field]string{
+			baseField:      opts.PR.Base,
+			reviewersField: strings.Join(opts.PR.Reviewers, " "),
+			assigneesField: strings.Join(opts.PR.Assignees, " "),
+			labelsField:    strings.Join(opts.PR.Labels, ", "),
+		},
+		draft:   opts.PR.Draft,
+		focused: titleField,
+		help:    h,
+	}
+}
+
+// IsSubmitted returns whether the PR was confirmed in the preview, to be
+// created with PR
+func (m *Model) IsSubmitted() bool {
+	return m.isSubmitted
+}
+
+// IsCancelled returns whether the form was closed without creating the PR
+func (m *Model) IsCancelled() bool {
+	return m.isCancelled
+}
+
+// PR returns the PR to create, as it's filled in
+func (m *Model) PR() tasks.CreatePROptions {
+	pr := m.opts.PR
+	pr.Title = strings.TrimSpace(m.title.Value())
+	pr.Body = strings.TrimSpace(m.body.Value())
+	pr.Base = strings.TrimSpace(m.value(baseField))
+	pr.Draft = m.draft
+	pr.Reviewers = fuzzyselect.AllWords(m.value(reviewersField))
+	pr.Assignees = fuzzyselect.AllWords(m.value(assigneesField))
+	pr.Labels = fuzzyselect.CurrentLabels(m.value(labelsField))
+	return pr
+}
+
+// value returns the value of a field of the picker, which is the picker's
+// own while it's focused
+func (m *Model) value(f field) string {
+	if f == m.focused {
+		return m.picker.Value()
+	}
+	return m.values[f]
+}
+
+func isPickerField(f field) bool {
+	return f == baseField || f == reviewersField || f == assigneesField || f == labelsField
+}
+
+// Focus focuses the field the form was left on, its title when it was just
+// opened
+func (m *Model) Focus() tea.Cmd {
+	return m.focus(m.focused)
+}
+
+func (m *Model) focus(f field) tea.Cmd {
+	switch {
+	case m.focused == titleField:
+		m.title.Blur()
+	case m.focused == bodyField:
+		m.body.Blur()
+	case isPickerField(m.focused) && m.picker.Active():
+		m.values[m.focused] = m.picker.Value()
+		m.picker.Exit()
+	}
+	m.focused = f
+
+	switch f {
+	case titleField:
+		return m.title.Focus()
+	case bodyField:
+		return m.body.Focus()
+	case draftField:
+		return nil
+	}
+
+	var src fuzzyselect.Source
+	switch f {
+	case baseField:
+		src = &fuzzyselect.BranchSource{Branches: m.opts.Bases}
+	case labelsField:
+		src = &fuzzyselect.LabelSource{}
+	default:
+		src = &fuzzyselect.UserMentionSource{WithAtSymbol: false}
+	}
+	m.picker.SetAutocompleteSource(src)
+	return m.picker.Enter(cmpcontroller.EnterOptions{
+		Mode:         cmpcontroller.ModeCreatePR,
+		InitialValue: m.values[f],
+		Repo: cmpcontroller.RepoRef{
+			NameWithOwner: m.opts.Owner + "/" + m.opts.Name,
+			Owner:         m.opts.Owner,
+			Name:          m.opts.Name,
+		},
+		EnterFetch:                       cmpcontroller.FetchSilent,
+		HideAutocompleteWhenContextEmpty: true,
+	})
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmds []tea.Cmd
+		if m.picker.Active() {
+			cmd, _ := m.picker.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+		switch m.focused {
+		case titleField:
+			var cmd tea.Cmd
+			m.title, cmd = m.title.Update(msg)
+			cmds = append(cmds, cmd)
+		case bodyField:
+			var cmd tea.Cmd
+			m.body, cmd = m.body.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+	}
+
+	if m.isPreviewing {
+		switch {
+		case key.Matches(keyMsg, submitKey):
+			m.isSubmitted = true
+		case key.Matches(keyMsg, editKey):
+			m.isPreviewing = false
+			return m, m.focus(m.focused)
+		case key.Matches(keyMsg, cancelKey):
+			m.isCancelled = true
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, cancelKey):
+		m.isCancelled = true
+		return m, nil
+	case key.Matches(keyMsg, nextFieldKey):
+		return m, m.focus((m.focused + 1) % fieldCount)
+	case key.Matches(keyMsg, prevFieldKey):
+		return m, m.focus((m.focused + fieldCount - 1) % fieldCount)
+	case key.Matches(keyMsg, previewKey):
+		if strings.TrimSpace(m.title.Value()) == "" {
+			m.err = fmt.Errorf("the PR needs a title")
+			return m, m.focus(titleField)
+		}
+		if isPickerField(m.focused) {
+			m.values[m.focused] = m.picker.Value()
+		}
+		m.err = nil
+		m.isPreviewing = true
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	switch m.focused {
+	case titleField:
+		m.title, cmd = m.title.Update(msg)
+	case bodyField:
+		m.body, cmd = m.body.Update(msg)
+	case draftField:
+		if key.Matches(keyMsg, toggleDraftKey) {
+			m.draft = !m.draft
+		}
+	default:
+		cmd, _ = m.picker.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	m.title.SetWidth(width)
+	m.body.SetWidth(width)
+	m.picker.SetWidth(width)
+}
+
+func (m *Model) UpdateProgramContext(ctx *context.ProgramContext) {
+	m.ctx = ctx
+	m.title.UpdateProgramContext(ctx)
+	m.body.UpdateProgramContext(ctx)
+	m.picker.UpdateProgramContext(ctx)
+	m.help.Styles = ctx.Styles.Help.BubbleStyles
+}
+
+func (m Model) View() string {
+	if m.isPreviewing {
+		return m.viewPreview()
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+	focusedStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+	label := func(f field) string {
+		if f == m.focused {
+			return focusedStyle.Render(fieldNames[f])
+		}
+		return labelStyle.Render(fieldNames[f])
+	}
+	// inlineLabel is the label of a field shown on the same line as its
+	// value
+	inlineLabel := func(f field) string {
+		return label(f) + strings.Repeat(" ", max(11-len(fieldNames[f]), 1))
+	}
+
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(
+			fmt.Sprintf("Create a PR from %s", m.opts.PR.Head)),
+		"",
+		label(titleField),
+		m.title.View(),
+		"",
+		label(bodyField),
+		m.body.View(),
+		"",
+	}
+	for _, f := range []field{baseField, draftField, reviewersField, assigneesField, labelsField} {
+		switch {
+		case f == draftField:
+			checkbox := "[ ]"
+			if m.draft {
+				checkbox = "[x]"
+			}
+			lines = append(lines, inlineLabel(f)+checkbox)
+		case f == m.focused:
+			lines = append(lines, label(f), m.picker.View())
+			if completions := m.picker.ViewCompletions(); completions != "" {
+				lines = append(lines, completions)
+			}
+		default:
+			lines = append(lines, inlineLabel(f)+m.values[f])
+		}
+	}
+
+	if m.err != nil {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText).Render(
+			m.err.Error()))
+	}
+	bindings := []key.Binding{nextFieldKey, prevFieldKey, previewKey, cancelKey}
+	switch {
+	case m.focused == draftField:
+		bindings = append([]key.Binding{toggleDraftKey}, bindings...)
+	case isPickerField(m.focused):
+		bindings = append(bindings, keys.CmpKeys.SelectKey)
+	}
+	lines = append(lines, "", m.help.ShortHelpView(bindings))
+	return strings.Join(lines, "\n")
+}
+
+// viewPreview shows the PR as it will be created, with its body rendered
+// like the PR view renders it
+func (m Model) viewPreview() string {
+	pr := m.PR()
+	labelStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText)
+
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Render(pr.Title),
+		labelStyle.Render(fmt.Sprintf("%s → %s", pr.Head, pr.Base)),
+	}
+	if pr.Draft {
+		lines = append(lines, labelStyle.Render("Draft"))
+	}
+	for _, f := range []struct {
+		name   string
+		values []string
+	}{
+		{"Reviewers", pr.Reviewers},
+		{"Assignees", pr.Assignees},
+		{"Labels", pr.Labels},
+	} {
+		if len(f.values) > 0 {
+			lines = append(lines, labelStyle.Render(fmt.Sprintf("%-11s", f.name))+
+				strings.Join(f.values, ", "))
+		}
+	}
+
+	body := labelStyle.Italic(true).Render("No description provided.")
+	if pr.Body != "" {
+		renderer := markdown.GetMarkdownRenderer(m.width, m.ctx)
+		rendered, err := renderer.Render(pr.Body)
+		if err == nil {
+			body = rendered
+		}
+	}
+	lines = append(lines, "", body, m.help.ShortHelpView([]key.Binding{submitKey, editKey}))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,188 @@
+package prform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/theme"
+)
+
+func TestPrefill(t *testing.T) {
+	testCases := []struct {
+		name      string
+		branch    string
+		commits   []git.CommitMessage
+		template  string
+		wantTitle string
+		wantBody  string
+	}{
+		{
+			name:      "single commit",
+			branch:    "fix-crash",
+			commits:   []git.CommitMessage{{Subject: "Fix the crash", Body: "It crashed"}},
+			wantTitle: "Fix the crash",
+			wantBody:  "It crashed",
+		},
+		{
+			name:   "multiple commits",
+			branch: "add_search-bar",
+			commits: []git.CommitMessage{
+				{Subject: "Add the search bar"},
+				{Subject: "Style it"},
+			},
+			wantTitle: "Add search bar",
+			wantBody:  "- Add the search bar\n- Style it",
+		},
+		{
+			name:      "template after the commits",
+			branch:    "fix-crash",
+			commits:   []git.CommitMessage{{Subject: "Fix the crash", Body: "It crashed"}},
+			template:  "## Checklist\n",
+			wantTitle: "Fix the crash",
+			wantBody:  "It crashed\n\n## Checklist",
+		},
+		{
+			name:      "template without commits",
+			branch:    "wip",
+			template:  "## Checklist",
+			wantTitle: "Wip",
+			wantBody:  "## Checklist",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			title, body := Prefill(tc.branch, tc.commits, tc.template)
+			require.Equal(t, tc.wantTitle, title)
+			require.Equal(t, tc.wantBody, body)
+		})
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestFindTemplate(t *testing.T) {
+	t.Run("no template", func(t *testing.T) {
+		require.Empty(t, FindTemplate(t.TempDir()))
+	})
+
+	t.Run("github dir is preferred, names in any case", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "docs", "pull_request_template.md"), "docs")
+		writeFile(t, filepath.Join(dir, ".github", "PULL_REQUEST_TEMPLATE.md"), "github")
+		require.Equal(t, "github", FindTemplate(dir))
+	})
+
+	t.Run("first of a directory of templates", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, ".github", "PULL_REQUEST_TEMPLATE", "b.md"), "b")
+		writeFile(t, filepath.Join(dir, ".github", "PULL_REQUEST_TEMPLATE", "a.md"), "a")
+		require.Equal(t, "a", FindTemplate(dir))
+	})
+}
+
+func testCtx(t *testing.T) *context.ProgramContext {
+	t.Helper()
+	cfg, err := config.ParseConfig(config.Location{
+		ConfigFlag:       "../../../config/testdata/test-config.yml",
+		SkipGlobalConfig: true,
+	})
+	require.NoError(t, err)
+
+	thm := theme.ParseTheme(&cfg)
+	return &context.ProgramContext{
+		Config: &cfg,
+		Theme:  thm,
+		Styles: context.InitStyles(thm),
+	}
+}
+
+func pressKey(m Model, k tea.KeyPressMsg) Model {
+	m, _ = m.Update(k)
+	return m
+}
+
+func typeText(m Model, text string) Model {
+	for _, r := range text {
+		m = pressKey(m, tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return m
+}
+
+func TestForm(t *testing.T) {
+	m := NewModel(testCtx(t), Options{
+		PR: tasks.CreatePROptions{
+			Repo:  "owner/repo",
+			Head:  "feat",
+			Base:  "main",
+			Title: "Add feat",
+			Body:  "Adds feat",
+		},
+		Owner: "owner",
+		Name:  "repo",
+		Bases: []string{"main", "release"},
+	})
+	m.SetWidth(60)
+	m.Focus()
+	require.Contains(t, m.View(), "Create a PR from feat")
+
+	tab := tea.KeyPressMsg{Code: tea.KeyTab}
+	m = pressKey(m, tab) // body
+	m = pressKey(m, tab) // base
+	require.Equal(t, baseField, m.focused)
+	require.Equal(t, "main", m.picker.Value())
+
+	m = pressKey(m, tab) // draft
+	m = pressKey(m, tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	require.True(t, m.draft)
+
+	m = pressKey(m, tab) // reviewers
+	m = typeText(m, "alice bob")
+	m = pressKey(m, tab) // assignees
+	m = pressKey(m, tab) // labels
+	m = typeText(m, "bug, docs")
+
+	m = pressKey(m, tea.KeyPressMsg{Code: 'd', Mod: tea.ModCtrl})
+	require.True(t, m.isPreviewing)
+	view := m.View()
+	require.Contains(t, view, "feat → main")
+	require.Contains(t, view, "alice, bob")
+	require.Contains(t, view, "Adds")
+
+	m = pressKey(m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.True(t, m.IsSubmitted())
+	require.Equal(t, tasks.CreatePROptions{
+		Repo:      "owner/repo",
+		Head:      "feat",
+		Base:      "main",
+		Title:     "Add feat",
+		Body:      "Adds feat",
+		Draft:     true,
+		Reviewers: []string{"alice", "bob"},
+		Labels:    []string{"bug", "docs"},
+	}, m.PR())
+}
+
+func TestFormNeedsTitle(t *testing.T) {
+	m := NewModel(testCtx(t), Options{PR: tasks.CreatePROptions{Head: "feat"}})
+	m.Focus()
+
+	m = pressKey(m, tea.KeyPressMsg{Code: 'd', Mod: tea.ModCtrl})
+	require.False(t, m.isPreviewing)
+	require.Contains(t, m.View(), "the PR needs a title")
+
+	m = pressKey(m, tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.True(t, m.IsCancelled())
+}
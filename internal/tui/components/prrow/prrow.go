@@ -8,6 +8,7 @@ import (
 	"charm.land/lipgloss/v2/compat"
 	checks "github.com/dlvhdr/x/gh-checks"
 
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/git"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components"
@@ -23,6 +24,8 @@ type PullRequest struct {
 	Branch         git.Branch
 	Columns        []table.Column
 	ShowAuthorIcon bool
+	// SeenIn holds the titles of the other sections showing this PR.
+	SeenIn []string
 }
 
 func (pr *PullRequest) getTextStyle() lipgloss.Style {
@@ -182,7 +185,8 @@ func (pr *PullRequest) renderTitle() string {
 		pr.Data.Primary.State,
 		pr.Data.Primary.Title,
 		pr.Data.Primary.Number,
-	)
+	) + components.RenderHostBadge(pr.Ctx, data.RowHost(pr.Data.Primary)) +
+		components.RenderSeenElsewhere(pr.Ctx, pr.SeenIn)
 }
 
 func (pr *PullRequest) renderExtendedTitle(isSelected bool) string {
@@ -201,6 +205,8 @@ func (pr *PullRequest) renderExtendedTitle(isSelected bool) string {
 		branch := baseStyle.Render(pr.Data.Primary.HeadRefName)
 		top = lipgloss.JoinHorizontal(lipgloss.Top, top, baseStyle.Render(" · "), branch)
 	}
+	top += components.RenderHostBadge(pr.Ctx, data.RowHost(pr.Data.Primary))
+	top += components.RenderSeenElsewhere(pr.Ctx, pr.SeenIn)
 	title := pr.Data.Primary.Title
 	var titleColumn table.Column
 	for _, column := range pr.Columns {
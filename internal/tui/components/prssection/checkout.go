@@ -3,12 +3,11 @@ package prssection
 import (
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
-	"strings"
 
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
@@ -20,8 +19,8 @@ func (m *Model) checkout() (tea.Cmd, error) {
 		return nil, errors.New("no pr selected")
 	}
 
-	repoName := pr.GetRepoNameWithOwner()
-	repoPath, ok := common.GetRepoLocalPath(repoName, m.Ctx.Config.RepoPaths)
+	repoName := data.RepoArg(pr)
+	repoPath, ok := common.ResolveRepoLocalPath(repoName, m.Ctx.Config.RepoPaths)
 
 	if !ok {
 		return nil, errors.New(
@@ -30,27 +29,34 @@ func (m *Model) checkout() (tea.Cmd, error) {
 	}
 
 	prNumber := pr.GetNumber()
+	repoPath = common.ExpandHomeDir(repoPath)
+	worktrees := m.Ctx.Config.Worktrees
+	checkoutPath := repoPath
+	if worktrees.Enabled {
+		checkoutPath = common.GetWorktreePath(worktrees.Dir, repoName, repoPath, prNumber)
+	}
+
 	taskId := fmt.Sprintf("checkout_%d", prNumber)
 	task := context.Task{
 		Id:           taskId,
 		StartText:    fmt.Sprintf("Checking out PR #%d", prNumber),
-		FinishedText: fmt.Sprintf("PR #%d has been checked out at %s", prNumber, repoPath),
+		FinishedText: fmt.Sprintf("PR #%d has been checked out at %s", prNumber, checkoutPath),
 		State:        context.TaskStart,
 		Error:        nil,
 	}
 	startCmd := m.Ctx.StartTask(task)
 	return tea.Batch(startCmd, func() tea.Msg {
+		if worktrees.Enabled {
+			err := common.CheckoutPRWorktree(repoPath, checkoutPath, prNumber)
+			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
+		}
+
 		c := exec.Command(
 			"gh",
 			"pr",
 			"checkout",
-			fmt.Sprint(m.GetCurrRow().GetNumber()),
+			fmt.Sprint(prNumber),
 		)
-		userHomeDir, _ := os.UserHomeDir()
-		if strings.HasPrefix(repoPath, "~") {
-			repoPath = strings.Replace(repoPath, "~", userHomeDir, 1)
-		}
-
 		c.Dir = repoPath
 		err := c.Run()
 		return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
@@ -3,6 +3,7 @@ package prssection
 import (
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
 )
 
@@ -14,7 +15,7 @@ func (m Model) diff() tea.Cmd {
 
 	return common.DiffPR(
 		currRowData.GetNumber(),
-		currRowData.GetRepoNameWithOwner(),
+		data.RepoArg(currRowData),
 		m.Ctx.Config.GetFullScreenDiffPagerEnv(),
 	)
 }
@@ -157,7 +157,7 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 
 	case tasks.UpdatePRMsg:
 		for i, currPr := range m.Prs {
-			if currPr.Primary.Number != msg.PrNumber {
+			if !msg.IsFor(currPr.Primary.Number, currPr.Primary.Url) {
 				continue
 			}
 
@@ -198,6 +198,7 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 
 	case SectionPullRequestsFetchedMsg:
 		if m.LastFetchTaskId == msg.TaskId {
+			reuseEnrichments(msg.Prs)
 			if m.PageInfo != nil {
 				m.Prs = append(m.Prs, msg.Prs...)
 			} else {
@@ -208,7 +209,7 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			m.SetIsLoading(false)
 			m.Table.SetRows(m.BuildRows())
 			m.Table.UpdateLastUpdated(time.Now())
-			m.UpdateTotalItemsCount(m.TotalCount)
+			m.UpdateTotalItemsCount(m.GetTotalCount())
 		}
 	}
 
@@ -227,7 +228,7 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 
 func (m *Model) EnrichPR(data data.EnrichedPullRequestData) {
 	for i, currPr := range m.Prs {
-		if currPr.Primary.Number != data.Number {
+		if currPr.Primary.Url != data.Url {
 			continue
 		}
 
@@ -406,11 +407,12 @@ func GetSectionColumns(
 func (m Model) BuildRows() []table.Row {
 	var rows []table.Row
 	currItem := m.Table.GetCurrItem()
-	for i, currPr := range m.Prs {
+	for i, currPr := range m.visiblePrs() {
 		prModel := prrow.PullRequest{
 			Ctx:     m.Ctx,
 			Data:    &currPr,
 			Columns: m.Table.Columns, ShowAuthorIcon: m.ShowAuthorIcon,
+			SeenIn: m.seenElsewhere(currPr.GetUrl()),
 		}
 		rows = append(
 			rows,
@@ -426,7 +428,7 @@ func (m Model) BuildRows() []table.Row {
 }
 
 func (m *Model) NumRows() int {
-	return len(m.Prs)
+	return len(m.visiblePrs())
 }
 
 type SectionPullRequestsFetchedMsg struct {
@@ -438,10 +440,11 @@ type SectionPullRequestsFetchedMsg struct {
 
 func (m *Model) GetCurrRow() data.RowData {
 	idx := m.Table.GetCurrItem()
-	if idx < 0 || idx >= len(m.Prs) {
+	prs := m.visiblePrs()
+	if idx < 0 || idx >= len(prs) {
 		return nil
 	}
-	pr := m.Prs[idx]
+	pr := prs[idx]
 	return &pr
 }
 
@@ -454,6 +457,19 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 		return nil
 	}
 
+	taskId, req, cmds := m.startFetch()
+	fetchCmd := func() tea.Msg {
+		res, err := data.FetchPullRequests(req.Host, req.Query, req.Limit, req.PageInfo)
+		return m.fetchedMsg(taskId, res, err)
+	}
+	cmds = append(cmds, fetchCmd)
+
+	return cmds
+}
+
+// startFetch marks the section as loading and starts the task tracking the
+// fetch of its next page.
+func (m *Model) startFetch() (string, data.SearchRequest, []tea.Cmd) {
 	var cmds []tea.Cmd
 
 	startCursor := time.Now().String()
@@ -476,47 +492,51 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 	startCmd := m.Ctx.StartTask(task)
 	cmds = append(cmds, startCmd)
 
-	fetchCmd := func() tea.Msg {
-		limit := m.Config.Limit
-		if limit == nil {
-			limit = &m.Ctx.Config.Defaults.PrsLimit
-		}
+	limit := m.Config.Limit
+	if limit == nil {
+		limit = &m.Ctx.Config.Defaults.PrsLimit
+	}
+	req := data.SearchRequest{
+		Host:     m.Config.Host,
+		Query:    m.GetFilters(),
+		Limit:    *limit,
+		PageInfo: m.PageInfo,
+	}
 
-		res, err := data.FetchPullRequests(m.GetFilters(), *limit, m.PageInfo)
-		if err != nil {
-			return constants.TaskFinishedMsg{
-				SectionId:   m.Id,
-				SectionType: m.Type,
-				TaskId:      taskId,
-				Err:         err,
-			}
-		}
+	m.IsLoading = true
+	if isFirstFetch {
+		m.SetIsLoading(true)
+		cmds = append(cmds, m.Table.StartLoadingSpinner())
+	}
 
-		prs := make([]prrow.Data, 0)
-		for _, pr := range res.Prs {
-			prs = append(prs, prrow.Data{Primary: &pr})
-		}
+	return taskId, req, cmds
+}
+
+func (m *Model) fetchedMsg(taskId string, res data.PullRequestsResponse, err error) tea.Msg {
+	if err != nil {
 		return constants.TaskFinishedMsg{
 			SectionId:   m.Id,
 			SectionType: m.Type,
 			TaskId:      taskId,
-			Msg: SectionPullRequestsFetchedMsg{
-				Prs:        prs,
-				TotalCount: res.TotalCount,
-				PageInfo:   res.PageInfo,
-				TaskId:     taskId,
-			},
+			Err:         err,
 		}
 	}
-	cmds = append(cmds, fetchCmd)
 
-	m.IsLoading = true
-	if isFirstFetch {
-		m.SetIsLoading(true)
-		cmds = append(cmds, m.Table.StartLoadingSpinner())
+	prs := make([]prrow.Data, 0)
+	for _, pr := range res.Prs {
+		prs = append(prs, prrow.Data{Primary: &pr})
+	}
+	return constants.TaskFinishedMsg{
+		SectionId:   m.Id,
+		SectionType: m.Type,
+		TaskId:      taskId,
+		Msg: SectionPullRequestsFetchedMsg{
+			Prs:        prs,
+			TotalCount: res.TotalCount,
+			PageInfo:   res.PageInfo,
+			TaskId:     taskId,
+		},
 	}
-
-	return cmds
 }
 
 func (m *Model) ResetRows() {
@@ -530,6 +550,9 @@ func FetchAllSections(
 ) (sections []section.Section, fetchAllCmd tea.Cmd) {
 	fetchPRsCmds := make([]tea.Cmd, 0, len(ctx.Config.PRSections))
 	sections = make([]section.Section, 0, len(ctx.Config.PRSections))
+	sectionModels := make([]*Model, 0, len(ctx.Config.PRSections))
+	taskIds := make([]string, 0, len(ctx.Config.PRSections))
+	reqs := make([]data.SearchRequest, 0, len(ctx.Config.PRSections))
 	for i, sectionConfig := range ctx.Config.PRSections {
 		sectionModel := NewModel(
 			i+1, // 0 is the search section
@@ -547,10 +570,30 @@ func FetchAllSections(
 			sectionModel.ShowAuthorIcon = !*sectionConfig.Layout.AuthorIcon.Hidden
 		}
 		sections = append(sections, &sectionModel)
-		fetchPRsCmds = append(
-			fetchPRsCmds,
-			sectionModel.FetchNextPageSectionRows()...)
+
+		taskId, req, cmds := sectionModel.startFetch()
+		fetchPRsCmds = append(fetchPRsCmds, cmds...)
+		sectionModels = append(sectionModels, &sectionModel)
+		taskIds = append(taskIds, taskId)
+		reqs = append(reqs, req)
 	}
+
+	if len(reqs) == 0 {
+		return sections, tea.Batch(fetchPRsCmds...)
+	}
+
+	// Fetch all sections together and fan the results back out to each
+	// section's own task.
+	fetchPRsCmds = append(fetchPRsCmds, func() tea.Msg {
+		results := data.FetchPullRequestsBatch(reqs)
+		msgs := make(tea.BatchMsg, 0, len(results))
+		for i, res := range results {
+			msg := sectionModels[i].fetchedMsg(taskIds[i], res.Response, res.Err)
+			msgs = append(msgs, func() tea.Msg { return msg })
+		}
+		return msgs
+	})
+
 	return sections, tea.Batch(fetchPRsCmds...)
 }
 
@@ -591,7 +634,7 @@ func (m Model) GetItemPluralForm() string {
 }
 
 func (m Model) GetTotalCount() int {
-	return m.TotalCount
+	return m.TotalCount - m.hiddenCount()
 }
 
 func (m *Model) SetIsLoading(val bool) {
@@ -607,14 +650,14 @@ func (m Model) GetPagerContent() string {
 	} else {
 		timeElapsed = fmt.Sprintf("~%v ago", timeElapsed)
 	}
-	if m.TotalCount > 0 {
+	if totalCount := m.GetTotalCount(); totalCount > 0 {
 		pagerContent = fmt.Sprintf(
 			"%v Updated %v • %v %v/%v (fetched %v)",
 			constants.WaitingIcon,
 			timeElapsed,
 			m.SingularForm,
 			m.Table.GetCurrItem()+1,
-			m.TotalCount,
+			totalCount,
 			len(m.Table.Rows),
 		)
 	}
@@ -130,3 +130,30 @@ func TestConfirmation_AllActions(t *testing.T) {
 		})
 	}
 }
+
+func TestVisiblePrs_Dedupe(t *testing.T) {
+	store := data.GetRowStore()
+	earlier := data.SectionKey{Type: SectionType, Id: 1}
+	store.SetSectionRows(earlier, []string{"https://github.com/o/r/pull/1"})
+	t.Cleanup(func() {
+		store.SetSectionRows(earlier, nil)
+	})
+
+	dedupe := true
+	m := Model{
+		BaseModel: section.BaseModel{Id: 2, Type: SectionType, TotalCount: 5},
+		Prs: []prrow.Data{
+			{Primary: &data.PullRequestData{Number: 1, Url: "https://github.com/o/r/pull/1"}},
+			{Primary: &data.PullRequestData{Number: 2, Url: "https://github.com/o/r/pull/2"}},
+		},
+	}
+	require.Len(t, m.visiblePrs(), 2, "rows are kept unless dedupe is enabled")
+	require.Equal(t, 5, m.GetTotalCount())
+
+	m.Config.Dedupe = &dedupe
+	prs := m.visiblePrs()
+	require.Len(t, prs, 1)
+	require.Equal(t, 2, prs[0].GetNumber())
+	require.Equal(t, 1, m.NumRows())
+	require.Equal(t, 4, m.GetTotalCount(), "the count excludes the hidden rows")
+}
@@ -0,0 +1,88 @@
+package prssection
+
+import (
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prrow"
+)
+
+func (m *Model) rowStoreKey() data.SectionKey {
+	return data.SectionKey{Type: m.Type, Id: m.Id}
+}
+
+// isDeduped returns true if the section hides PRs already shown by an earlier
+// section. The search section never does.
+func (m *Model) isDeduped() bool {
+	return m.Id > 0 && m.Config.Dedupe != nil && *m.Config.Dedupe
+}
+
+// visiblePrs returns the PRs displayed by the section.
+func (m *Model) visiblePrs() []prrow.Data {
+	if !m.isDeduped() {
+		return m.Prs
+	}
+
+	store := data.GetRowStore()
+	key := m.rowStoreKey()
+	prs := make([]prrow.Data, 0, len(m.Prs))
+	for _, pr := range m.Prs {
+		if !store.IsInEarlierSection(key, pr.GetUrl()) {
+			prs = append(prs, pr)
+		}
+	}
+	return prs
+}
+
+// hiddenCount returns the number of fetched PRs hidden because an earlier
+// section shows them.
+func (m *Model) hiddenCount() int {
+	return len(m.Prs) - len(m.visiblePrs())
+}
+
+// seenElsewhere returns the titles of the other sections showing the PR.
+func (m *Model) seenElsewhere(url string) []string {
+	if m.Id == 0 || !m.Ctx.Config.Theme.Ui.Table.ShowSeenElsewhere {
+		return nil
+	}
+
+	var titles []string
+	for _, id := range data.GetRowStore().OtherSections(m.rowStoreKey(), url) {
+		if id > 0 && id <= len(m.Ctx.Config.PRSections) {
+			titles = append(titles, m.Ctx.Config.PRSections[id-1].Title)
+		}
+	}
+	return titles
+}
+
+// SyncRowStore records the PRs displayed by the section in the shared row
+// store. Sections must be synced in order, as deduped sections depend on the
+// rows of the sections before them.
+func (m *Model) SyncRowStore() {
+	if m.Id == 0 {
+		return
+	}
+
+	prs := m.visiblePrs()
+	urls := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		urls = append(urls, pr.GetUrl())
+	}
+	data.GetRowStore().SetSectionRows(m.rowStoreKey(), urls)
+}
+
+func (m *Model) RebuildRows() {
+	m.Table.SetRows(m.BuildRows())
+}
+
+// reuseEnrichments attaches enrichments fetched for other sections, as long
+// as the PR hasn't been updated since.
+func reuseEnrichments(prs []prrow.Data) {
+	store := data.GetRowStore()
+	for i, pr := range prs {
+		enriched, ok := store.GetEnrichedPullRequest(pr.GetUrl())
+		if !ok || enriched.UpdatedAt.Before(pr.GetUpdatedAt()) {
+			continue
+		}
+		prs[i].Enriched = enriched
+		prs[i].IsEnriched = true
+	}
+}
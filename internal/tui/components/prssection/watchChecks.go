@@ -9,6 +9,7 @@ import (
 	"charm.land/log/v2"
 	"github.com/gen2brain/beeep"
 
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prrow"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
@@ -23,7 +24,7 @@ func (m *Model) watchChecks() tea.Cmd {
 
 	prNumber := pr.GetNumber()
 	title := pr.GetTitle()
-	repoNameWithOwner := pr.GetRepoNameWithOwner()
+	repoNameWithOwner := data.RepoArg(pr)
 	prData := pr.(*prrow.Data)
 	taskId := fmt.Sprintf("pr_reopen_%d", prNumber)
 	task := context.Task{
@@ -84,6 +85,7 @@ func (m *Model) watchChecks() tea.Cmd {
 			Err:         err,
 			Msg: tasks.UpdatePRMsg{
 				PrNumber: prNumber,
+				PrUrl:    pr.GetUrl(),
 			},
 		}
 	})
@@ -26,6 +26,7 @@ const (
 	PRActionUpdate
 	PRActionSummaryViewMore
 	PRActionApproveWorkflows
+	PRActionFocusCommits
 )
 
 // PRAction represents an action to be performed on a PR.
@@ -69,6 +70,8 @@ func MsgToAction(msg tea.Msg) *PRAction {
 		return &PRAction{Type: PRActionSummaryViewMore}
 	case key.Matches(keyMsg, keys.PRKeys.ApproveWorkflows):
 		return &PRAction{Type: PRActionApproveWorkflows}
+	case key.Matches(keyMsg, keys.PRKeys.FocusCommits):
+		return &PRAction{Type: PRActionFocusCommits}
 	}
 
 	return nil
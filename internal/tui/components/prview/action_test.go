@@ -62,6 +62,7 @@ func TestMsgToActionReturnsCorrectActions(t *testing.T) {
 		{"update key", 'u', PRActionUpdate},
 		{"summary view more key", 'e', PRActionSummaryViewMore},
 		{"approve workflows key", 'V', PRActionApproveWorkflows},
+		{"focus commits key", 'f', PRActionFocusCommits},
 	}
 
 	for _, tc := range testCases {
@@ -184,6 +185,7 @@ func TestPRActionTypes(t *testing.T) {
 		PRActionUpdate,
 		PRActionSummaryViewMore,
 		PRActionApproveWorkflows,
+		PRActionFocusCommits,
 	}
 
 	seen := make(map[PRActionType]bool)
@@ -1,16 +1,194 @@
 package prview
 
 import (
+	"errors"
 	"fmt"
+	"os/exec"
 	"strings"
+	"time"
 
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/keys"
 	"github.com/dlvhdr/gh-dash/v4/internal/utils"
 	checks "github.com/dlvhdr/x/gh-checks"
 )
 
+// commitDiff is the diff of a commit shown under it in the commits tab
+type commitDiff struct {
+	oid       string
+	diff      string
+	err       error
+	isLoading bool
+}
+
+type CommitDiffFetchedMsg struct {
+	Oid  string
+	Diff string
+	Err  error
+}
+
+func (m *Model) commits() []data.AllCommitsNode {
+	if !m.hasData() || !m.pr.Data.IsEnriched {
+		return nil
+	}
+	return m.pr.Data.Enriched.AllCommits.Nodes
+}
+
+// IsCommitsFocused returns whether the commits tab's list gets the keys
+func (m *Model) IsCommitsFocused() bool {
+	return m.isCommitsFocused
+}
+
+// FocusCommits shows the commits tab and moves the keys to its list, to
+// move between the PR's commits and act on one of them
+func (m *Model) FocusCommits() {
+	if len(m.commits()) == 0 {
+		return
+	}
+	m.carousel.SetCursor(2)
+	m.isCommitsFocused = true
+	m.commitCursor = min(m.commitCursor, len(m.commits())-1)
+}
+
+func (m *Model) resetCommits() {
+	m.isCommitsFocused = false
+	m.commitCursor = 0
+	m.commitDiff = nil
+}
+
+func (m Model) updateCommits(msg tea.KeyMsg) (Model, tea.Cmd) {
+	commits := m.commits()
+	if len(commits) == 0 {
+		m.isCommitsFocused = false
+		return m, nil
+	}
+	m.commitCursor = max(min(m.commitCursor, len(commits)-1), 0)
+	commit := commits[m.commitCursor].Commit
+
+	switch {
+	case key.Matches(msg, keys.Keys.Up):
+		m.commitCursor = max(m.commitCursor-1, 0)
+		m.commitDiff = nil
+	case key.Matches(msg, keys.Keys.Down):
+		m.commitCursor = min(m.commitCursor+1, len(commits)-1)
+		m.commitDiff = nil
+	case key.Matches(msg, keys.Keys.FirstLine):
+		m.commitCursor = 0
+		m.commitDiff = nil
+	case key.Matches(msg, keys.Keys.LastLine):
+		m.commitCursor = len(commits) - 1
+		m.commitDiff = nil
+	case key.Matches(msg, keys.PRKeys.ShowCommitDiff):
+		if m.commitDiff != nil && m.commitDiff.oid == commit.Oid {
+			m.commitDiff = nil
+			return m, nil
+		}
+		m.commitDiff = &commitDiff{oid: commit.Oid, isLoading: true}
+		return m, fetchCommitDiff(commit.Oid, data.RepoArg(m.pr.Data.Primary))
+	case key.Matches(msg, keys.PRKeys.Diff):
+		return m, common.DiffCommit(commit.Oid, data.RepoArg(m.pr.Data.Primary),
+			m.ctx.Config.GetFullScreenDiffPagerEnv())
+	case key.Matches(msg, keys.Keys.CopyNumber):
+		return m, m.copyCommitSha(commit.Oid)
+	case key.Matches(msg, keys.PRKeys.Checkout):
+		return m, m.checkoutCommit(commit.Oid, commit.AbbreviatedOid)
+	case key.Matches(msg, keys.PRKeys.PrevSidebarTab):
+		m.resetCommits()
+		m.carousel.MoveLeft()
+	case key.Matches(msg, keys.PRKeys.NextSidebarTab):
+		m.resetCommits()
+		m.carousel.MoveRight()
+	case msg.String() == "esc", key.Matches(msg, keys.PRKeys.FocusCommits):
+		if m.commitDiff != nil {
+			m.commitDiff = nil
+		} else {
+			m.isCommitsFocused = false
+		}
+	}
+	return m, nil
+}
+
+func fetchCommitDiff(oid string, repoName string) tea.Cmd {
+	return func() tea.Msg {
+		out, err := exec.Command("gh", common.CommitDiffArgs(oid, repoName)...).Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			err = errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return CommitDiffFetchedMsg{Oid: oid, Diff: string(out), Err: err}
+	}
+}
+
+func (m *Model) onCommitDiffFetched(msg CommitDiffFetchedMsg) {
+	// The cursor moved on while the diff was fetched
+	if m.commitDiff == nil || m.commitDiff.oid != msg.Oid {
+		return
+	}
+	m.commitDiff = &commitDiff{oid: msg.Oid, diff: msg.Diff, err: msg.Err}
+}
+
+func (m *Model) copyCommitSha(oid string) tea.Cmd {
+	text := fmt.Sprintf("Copied %s to clipboard", oid)
+	var err error
+	if cerr := clipboard.WriteAll(oid); cerr != nil {
+		text = fmt.Sprintf("Failed copying to clipboard %v", cerr)
+		err = errors.New(text)
+	}
+	taskId := fmt.Sprintf("copy_sha_%d", time.Now().Unix())
+	startCmd := m.ctx.StartTask(context.Task{
+		Id:           taskId,
+		StartText:    text,
+		FinishedText: text,
+		State:        context.TaskStart,
+	})
+	return tea.Batch(startCmd, func() tea.Msg {
+		return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
+	})
+}
+
+// checkoutCommit checks out the commit detached in the PR repo's local
+// clone, fetching it from the PR's ref on the clone's remote of the PR's
+// repo when the clone doesn't have it
+func (m *Model) checkoutCommit(oid string, abbreviatedOid string) tea.Cmd {
+	pr := m.pr.Data.Primary
+	repoPath, ok := common.ResolveRepoLocalPath(data.RepoArg(pr), m.ctx.Config.RepoPaths)
+	if !ok {
+		return func() tea.Msg {
+			return constants.ErrMsg{Err: errors.New(
+				"local path to repo not specified, set one in your config.yml under repoPaths",
+			)}
+		}
+	}
+	repoPath = common.ExpandHomeDir(repoPath)
+	ref := fmt.Sprintf("refs/pull/%d/head", pr.GetNumber())
+	host := data.HostFromUrl(pr.GetUrl())
+	nameWithOwner := pr.GetRepoNameWithOwner()
+
+	taskId := fmt.Sprintf("checkout_%s", oid)
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Checking out commit %s", abbreviatedOid),
+		FinishedText: fmt.Sprintf("Commit %s has been checked out detached at %s", abbreviatedOid, repoPath),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		remote := git.FindRemote(repoPath, host, nameWithOwner)
+		err := git.CheckoutCommit(repoPath, remote, ref, oid)
+		return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
+	})
+}
+
 func (m *Model) renderCommits() string {
 	main := m.ctx.Styles.Common.MainTextStyle
 	faint := m.ctx.Styles.Common.FaintTextStyle
@@ -32,14 +210,19 @@ func (m *Model) renderCommits() string {
 	rendered := make([]string, len(commits))
 	for i, commit := range commits {
 		commit := commit.Commit
+		isSelected := m.isCommitsFocused && i == m.commitCursor
 		name := commit.Author.User.Login
 		if name == "" {
 			name = commit.Author.Name
 		}
+		headline := main
+		if isSelected {
+			headline = headline.Background(m.ctx.Theme.SelectedBackground).Bold(true)
+		}
 		left := fmt.Sprintf(
 			"%s %s",
 			faint.Render(constants.VerticalCommitIcon),
-			main.Render(commit.MessageHeadline),
+			headline.Render(commit.MessageHeadline),
 		)
 		right := faint.Render(commit.AbbreviatedOid)
 		wright := lipgloss.Width(right)
@@ -72,6 +255,10 @@ func (m *Model) renderCommits() string {
 			statsStr,
 		)
 		rendered[i] = lipgloss.JoinVertical(lipgloss.Left, title, desc)
+		if isSelected {
+			rendered[i] = lipgloss.JoinVertical(lipgloss.Left, rendered[i],
+				m.renderSelectedCommit(commit.Oid, commit.StatusCheckRollup))
+		}
 	}
 
 	res := heading
@@ -82,9 +269,106 @@ func (m *Model) renderCommits() string {
 		}
 	}
 
+	if len(commits) > 0 {
+		help := fmt.Sprintf("%s to move through the commits", keys.PRKeys.FocusCommits.Help().Key)
+		if m.isCommitsFocused {
+			help = commitsHelp()
+		}
+		res = lipgloss.JoinVertical(lipgloss.Left, res, "", faint.Render(help))
+	}
+
 	return res
 }
 
+// renderSelectedCommit renders the check counts of the commit under the
+// cursor by their state, and its diff when it's shown
+func (m *Model) renderSelectedCommit(oid string, rollup data.StatusCheckRollupStats) string {
+	faint := m.ctx.Styles.Common.FaintTextStyle
+	fainter := lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintBorder)
+
+	details := "No checks"
+	if rollup.Contexts.TotalCount > 0 {
+		details = commitChecksSummary(m.getStatusCheckRollupStats(rollup))
+	}
+	lines := []string{fainter.Render("│ ") + faint.Render(details)}
+
+	if m.commitDiff == nil || m.commitDiff.oid != oid {
+		return strings.Join(lines, "\n")
+	}
+	switch {
+	case m.commitDiff.isLoading:
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top,
+			m.ctx.Styles.Common.WaitingGlyph, " ", faint.Render("Loading the diff...")))
+	case m.commitDiff.err != nil:
+		lines = append(lines, lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText).Render(
+			fmt.Sprintf("Failed fetching the diff: %v", m.commitDiff.err)))
+	default:
+		lines = append(lines, m.renderCommitDiff(m.commitDiff.diff))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func commitChecksSummary(stats checksStats) string {
+	statStrs := make([]string, 0)
+	if stats.failed > 0 {
+		statStrs = append(statStrs, fmt.Sprintf("%d failing", stats.failed))
+	}
+	if stats.inProgress > 0 {
+		statStrs = append(statStrs, fmt.Sprintf("%d in progress", stats.inProgress))
+	}
+	if stats.skipped > 0 {
+		statStrs = append(statStrs, fmt.Sprintf("%d skipped", stats.skipped))
+	}
+	if stats.neutral > 0 {
+		statStrs = append(statStrs, fmt.Sprintf("%d neutral", stats.neutral))
+	}
+	if stats.succeeded > 0 {
+		statStrs = append(statStrs, fmt.Sprintf("%d successful", stats.succeeded))
+	}
+	return strings.Join(statStrs, ", ")
+}
+
+// renderCommitDiff colors the added and removed lines of a unified diff and
+// truncates its lines to the sidebar's width
+func (m *Model) renderCommitDiff(diff string) string {
+	width := m.getIndentedContentWidth()
+	header := m.ctx.Styles.Common.MainTextStyle.Bold(true)
+	hunk := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+	added := lipgloss.NewStyle().Foreground(m.ctx.Theme.SuccessText)
+	removed := lipgloss.NewStyle().Foreground(m.ctx.Theme.ErrorText)
+	unchanged := m.ctx.Styles.Common.MainTextStyle
+
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		line = ansi.Truncate(strings.ReplaceAll(line, "\t", "    "), width, constants.Ellipsis)
+		switch {
+		case strings.HasPrefix(line, "diff --git"), strings.HasPrefix(line, "index "),
+			strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = header.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunk.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = added.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = removed.Render(line)
+		default:
+			lines[i] = unchanged.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func commitsHelp() string {
+	help := []string{
+		fmt.Sprintf("%s show diff", keys.PRKeys.ShowCommitDiff.Help().Key),
+		fmt.Sprintf("%s diff in pager", keys.PRKeys.Diff.Help().Key),
+		fmt.Sprintf("%s copy sha", keys.Keys.CopyNumber.Help().Key),
+		fmt.Sprintf("%s checkout detached", keys.PRKeys.Checkout.Help().Key),
+		"esc back",
+	}
+	return strings.Join(help, " • ")
+}
+
 func (m *Model) commitStateSign(state checks.CommitState) string {
 	switch state {
 	case checks.CommitStateError, checks.CommitStateFailure:
@@ -0,0 +1,120 @@
+package prview
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	checks "github.com/dlvhdr/x/gh-checks"
+)
+
+func makeCommit(oid string, headline string, counts ...data.ContextCountByState) data.AllCommitsNode {
+	var node data.AllCommitsNode
+	node.Commit.Oid = oid
+	node.Commit.AbbreviatedOid = oid[:7]
+	node.Commit.MessageHeadline = headline
+	node.Commit.CommittedDate = time.Now()
+	for _, count := range counts {
+		node.Commit.StatusCheckRollup.Contexts.TotalCount += count.Count
+	}
+	node.Commit.StatusCheckRollup.Contexts.CheckRunCountsByState = counts
+	return node
+}
+
+func newTestModelWithCommits(t *testing.T) Model {
+	t.Helper()
+	m := newTestModelForAction(t)
+	m.width = 80
+	m.pr.Data.Enriched.AllCommits.Nodes = []data.AllCommitsNode{
+		makeCommit("1111111aaaaaaa", "Add the parser",
+			data.ContextCountByState{Count: 2, State: checks.CheckRunStateSuccess},
+			data.ContextCountByState{Count: 1, State: checks.CheckRunStateFailure},
+		),
+		makeCommit("2222222bbbbbbb", "Fix the parser"),
+	}
+	return m
+}
+
+func TestFocusCommits(t *testing.T) {
+	m := newTestModelWithCommits(t)
+	m.carousel.SetCursor(2)
+	require.Contains(t, m.View(), "f to move through the commits")
+
+	m.FocusCommits()
+	require.True(t, m.IsCommitsFocused())
+	require.Equal(t, tabs[2], m.SelectedTab())
+	view := m.View()
+	require.Contains(t, view, "2 successful")
+	require.Contains(t, view, "1 failing")
+	require.Contains(t, view, "esc back")
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	require.Equal(t, 1, m.commitCursor)
+	require.Contains(t, m.View(), "No checks")
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	require.Equal(t, 1, m.commitCursor, "the cursor stays on the last commit")
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	require.Equal(t, 0, m.commitCursor)
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.False(t, m.IsCommitsFocused())
+
+	t.Run("without commits", func(t *testing.T) {
+		m := newTestModelForAction(t)
+		m.FocusCommits()
+		require.False(t, m.IsCommitsFocused())
+	})
+
+	t.Run("reset when going back to the first tab", func(t *testing.T) {
+		m := newTestModelWithCommits(t)
+		m.FocusCommits()
+		m, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+		m.GoToFirstTab()
+		require.False(t, m.IsCommitsFocused())
+		require.Equal(t, 0, m.commitCursor)
+	})
+}
+
+func TestCommitDiff(t *testing.T) {
+	m := newTestModelWithCommits(t)
+	m.FocusCommits()
+
+	m, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	require.Contains(t, m.View(), "Loading the diff...")
+
+	// A diff of another commit arriving late is dropped
+	m, _ = m.Update(CommitDiffFetchedMsg{Oid: "2222222bbbbbbb", Diff: "+other"})
+	require.Contains(t, m.View(), "Loading the diff...")
+
+	m, _ = m.Update(CommitDiffFetchedMsg{
+		Oid:  "1111111aaaaaaa",
+		Diff: "diff --git a/parser.go b/parser.go\n@@ -1 +1 @@\n-old line\n+new line\n",
+	})
+	view := m.View()
+	require.Contains(t, view, "-old line")
+	require.Contains(t, view, "+new line")
+
+	// esc closes the diff first and leaves the list after
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.True(t, m.IsCommitsFocused())
+	require.NotContains(t, m.View(), "+new line")
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m, _ = m.Update(CommitDiffFetchedMsg{Oid: "1111111aaaaaaa", Err: errors.New("not found")})
+	require.Contains(t, m.View(), "Failed fetching the diff: not found")
+
+	// Moving the cursor closes the diff
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	require.Nil(t, m.commitDiff)
+}
+
+func TestCommitChecksSummary(t *testing.T) {
+	require.Equal(t, "1 failing, 2 in progress, 3 successful",
+		commitChecksSummary(checksStats{failed: 1, inProgress: 2, succeeded: 3}))
+	require.Empty(t, commitChecksSummary(checksStats{}))
+}
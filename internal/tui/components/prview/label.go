@@ -30,7 +30,7 @@ func (m *Model) label(labels []string) tea.Cmd {
 		"edit",
 		fmt.Sprint(prNumber),
 		"-R",
-		pr.GetRepoNameWithOwner(),
+		data.RepoArg(pr),
 	}
 	labelsMap := make(map[string]bool)
 	for _, label := range labels {
@@ -74,6 +74,7 @@ func (m *Model) label(labels []string) tea.Cmd {
 			Err:         err,
 			Msg: tasks.UpdatePRMsg{
 				PrNumber: prNumber,
+				PrUrl:    pr.GetUrl(),
 				Labels:   &returnedLabels,
 			},
 		}
@@ -40,6 +40,12 @@ type Model struct {
 	carousel        carousel.Model
 	editor          cmpcontroller.Controller
 	summaryViewMore bool
+	stacks          map[string]repoStacks
+	// the commits tab's list, which gets the keys while it's focused to act
+	// on the commit under the cursor
+	isCommitsFocused bool
+	commitCursor     int
+	commitDiff       *commitDiff
 }
 
 var tabs = []string{" Overview", " Activity", " Commits", " Checks", " Files Changed"}
@@ -57,10 +63,24 @@ func NewModel(ctx *context.ProgramContext) Model {
 		pr:       nil,
 		carousel: c,
 		editor:   cmp,
+		stacks:   map[string]repoStacks{},
 	}
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case StackFetchedMsg:
+		m.onStackFetched(msg)
+		return m, nil
+	case CommitDiffFetchedMsg:
+		m.onCommitDiffFetched(msg)
+		return m, nil
+	case tea.KeyMsg:
+		if m.isCommitsFocused {
+			return m.updateCommits(msg)
+		}
+	}
+
 	cmd, handled := m.editor.Update(msg)
 
 	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+d" {
@@ -189,6 +209,12 @@ func (m *Model) viewOverviewTab() string {
 		body.WriteString("\n\n")
 	}
 
+	stack := m.renderStack()
+	if stack != "" {
+		body.WriteString(stack)
+		body.WriteString("\n\n")
+	}
+
 	body.WriteString(m.renderSummary())
 	body.WriteString("\n\n")
 	body.WriteString(
@@ -226,15 +252,15 @@ func (m *Model) renderFullNameAndNumber() string {
 		return ""
 	}
 
-	return common.RenderPreviewHeader(
-		m.ctx.Theme,
-		m.width,
-		fmt.Sprintf(
-			"%s · #%d",
-			m.pr.Data.Primary.GetRepoNameWithOwner(),
-			m.pr.Data.Primary.GetNumber(),
-		),
+	header := fmt.Sprintf(
+		"%s · #%d",
+		m.pr.Data.Primary.GetRepoNameWithOwner(),
+		m.pr.Data.Primary.GetNumber(),
 	)
+	if host := data.RowHost(m.pr.Data.Primary); host != "" {
+		header = fmt.Sprintf("%s %s · %s", constants.HostIcon, host, header)
+	}
+	return common.RenderPreviewHeader(m.ctx.Theme, m.width, header)
 }
 
 func (m *Model) renderTitle() string {
@@ -573,6 +599,12 @@ func (m *Model) EnrichCurrRow() tea.Cmd {
 		return nil
 	}
 	url := m.pr.Data.Primary.Url
+	if d, ok := data.GetRowStore().GetEnrichedPullRequest(url); ok &&
+		!d.UpdatedAt.Before(m.pr.Data.Primary.UpdatedAt) {
+		return func() tea.Msg {
+			return EnrichedPrMsg{Id: m.sectionId, Type: prssection.SectionType, Data: d}
+		}
+	}
 	return func() tea.Msg {
 		d, err := data.FetchPullRequest(url)
 		return EnrichedPrMsg{
@@ -750,10 +782,12 @@ func (m *Model) prAssignees() []string {
 
 func (m *Model) GoToFirstTab() {
 	m.carousel.SetCursor(0)
+	m.resetCommits()
 }
 
 func (m *Model) GoToActivityTab() {
 	m.carousel.SetCursor(1) // Activity is the second tab (index 1)
+	m.resetCommits()
 }
 
 func (m Model) SelectedTab() string {
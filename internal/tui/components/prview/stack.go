@@ -0,0 +1,128 @@
+package prview
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	log "charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+)
+
+// stackTTL is how long the PRs of a repo are reused before its stacks are
+// fetched again
+const stackTTL = 5 * time.Minute
+
+// repoStacks are the stacks of a repo's open PRs, once they're fetched
+type repoStacks struct {
+	loading   bool
+	fetchedAt time.Time
+	stacks    []*data.StackNode
+}
+
+// StackFetchedMsg is sent when the PRs of a repo have been fetched to find
+// the stacks of PRs
+type StackFetchedMsg struct {
+	Repo string
+	PRs  []data.StackPR
+	Err  error
+}
+
+func (m *Model) stackKey() string {
+	return data.RepoArg(m.pr.Data.Primary)
+}
+
+// FetchStack starts fetching the open PRs of the current PR's repo, to find
+// the stack of PRs it belongs to, unless they were fetched recently.
+func (m *Model) FetchStack() tea.Cmd {
+	if m == nil || m.pr == nil || m.pr.Data.Primary == nil {
+		return nil
+	}
+
+	key := m.stackKey()
+	if s, ok := m.stacks[key]; ok && (s.loading || time.Since(s.fetchedAt) < stackTTL) {
+		return nil
+	}
+	m.stacks[key] = repoStacks{loading: true}
+	host := data.RowHost(m.pr.Data.Primary)
+	repo := m.pr.Data.Primary.Repository.NameWithOwner
+	return func() tea.Msg {
+		prs, err := data.FetchStackPRs(host, repo)
+		return StackFetchedMsg{Repo: key, PRs: prs, Err: err}
+	}
+}
+
+func (m *Model) onStackFetched(msg StackFetchedMsg) {
+	if msg.Err != nil {
+		log.Error("Failed fetching stack PRs", "repo", msg.Repo, "err", msg.Err)
+	}
+	// A failed fetch shows no stack until it's retried after stackTTL
+	m.stacks[msg.Repo] = repoStacks{fetchedAt: time.Now(), stacks: data.BuildStacks(msg.PRs)}
+}
+
+func (m *Model) renderStack() string {
+	s, ok := m.stacks[m.stackKey()]
+	if !ok || s.loading {
+		return ""
+	}
+	number := m.pr.Data.Primary.Number
+	stack := data.FindStack(s.stacks, number)
+	if stack == nil {
+		return ""
+	}
+
+	rows := []string{
+		m.ctx.Styles.Common.FaintTextStyle.Render(stack.PR.BaseRefName),
+	}
+	// lines[d] is whether the tree line of depth d continues below
+	lines := []bool{}
+	stack.Walk(func(node *data.StackNode, depth int, isLast bool) {
+		lines = append(lines[:depth], !isLast)
+		prefix := strings.Builder{}
+		for d := range depth {
+			if lines[d] {
+				prefix.WriteString("│  ")
+			} else {
+				prefix.WriteString("   ")
+			}
+		}
+		if isLast {
+			prefix.WriteString("└─ ")
+		} else {
+			prefix.WriteString("├─ ")
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top,
+			m.ctx.Styles.Common.FaintTextStyle.Render(prefix.String()),
+			m.renderStackPR(node.PR, node.PR.Number == number),
+		))
+	})
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.ctx.Styles.Common.MainTextStyle.Underline(true).Bold(true).Render(
+			fmt.Sprintf("%s Stack (%d PRs)", constants.StackIcon, stack.Size())),
+		"",
+		strings.Join(rows, "\n"),
+	)
+}
+
+func (m *Model) renderStackPR(pr data.StackPR, isCurrent bool) string {
+	icon := lipgloss.NewStyle().Foreground(m.ctx.Styles.Colors.OpenPR).Render(constants.OpenIcon)
+	if pr.IsDraft {
+		icon = lipgloss.NewStyle().Foreground(m.ctx.Theme.FaintText).Render(constants.DraftIcon)
+	}
+	textStyle := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText)
+	if isCurrent {
+		textStyle = lipgloss.NewStyle().Foreground(m.ctx.Theme.PrimaryText).Bold(true)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		icon,
+		" ",
+		textStyle.Render(fmt.Sprintf("#%d %s", pr.Number, pr.Title)),
+		m.ctx.Styles.Common.FaintTextStyle.Render(" · "+pr.HeadRefName),
+	)
+}
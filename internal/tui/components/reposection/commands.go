@@ -1,7 +1,11 @@
 package reposection
 
 import (
+	"errors"
 	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,6 +15,8 @@ import (
 
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/git"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/branch"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
@@ -27,7 +33,10 @@ type UpdatePRMsg struct {
 }
 
 func (m *Model) fastForward() (tea.Cmd, error) {
-	b := m.getCurrBranch()
+	b, r, err := m.getCurrBranchAndRepo()
+	if err != nil {
+		return nil, err
+	}
 
 	taskId := fmt.Sprintf("fast-forward_%s_%d", b.Data.Name, time.Now().Unix())
 	task := context.Task{
@@ -40,7 +49,7 @@ func (m *Model) fastForward() (tea.Cmd, error) {
 	startCmd := m.Ctx.StartTask(task)
 	return tea.Batch(startCmd, func() tea.Msg {
 		var err error
-		repo, err := git.GetRepo(m.Ctx.RepoPath)
+		repo, err := git.GetRepo(r.path)
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
@@ -62,7 +71,7 @@ func (m *Model) fastForward() (tea.Cmd, error) {
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
-		repo, err = git.GetRepo(m.Ctx.RepoPath)
+		repo, err = git.GetRepo(r.path)
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
@@ -71,7 +80,7 @@ func (m *Model) fastForward() (tea.Cmd, error) {
 			SectionId:   0,
 			SectionType: SectionType,
 			TaskId:      taskId,
-			Msg:         repoMsg{repo: repo},
+			Msg:         repoMsg{path: r.path, repo: repo},
 			Err:         err,
 		}
 	}), nil
@@ -82,7 +91,13 @@ type pushOptions struct {
 }
 
 func (m *Model) push(opts pushOptions) (tea.Cmd, error) {
-	b := m.getCurrBranch()
+	b, r, err := m.getCurrBranchAndRepo()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.errRebaseInProgress(); err != nil {
+		return nil, err
+	}
 
 	taskId := fmt.Sprintf("push_%s_%d", b.Data.Name, time.Now().Unix())
 	withForceText := func() string {
@@ -108,14 +123,14 @@ func (m *Model) push(opts pushOptions) (tea.Cmd, error) {
 		if len(b.Data.Remotes) == 0 {
 			args = append(args, "--set-upstream")
 			err = gitm.Push(
-				m.Ctx.RepoPath,
+				r.path,
 				"origin",
 				b.Data.Name,
 				gitm.PushOptions{CommandOptions: gitm.CommandOptions{Args: args}},
 			)
 		} else {
 			err = gitm.Push(
-				m.Ctx.RepoPath,
+				r.path,
 				b.Data.Remotes[0],
 				b.Data.Name,
 				gitm.PushOptions{CommandOptions: gitm.CommandOptions{Args: args}},
@@ -124,7 +139,7 @@ func (m *Model) push(opts pushOptions) (tea.Cmd, error) {
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
-		repo, err := git.GetRepo(m.Ctx.RepoPath)
+		repo, err := git.GetRepo(r.path)
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
@@ -133,14 +148,29 @@ func (m *Model) push(opts pushOptions) (tea.Cmd, error) {
 			SectionId:   0,
 			SectionType: SectionType,
 			TaskId:      taskId,
-			Msg:         repoMsg{repo: repo},
+			Msg:         repoMsg{path: r.path, repo: repo},
 			Err:         err,
 		}
 	}), nil
 }
 
 func (m *Model) checkout() (tea.Cmd, error) {
-	b := m.getCurrBranch()
+	b, r, err := m.getCurrBranchAndRepo()
+	if err != nil {
+		return nil, err
+	}
+	if b.Data.WorktreePath != "" {
+		return nil, fmt.Errorf(
+			"branch %s is already checked out in worktree %s", b.Data.Name, b.Data.WorktreePath)
+	}
+	if worktrees := m.Ctx.Config.Worktrees; worktrees.Enabled && b.PR != nil {
+		return m.checkoutWorktree(r, b, common.GetWorktreePath(
+			worktrees.Dir,
+			r.name,
+			r.path,
+			b.PR.Number,
+		)), nil
+	}
 
 	taskId := fmt.Sprintf("checkout_%s_%d", b.Data.Name, time.Now().Unix())
 	task := context.Task{
@@ -152,11 +182,43 @@ func (m *Model) checkout() (tea.Cmd, error) {
 	}
 	startCmd := m.Ctx.StartTask(task)
 	return tea.Batch(startCmd, func() tea.Msg {
-		err := gitm.Checkout(m.Ctx.RepoPath, b.Data.Name)
+		err := gitm.Checkout(r.path, b.Data.Name)
+		if err != nil {
+			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
+		}
+		repo, err := git.GetRepo(r.path)
+		if err != nil {
+			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
+		}
+
+		return constants.TaskFinishedMsg{
+			SectionId:   0,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Msg:         repoMsg{path: r.path, repo: repo, resetSelection: true},
+			Err:         err,
+		}
+	}), nil
+}
+
+// checkoutWorktree checks out a branch in a new worktree at path, instead of
+// switching the branch of the repo's working tree
+func (m *Model) checkoutWorktree(r *localRepo, b *branch.Branch, path string) tea.Cmd {
+	taskId := fmt.Sprintf("checkout_%s_%d", b.Data.Name, time.Now().Unix())
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Checking out branch %s", b.Data.Name),
+		FinishedText: fmt.Sprintf("Branch %s has been checked out at %s", b.Data.Name, path),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		err := git.AddWorktree(r.path, path, b.Data.Name)
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
-		repo, err := git.GetRepo(m.Ctx.RepoPath)
+		repo, err := git.GetRepo(r.path)
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
@@ -165,33 +227,498 @@ func (m *Model) checkout() (tea.Cmd, error) {
 			SectionId:   0,
 			SectionType: SectionType,
 			TaskId:      taskId,
-			Msg:         repoMsg{repo: repo, resetSelection: true},
+			Msg:         repoMsg{path: r.path, repo: repo},
+			Err:         err,
+		}
+	})
+}
+
+// staleWorktree is a worktree of a branch whose PR was merged or closed
+type staleWorktree struct {
+	repo     *localRepo
+	worktree git.Worktree
+}
+
+// getStaleWorktrees returns the worktrees of branches whose PR was merged or
+// closed, in every repo
+func (m *Model) getStaleWorktrees() []staleWorktree {
+	stale := make([]staleWorktree, 0)
+	for _, r := range m.repos {
+		prs := m.repoPrs(r)
+		for _, wt := range r.repo.Worktrees {
+			if wt.IsMain || wt.Branch == "" {
+				continue
+			}
+			pr := findPRForRef(prs, wt.Branch)
+			if pr != nil && (pr.State == "MERGED" || pr.State == "CLOSED") {
+				stale = append(stale, staleWorktree{repo: r, worktree: wt})
+			}
+		}
+	}
+	return stale
+}
+
+// pruneWorktrees removes the worktrees of merged or closed PRs. Worktrees
+// with uncommitted changes are kept and reported as errors.
+func (m *Model) pruneWorktrees() (tea.Cmd, error) {
+	stale := m.getStaleWorktrees()
+	if len(stale) == 0 {
+		return nil, errors.New("no worktrees of merged or closed PRs to remove")
+	}
+
+	taskId := fmt.Sprintf("prune_worktrees_%d", time.Now().Unix())
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Removing %d stale worktrees", len(stale)),
+		FinishedText: fmt.Sprintf("%d stale worktrees have been removed", len(stale)),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		var errs []error
+		repos := make([]*localRepo, 0)
+		for _, s := range stale {
+			if !slices.Contains(repos, s.repo) {
+				repos = append(repos, s.repo)
+			}
+			if err := git.RemoveWorktree(s.repo.path, s.worktree.Path, false); err != nil {
+				errs = append(errs, fmt.Errorf("failed removing worktree %s: %w", s.worktree.Path, err))
+			}
+		}
+		msg := make(reposMsg, 0, len(repos))
+		for _, r := range repos {
+			if err := git.PruneWorktrees(r.path); err != nil {
+				errs = append(errs, err)
+			}
+			repo, err := git.GetRepo(r.path)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			msg = append(msg, repoMsg{path: r.path, repo: repo})
+		}
+
+		return constants.TaskFinishedMsg{
+			SectionId:   0,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Msg:         msg,
+			Err:         errors.Join(errs...),
+		}
+	}), nil
+}
+
+// getPrunableBranches returns the shown branches that were merged and can be
+// deleted, see branch.BranchData.IsPrunable. Branches the search filters out,
+// like those of other repos, are kept.
+func (m *Model) getPrunableBranches() []branch.Branch {
+	prunable := make([]branch.Branch, 0)
+	for _, b := range m.getFilteredBranches() {
+		if (branch.BranchData{Data: b.Data, PR: b.PR}).IsPrunable() {
+			prunable = append(prunable, b)
+		}
+	}
+	return prunable
+}
+
+// pruneMergedBranches deletes the branches of getPrunableBranches
+func (m *Model) pruneMergedBranches() (tea.Cmd, error) {
+	prunable := m.getPrunableBranches()
+	if len(prunable) == 0 {
+		return nil, errors.New("no merged branches to delete")
+	}
+
+	taskId := fmt.Sprintf("prune_merged_%d", time.Now().Unix())
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Deleting %d merged branches", len(prunable)),
+		FinishedText: fmt.Sprintf("%d merged branches have been deleted", len(prunable)),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		var errs []error
+		paths := make([]string, 0)
+		for _, b := range prunable {
+			if !slices.Contains(paths, b.RepoPath) {
+				paths = append(paths, b.RepoPath)
+			}
+			// Squash merged branches aren't merged as far as git can tell, so
+			// they have to be force deleted, but only when that can't lose
+			// commits. Others are deleted only if git knows they're merged.
+			force := (branch.BranchData{Data: b.Data, PR: b.PR}).IsSafeToForceDelete()
+			err := gitm.DeleteBranch(b.RepoPath, b.Data.Name, gitm.DeleteBranchOptions{Force: force})
+			switch {
+			case err != nil && !force:
+				errs = append(errs, fmt.Errorf(
+					"skipped branch %s, it has commits its merged PR doesn't", b.Data.Name))
+			case err != nil:
+				errs = append(errs, fmt.Errorf("failed deleting branch %s: %w", b.Data.Name, err))
+			}
+		}
+		msg := make(reposMsg, 0, len(paths))
+		for _, path := range paths {
+			repo, err := git.GetRepo(path)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			msg = append(msg, repoMsg{path: path, repo: repo})
+		}
+
+		return constants.TaskFinishedMsg{
+			SectionId:   0,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Msg:         msg,
+			Err:         errors.Join(errs...),
+		}
+	}), nil
+}
+
+// restack rebases every branch of the selected branch's stack onto its
+// parent, bottom to top, and the stack's bottom branch onto its base.
+func (m *Model) restack() (tea.Cmd, error) {
+	b, r, err := m.getCurrBranchAndRepo()
+	if err != nil {
+		return nil, err
+	}
+	var stack *data.StackNode
+	if b.PR != nil {
+		stack = data.FindStack(getStacks(m.repoPrs(r)), b.PR.Number)
+	}
+	if stack == nil {
+		return nil, fmt.Errorf("branch %s isn't part of a stack of PRs", b.Data.Name)
+	}
+	if err := r.errRebaseInProgress(); err != nil {
+		return nil, err
+	}
+
+	local := make(map[string]*branch.Branch)
+	for i := range m.Branches {
+		if m.Branches[i].RepoPath == r.path {
+			local[m.Branches[i].Data.Name] = &m.Branches[i]
+		}
+	}
+	type rebase struct{ upstream, branch string }
+	rebases := make([]rebase, 0)
+	stack.Walk(func(node *data.StackNode, _ int, _ bool) {
+		b := local[node.PR.HeadRefName]
+		if b == nil {
+			return
+		}
+		// The bottom of the stack, or a branch whose parent isn't checked
+		// out, is rebased onto its PR's base on the remote it tracks
+		upstream := rebaseBase(r, b)
+		if node.Parent != nil && local[node.Parent.PR.HeadRefName] != nil {
+			upstream = node.Parent.PR.HeadRefName
+		}
+		rebases = append(rebases, rebase{upstream: upstream, branch: node.PR.HeadRefName})
+	})
+	headBranch := r.repo.HeadBranchName
+
+	taskId := fmt.Sprintf("restack_%s_%d", stack.PR.HeadRefName, time.Now().Unix())
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Restacking %d branches on %s", len(rebases), stack.PR.BaseRefName),
+		FinishedText: fmt.Sprintf("%d branches have been restacked", len(rebases)),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		var err error
+		var failed rebase
+		for _, rb := range rebases {
+			if err = git.RebaseBranch(r.path, rb.upstream, rb.branch); err != nil {
+				failed = rb
+				err = fmt.Errorf("failed rebasing %s onto %s: %w", rb.branch, rb.upstream, err)
+				break
+			}
+		}
+		status, statusErr := git.GetStatus(r.path)
+		isStopped := statusErr == nil && status.Rebase != nil
+		// rebasing checks out every branch, go back to the one that was,
+		// unless a rebase stopped on conflicts and waits to be continued
+		if headBranch != "" && !isStopped {
+			if checkoutErr := gitm.Checkout(r.path, headBranch); checkoutErr != nil {
+				err = errors.Join(err, checkoutErr)
+			}
+		}
+		repo, repoErr := git.GetRepo(r.path)
+		if repoErr != nil {
+			return constants.TaskFinishedMsg{TaskId: taskId, Err: errors.Join(err, repoErr)}
+		}
+		if err != nil && repo.Status.Rebase != nil {
+			err = fmt.Errorf(
+				"restacking stopped on conflicts in %d files rebasing %s onto %s, continue or abort the rebase",
+				len(repo.Status.Conflicted), failed.branch, failed.upstream)
+		}
+
+		return constants.TaskFinishedMsg{
+			SectionId:   0,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Msg:         repoMsg{path: r.path, repo: repo},
 			Err:         err,
 		}
 	}), nil
 }
 
+// retargetedPRsMsg has the new bases of the retargeted PRs, by their URL
+type retargetedPRsMsg struct {
+	bases map[string]string
+}
+
+// repoRetarget is a PR to retarget, along with how gh names its repo
+type repoRetarget struct {
+	repo string
+	data.StackRetarget
+}
+
+// retarget changes the base of the PRs stacked on merged PRs to the base of
+// the merged PR, like the repo's default branch once the bottom PR merged.
+func (m *Model) retarget() (tea.Cmd, error) {
+	retargets := make([]repoRetarget, 0)
+	for _, r := range m.repos {
+		prs := make([]data.StackPR, 0)
+		for _, pr := range m.repoPrs(r) {
+			prs = append(prs, data.StackPRFromPullRequest(pr))
+		}
+		for _, retarget := range data.FindRetargets(prs) {
+			retargets = append(retargets, repoRetarget{repo: r.fullName, StackRetarget: retarget})
+		}
+	}
+	if len(retargets) == 0 {
+		return nil, errors.New("no PRs are stacked on merged PRs")
+	}
+
+	taskId := fmt.Sprintf("retarget_%d", time.Now().Unix())
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Retargeting %d PRs", len(retargets)),
+		FinishedText: fmt.Sprintf("%d PRs have been retargeted", len(retargets)),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		bases := make(map[string]string, len(retargets))
+		var errs []error
+		for _, r := range retargets {
+			c := exec.Command(
+				"gh",
+				"pr",
+				"edit",
+				fmt.Sprint(r.PR.Number),
+				"-R",
+				r.repo,
+				"--base",
+				r.NewBase,
+			)
+			if err := c.Run(); err != nil {
+				errs = append(errs, fmt.Errorf("failed retargeting PR #%d: %w", r.PR.Number, err))
+				continue
+			}
+			bases[r.PR.Url] = r.NewBase
+		}
+
+		return constants.TaskFinishedMsg{
+			SectionId:   0,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Msg:         retargetedPRsMsg{bases: bases},
+			Err:         errors.Join(errs...),
+		}
+	}), nil
+}
+
+// rebaseBase returns the branch to rebase a branch onto: its PR's base
+// branch, on the remote of the repo's default branch, or the default branch
+// when it has no PR
+func rebaseBase(r *localRepo, b *branch.Branch) string {
+	base := b.Data.BaseBranch
+	if b.PR == nil || b.PR.BaseRefName == "" {
+		return base
+	}
+	if remote, _, ok := strings.Cut(base, "/"); ok && slices.Contains(r.repo.Remotes, remote) {
+		return remote + "/" + b.PR.BaseRefName
+	}
+	return b.PR.BaseRefName
+}
+
+func (m *Model) rebaseOntoBase() (tea.Cmd, error) {
+	b, r, err := m.getCurrBranchAndRepo()
+	if err != nil {
+		return nil, err
+	}
+	onto := rebaseBase(r, b)
+	if onto == "" {
+		return nil, fmt.Errorf("branch %s has no base branch to rebase onto", b.Data.Name)
+	}
+	return m.rebase(r, b, onto)
+}
+
+func (m *Model) rebaseOntoUpstream() (tea.Cmd, error) {
+	b, r, err := m.getCurrBranchAndRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Data.Upstream == "" {
+		return nil, fmt.Errorf("branch %s has no upstream", b.Data.Name)
+	}
+	if b.Data.UpstreamGone {
+		return nil, fmt.Errorf("the upstream of %s was deleted from its remote", b.Data.Name)
+	}
+	return m.rebase(r, b, b.Data.Upstream)
+}
+
+// rebase rebases the branch onto onto. A rebase that stops on conflicts is
+// left in progress, for the conflicts to be resolved before it's continued.
+func (m *Model) rebase(r *localRepo, b *branch.Branch, onto string) (tea.Cmd, error) {
+	if err := r.errRebaseInProgress(); err != nil {
+		return nil, err
+	}
+	if b.Data.WorktreePath != "" {
+		return nil, fmt.Errorf(
+			"branch %s is checked out in worktree %s, rebase it there", b.Data.Name, b.Data.WorktreePath)
+	}
+
+	taskId := fmt.Sprintf("rebase_%s_%d", b.Data.Name, time.Now().Unix())
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("Rebasing branch %s onto %s", b.Data.Name, onto),
+		FinishedText: fmt.Sprintf("Branch %s has been rebased onto %s", b.Data.Name, onto),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		err := git.Rebase(r.path, onto, b.Data.Name)
+		repo, repoErr := git.GetRepo(r.path)
+		if repoErr != nil {
+			return constants.TaskFinishedMsg{TaskId: taskId, Err: errors.Join(err, repoErr)}
+		}
+		if err != nil && repo.Status.Rebase != nil {
+			err = fmt.Errorf("rebasing %s onto %s stopped on conflicts in %d files",
+				b.Data.Name, onto, len(repo.Status.Conflicted))
+		}
+
+		return constants.TaskFinishedMsg{
+			SectionId:   0,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Msg:         repoMsg{path: r.path, repo: repo},
+			Err:         err,
+		}
+	}), nil
+}
+
+// rebaseInteractive runs an interactive rebase of the branch onto its base
+// in the user's editor
+func (m *Model) rebaseInteractive() (tea.Cmd, error) {
+	b, r, err := m.getCurrBranchAndRepo()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.errRebaseInProgress(); err != nil {
+		return nil, err
+	}
+	onto := rebaseBase(r, b)
+	if onto == "" {
+		return nil, fmt.Errorf("branch %s has no base branch to rebase onto", b.Data.Name)
+	}
+
+	c := exec.Command("git", "rebase", "--interactive", onto, b.Data.Name)
+	c.Dir = r.path
+	// The refs watcher reads the repo again once the rebase changed it
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return constants.ErrMsg{Err: fmt.Errorf("rebasing %s onto %s: %w", b.Data.Name, onto, err)}
+		}
+		return nil
+	}), nil
+}
+
+// finishRebase continues the rebase in progress once its conflicts were
+// resolved, or aborts it
+func (m *Model) finishRebase(abort bool) (tea.Cmd, error) {
+	r := m.getCurrRepo()
+	if r == nil || r.repo.Status.Rebase == nil {
+		return nil, errors.New("no rebase in progress")
+	}
+	rebase := r.repo.Status.Rebase
+	action, done, run := "Continuing", "continued", git.ContinueRebase
+	if abort {
+		action, done, run = "Aborting", "aborted", git.AbortRebase
+	}
+
+	taskId := fmt.Sprintf("rebase_%s_%s_%d", strings.ToLower(action), rebase.Branch, time.Now().Unix())
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf("%s the rebase of %s", action, rebase.Branch),
+		FinishedText: fmt.Sprintf("The rebase of %s has been %s", rebase.Branch, done),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		err := run(r.path)
+		repo, repoErr := git.GetRepo(r.path)
+		if repoErr != nil {
+			return constants.TaskFinishedMsg{TaskId: taskId, Err: errors.Join(err, repoErr)}
+		}
+
+		return constants.TaskFinishedMsg{
+			SectionId:   0,
+			SectionType: SectionType,
+			TaskId:      taskId,
+			Msg:         repoMsg{path: r.path, repo: repo},
+			Err:         err,
+		}
+	}), nil
+}
+
+// repoMsg has the repo at path, read again
 type repoMsg struct {
+	path           string
 	repo           *git.Repo
 	resetSelection bool
 }
 
-func (m *Model) readRepoCmd() []tea.Cmd {
+// reposMsg has the repos an action changed more than one of, read again
+type reposMsg []repoMsg
+
+func (m *Model) onRepoMsg(msg repoMsg) {
+	r := m.findRepo(msg.path)
+	if r == nil || msg.repo == nil {
+		return
+	}
+	r.repo = msg.repo
+	m.SetIsLoading(false)
+	m.updateBranchesWithPrs()
+	m.Table.SetRows(m.BuildRows())
+	if msg.resetSelection {
+		m.Table.ResetCurrItem()
+	}
+}
+
+func (m *Model) readRepoCmd(r *localRepo) []tea.Cmd {
 	cmds := make([]tea.Cmd, 0)
-	branchesTaskId := fmt.Sprintf("fetching_branches_%d", time.Now().Unix())
-	if m.Ctx.RepoPath != "" {
-		branchesTask := context.Task{
-			Id:           branchesTaskId,
-			StartText:    "Reading local branches",
-			FinishedText: "Branches read",
-			State:        context.TaskStart,
-			Error:        nil,
-		}
-		bCmd := m.Ctx.StartTask(branchesTask)
-		cmds = append(cmds, bCmd)
+	branchesTaskId := fmt.Sprintf("fetching_branches_%s_%d", r.path, time.Now().Unix())
+	branchesTask := context.Task{
+		Id:           branchesTaskId,
+		StartText:    m.repoTaskText(r, "Reading local branches"),
+		FinishedText: m.repoTaskText(r, "Branches read"),
+		State:        context.TaskStart,
+		Error:        nil,
 	}
+	cmds = append(cmds, m.Ctx.StartTask(branchesTask))
 	cmds = append(cmds, func() tea.Msg {
-		repo, err := git.GetRepo(m.Ctx.RepoPath)
+		repo, err := git.GetRepo(r.path)
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: branchesTaskId, Err: err}
 		}
@@ -199,29 +726,26 @@ func (m *Model) readRepoCmd() []tea.Cmd {
 			SectionId:   0,
 			SectionType: SectionType,
 			TaskId:      branchesTaskId,
-			Msg:         repoMsg{repo: repo},
+			Msg:         repoMsg{path: r.path, repo: repo},
 			Err:         err,
 		}
 	})
 	return cmds
 }
 
-func (m *Model) fetchRepoCmd() []tea.Cmd {
+func (m *Model) fetchRepoCmd(r *localRepo) []tea.Cmd {
 	cmds := make([]tea.Cmd, 0)
-	fetchTaskId := fmt.Sprintf("git_fetch_repo_%d", time.Now().Unix())
-	if m.Ctx.RepoPath == "" {
-		return []tea.Cmd{}
-	}
+	fetchTaskId := fmt.Sprintf("git_fetch_repo_%s_%d", r.path, time.Now().Unix())
 	fetchTask := context.Task{
 		Id:           fetchTaskId,
-		StartText:    "Fetching branches from origin",
-		FinishedText: "Fetched origin branches",
+		StartText:    m.repoTaskText(r, "Fetching branches from origin"),
+		FinishedText: m.repoTaskText(r, "Fetched origin branches"),
 		State:        context.TaskStart,
 		Error:        nil,
 	}
 	cmds = append(cmds, m.Ctx.StartTask(fetchTask))
 	cmds = append(cmds, func() tea.Msg {
-		repo, err := git.FetchRepo(m.Ctx.RepoPath)
+		repo, err := git.FetchRepo(r.path)
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: fetchTaskId, Err: err}
 		}
@@ -229,13 +753,15 @@ func (m *Model) fetchRepoCmd() []tea.Cmd {
 			SectionId:   0,
 			SectionType: SectionType,
 			TaskId:      fetchTaskId,
-			Msg:         repoMsg{repo: repo},
+			Msg:         repoMsg{path: r.path, repo: repo},
 			Err:         err,
 		}
 	})
 	return cmds
 }
 
+// fetchPRsCmd fetches the user's PRs of every repo, in a single search per
+// host
 func (m *Model) fetchPRsCmd() tea.Cmd {
 	prsTaskId := fmt.Sprintf("fetching_pr_branches_%d", time.Now().Unix())
 	task := context.Task{
@@ -246,39 +772,104 @@ func (m *Model) fetchPRsCmd() tea.Cmd {
 		Error:        nil,
 	}
 	startCmd := m.Ctx.StartTask(task)
+	repos := slices.Clone(m.repos)
 	return tea.Batch(startCmd, func() tea.Msg {
 		limit := m.Config.Limit
 		if limit == nil {
 			limit = &m.Ctx.Config.Defaults.PrsLimit
 		}
-		res, err := data.FetchPullRequests(
-			fmt.Sprintf("author:@me repo:%s", git.GetRepoShortName(m.Ctx.RepoUrl)),
-			*limit,
-			nil,
-		)
-		if err != nil {
+		hosts := make([]string, 0)
+		byHost := make(map[string][]string)
+		for _, r := range repos {
+			if _, ok := byHost[r.host]; !ok {
+				hosts = append(hosts, r.host)
+			}
+			byHost[r.host] = append(byHost[r.host], "repo:"+r.name)
+		}
+
+		var res data.PullRequestsResponse
+		prs := make([]data.PullRequestData, 0)
+		var errs []error
+		for _, host := range hosts {
+			hostRes, err := data.FetchPullRequests(
+				host,
+				"author:@me "+strings.Join(byHost[host], " "),
+				*limit,
+				nil,
+			)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			res = hostRes
+			prs = append(prs, hostRes.Prs...)
+		}
+		if len(errs) == len(hosts) {
 			return constants.TaskFinishedMsg{
 				SectionId:   0,
 				SectionType: SectionType,
 				TaskId:      prsTaskId,
-				Err:         err,
+				Err:         errors.Join(errs...),
+			}
+		}
+		for _, r := range repos {
+			repoPrs := prs
+			if len(repos) > 1 {
+				repoPrs = filterRepoPrs(prs, r.name)
 			}
+			prs = append(prs, fetchWorktreePRs(r, repoPrs)...)
 		}
 		return constants.TaskFinishedMsg{
 			SectionId:   0,
 			SectionType: SectionType,
 			TaskId:      prsTaskId,
 			Msg: SectionPullRequestsFetchedMsg{
-				Prs:        res.Prs,
-				TotalCount: res.TotalCount,
+				Prs:        prs,
+				TotalCount: len(prs),
 				PageInfo:   res.PageInfo,
 				TaskId:     prsTaskId,
 			},
+			Err: errors.Join(errs...),
 		}
 	})
 }
 
-func (m *Model) fetchPRCmd(branch string) []tea.Cmd {
+// fetchWorktreePRs fetches the PRs of branches checked out in worktrees that
+// aren't in prs, like PRs of others checked out for review, so the repo view
+// shows their state. Branches found to have no PR are skipped until
+// worktreePRMissTTL passes.
+func fetchWorktreePRs(r *localRepo, prs []data.PullRequestData) []data.PullRequestData {
+	worktrees, err := git.ListWorktrees(r.path)
+	if err != nil {
+		log.Debug("Failed listing worktrees", "err", err)
+		return nil
+	}
+
+	res := make([]data.PullRequestData, 0)
+	for _, wt := range worktrees {
+		if wt.IsMain || wt.Branch == "" || findPRForRef(prs, wt.Branch) != nil ||
+			r.isWorktreePRMiss(wt.Branch) {
+			continue
+		}
+		wtPrs, err := data.FetchPullRequests(
+			r.host,
+			fmt.Sprintf("repo:%s head:%s", r.name, wt.Branch),
+			1,
+			nil,
+		)
+		if err != nil {
+			log.Debug("Failed fetching worktree PR", "branch", wt.Branch, "err", err)
+			continue
+		}
+		if len(wtPrs.Prs) == 0 {
+			r.setWorktreePRMiss(wt.Branch)
+		}
+		res = append(res, wtPrs.Prs...)
+	}
+	return res
+}
+
+func (m *Model) fetchPRCmd(r *localRepo, branch string) []tea.Cmd {
 	prsTaskId := fmt.Sprintf("fetching_pr_for_branch_%s_%d", branch, time.Now().Unix())
 	task := context.Task{
 		Id:           prsTaskId,
@@ -290,7 +881,8 @@ func (m *Model) fetchPRCmd(branch string) []tea.Cmd {
 	startCmd := m.Ctx.StartTask(task)
 	return []tea.Cmd{startCmd, func() tea.Msg {
 		res, err := data.FetchPullRequests(
-			fmt.Sprintf("author:@me repo:%s head:%s", git.GetRepoShortName(m.Ctx.RepoUrl), branch),
+			r.host,
+			fmt.Sprintf("author:@me repo:%s head:%s", r.name, branch),
 			1,
 			nil,
 		)
@@ -366,28 +958,115 @@ func (m *Model) tickFetchPrsCmd() tea.Cmd {
 	)
 }
 
+// onRefreshBranchesMsg re-reads the repos, or only the working tree status
+// of those whose refs are watched, since then branches are read when they
+// change
 func (m *Model) onRefreshBranchesMsg() []tea.Cmd {
 	cmds := make([]tea.Cmd, 0)
-	cmds = append(cmds, m.readRepoCmd()...)
+	for _, r := range m.repos {
+		if r.refsWatcher != nil {
+			cmds = append(cmds, m.readStatusCmd(r))
+		} else {
+			cmds = append(cmds, m.readRepoCmd(r)...)
+		}
+	}
 	cmds = append(cmds, m.tickRefreshBranchesCmd())
 	return cmds
 }
 
+type statusMsg struct {
+	id     int
+	path   string
+	status git.Status
+}
+
+func (m *Model) readStatusCmd(r *localRepo) tea.Cmd {
+	id := m.refreshId
+	return func() tea.Msg {
+		status, err := git.GetStatus(r.path)
+		if err != nil {
+			log.Error("Failed reading the working tree status", "path", r.path, "err", err)
+			return nil
+		}
+		return statusMsg{id: id, path: r.path, status: status}
+	}
+}
+
+type refsWatchStartedMsg struct {
+	id      int
+	path    string
+	watcher *git.RefsWatcher
+	err     error
+}
+
+// RefsChangedMsg is sent when the branches or checked out branch of the
+// repo at path changed
+type RefsChangedMsg struct {
+	id      int
+	path    string
+	watcher *git.RefsWatcher
+}
+
+func (m *Model) watchRefsCmd(r *localRepo) tea.Cmd {
+	id := m.refreshId
+	return func() tea.Msg {
+		w, err := git.WatchRefs(r.path)
+		return refsWatchStartedMsg{id: id, path: r.path, watcher: w, err: err}
+	}
+}
+
+func waitForRefsCmd(id int, path string, w *git.RefsWatcher) tea.Cmd {
+	return func() tea.Msg {
+		if err := w.Wait(); err != nil {
+			if !errors.Is(err, git.ErrRefsWatcherClosed) {
+				log.Error("Failed watching refs", "path", path, "err", err)
+			}
+			return nil
+		}
+		return RefsChangedMsg{id: id, path: path, watcher: w}
+	}
+}
+
+// onRefsChanged reads the repo again without showing a task, since the
+// refs change whenever a branch is committed to or checked out
+func (m *Model) onRefsChanged(r *localRepo) tea.Cmd {
+	return tea.Batch(func() tea.Msg {
+		repo, err := git.GetRepo(r.path)
+		if err != nil {
+			log.Error("Failed reading repo after refs changed", "path", r.path, "err", err)
+			return nil
+		}
+		return repoMsg{path: r.path, repo: repo}
+	}, waitForRefsCmd(m.refreshId, r.path, r.refsWatcher))
+}
+
 func (m *Model) onRefreshPrsMsg() []tea.Cmd {
 	cmds := make([]tea.Cmd, 0)
-	cmds = append(cmds, m.fetchRepoCmd()...)
+	for _, r := range m.repos {
+		cmds = append(cmds, m.fetchRepoCmd(r)...)
+	}
 	cmds = append(cmds, m.tickFetchPrsCmd())
 	return cmds
 }
 
 func (m *Model) OpenGithub() tea.Cmd {
-	row := m.CurrRow()
-	b := m.getFilteredBranches()[row]
-	return tasks.OpenBranchPR(m.Ctx, tasks.SectionIdentifier{Id: 0, Type: SectionType}, b.Data.Name)
+	b, r, err := m.getCurrBranchAndRepo()
+	if err != nil {
+		return nil
+	}
+	return tasks.OpenBranchPR(
+		m.Ctx,
+		tasks.SectionIdentifier{Id: 0, Type: SectionType},
+		r.fullName,
+		b.Data.Name,
+	)
 }
 
 func (m *Model) deleteBranch() tea.Cmd {
-	b := m.getCurrBranch()
+	b, r, err := m.getCurrBranchAndRepo()
+	if err != nil {
+		return nil
+	}
 
 	taskId := fmt.Sprintf("delete_%s_%d", b.Data.Name, time.Now().Unix())
 	task := context.Task{
@@ -399,11 +1078,11 @@ func (m *Model) deleteBranch() tea.Cmd {
 	}
 	startCmd := m.Ctx.StartTask(task)
 	return tea.Batch(startCmd, func() tea.Msg {
-		err := gitm.DeleteBranch(m.Ctx.RepoPath, b.Data.Name, gitm.DeleteBranchOptions{Force: true})
+		err := gitm.DeleteBranch(r.path, b.Data.Name, gitm.DeleteBranchOptions{Force: true})
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
-		repo, err := git.GetRepo(m.Ctx.RepoPath)
+		repo, err := git.GetRepo(r.path)
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
@@ -412,13 +1091,20 @@ func (m *Model) deleteBranch() tea.Cmd {
 			SectionId:   0,
 			SectionType: SectionType,
 			TaskId:      taskId,
-			Msg:         repoMsg{repo: repo},
+			Msg:         repoMsg{path: r.path, repo: repo},
 			Err:         err,
 		}
 	})
 }
 
+// newBranch creates a branch off the checked out branch of the selected
+// branch's repo, and checks it out
 func (m *Model) newBranch(name string) tea.Cmd {
+	r := m.getCurrRepo()
+	if r == nil {
+		return nil
+	}
+	base := r.repo.HeadBranchName
 	taskId := fmt.Sprintf("create_branch_%s_%d", name, time.Now().Unix())
 	task := context.Task{
 		Id:           taskId,
@@ -430,14 +1116,14 @@ func (m *Model) newBranch(name string) tea.Cmd {
 	startCmd := m.Ctx.StartTask(task)
 	return tea.Batch(startCmd, func() tea.Msg {
 		err := gitm.Checkout(
-			m.Ctx.RepoPath,
+			r.path,
 			name,
-			gitm.CheckoutOptions{BaseBranch: m.repo.HeadBranchName},
+			gitm.CheckoutOptions{BaseBranch: base},
 		)
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
-		repo, err := git.GetRepo(m.Ctx.RepoPath)
+		repo, err := git.GetRepo(r.path)
 		if err != nil {
 			return constants.TaskFinishedMsg{TaskId: taskId, Err: err}
 		}
@@ -446,7 +1132,7 @@ func (m *Model) newBranch(name string) tea.Cmd {
 			SectionId:   0,
 			SectionType: SectionType,
 			TaskId:      taskId,
-			Msg:         repoMsg{repo: repo},
+			Msg:         repoMsg{path: r.path, repo: repo},
 			Err:         err,
 		}
 	})
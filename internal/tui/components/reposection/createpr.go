@@ -0,0 +1,154 @@
+package reposection
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/branch"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prform"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
+)
+
+// CreatePROptionsMsg carries what the create PR form of a branch starts
+// with, built in a command as it lists the branch's commits and the remote's
+// branches
+type CreatePROptionsMsg struct {
+	Options prform.Options
+	Err     error
+}
+
+// CreatePROptions returns a command building what the create PR form of the
+// selected branch starts with: a title and body of its commits and the repo's
+// PR template, based on the repo's default branch
+func (m *Model) CreatePROptions() tea.Cmd {
+	b, r, err := m.getCurrBranchAndRepo()
+	if err != nil {
+		return func() tea.Msg { return CreatePROptionsMsg{Err: err} }
+	}
+	if b.PR != nil && b.PR.State == "OPEN" {
+		err := fmt.Errorf("branch %s already has PR #%d", b.Data.Name, b.PR.GetNumber())
+		return func() tea.Msg { return CreatePROptionsMsg{Err: err} }
+	}
+
+	// The branch and repo are read here, as they're replaced when the
+	// branches are fetched again
+	branchData := b.Data
+	baseRemote, base := prBase(r)
+	pushRemote, setUpstream := prPushRemote(b, base)
+	headRemote, remoteHead := prHead(b, base)
+	var localBases []string
+	for _, rb := range r.repo.Branches {
+		localBases = append(localBases, rb.Name)
+	}
+	path, name, fullName := r.path, r.name, r.fullName
+
+	return func() tea.Msg {
+		bases := localBases
+		if baseRemote != "" {
+			var err error
+			bases, err = git.ListRemoteBranches(path, baseRemote)
+			if err != nil {
+				log.Warn("Failed listing remote branches", "remote", baseRemote, "err", err)
+			}
+		}
+		bases = slices.DeleteFunc(bases, func(name string) bool { return name == branchData.Name })
+
+		var commits []git.CommitMessage
+		if branchData.BaseBranch != "" {
+			var err error
+			commits, err = git.ListCommitMessages(path, branchData.BaseBranch, branchData.Name)
+			if err != nil {
+				log.Warn("Failed listing the commits of the branch", "branch", branchData.Name,
+					"err", err)
+			}
+		}
+		title, body := prform.Prefill(branchData.Name, commits, prform.FindTemplate(path))
+
+		owner, repoName, _ := strings.Cut(name, "/")
+		return CreatePROptionsMsg{Options: prform.Options{
+			PR: tasks.CreatePROptions{
+				Dir:         path,
+				Repo:        fullName,
+				Head:        branchData.Name,
+				RemoteHead:  remoteHead,
+				HeadOwner:   forkOwner(path, headRemote, name),
+				Base:        base,
+				Title:       title,
+				Body:        body,
+				PushRemote:  pushRemote,
+				SetUpstream: setUpstream,
+			},
+			Owner: owner,
+			Name:  repoName,
+			Bases: bases,
+		}}
+	}
+}
+
+// prBase returns the remote of the repo's default branch, which a PR's base
+// branches are on, and the default branch's name on it. The remote is empty
+// when the default branch is a local one.
+func prBase(r *localRepo) (remote string, base string) {
+	base = r.repo.DefaultBranch
+	if rm, name, ok := strings.Cut(base, "/"); ok && slices.Contains(r.repo.Remotes, rm) {
+		return rm, name
+	}
+	return "", base
+}
+
+// prPushRemote returns the remote to push the branch to before creating its
+// PR, which is empty when its upstream has all its commits. Branches that
+// don't track a branch of their own, like ones created from origin/main, are
+// pushed to origin and track it from then on.
+func prPushRemote(b *branch.Branch, base string) (remote string, setUpstream bool) {
+	headRemote, _ := prHead(b, base)
+	switch {
+	case !hasHeadUpstream(b, base), b.Data.UpstreamGone:
+		return headRemote, true
+	case b.Data.CommitsAhead > 0:
+		return headRemote, false
+	default:
+		return "", false
+	}
+}
+
+// prHead returns the remote the head of the branch's PR is on and the
+// branch's name there, which is its upstream. Branches that don't track a
+// branch of their own are pushed to origin under the same name.
+func prHead(b *branch.Branch, base string) (remote string, name string) {
+	if hasHeadUpstream(b, base) {
+		remote, name, _ := strings.Cut(b.Data.Upstream, "/")
+		return remote, name
+	}
+	return "origin", b.Data.Name
+}
+
+// hasHeadUpstream reports whether the branch's upstream can be the head of
+// its PR. A branch created with git checkout -b feat origin/main tracks the
+// PR's base, which its commits must not be pushed to.
+func hasHeadUpstream(b *branch.Branch, base string) bool {
+	_, name, ok := strings.Cut(b.Data.Upstream, "/")
+	return ok && name != base
+}
+
+// forkOwner returns the owner of the repo the remote points to when it's a
+// fork of the repo named nameWithOwner, as gh names the head of a PR from a
+// fork with its owner
+func forkOwner(dir string, remote string, nameWithOwner string) string {
+	urls, err := git.GetRemoteUrls(dir)
+	if err != nil {
+		log.Warn("Failed reading the remotes of the repo", "dir", dir, "err", err)
+		return ""
+	}
+	_, owner, _, ok := git.ParseRemoteUrl(urls[remote])
+	repoOwner, _, _ := strings.Cut(nameWithOwner, "/")
+	if !ok || strings.EqualFold(owner, repoOwner) {
+		return ""
+	}
+	return owner
+}
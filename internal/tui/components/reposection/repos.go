@@ -0,0 +1,215 @@
+package reposection
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/branch"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+)
+
+// localRepo is a local clone the repo view shows the branches of. Every
+// clone is read, fetched and watched on its own.
+type localRepo struct {
+	// name is the repo's name with its owner, like dlvhdr/gh-dash, and host
+	// its host when it isn't gh's default one
+	name string
+	host string
+	// fullName is how gh's -R flag names the repo
+	fullName string
+	path     string
+	repo     *git.Repo
+	// refsWatcher reads the repo again when its branches change, instead of
+	// every BranchesRefetchIntervalSeconds
+	refsWatcher *git.RefsWatcher
+	// worktreePRMisses are when worktree branches were found to have no PR,
+	// so they aren't searched for again on every refresh
+	worktreePRMisses   map[string]time.Time
+	worktreePRMissesMu sync.Mutex
+}
+
+// worktreePRMissTTL is how long a worktree branch found to have no PR isn't
+// searched for again
+const worktreePRMissTTL = 10 * time.Minute
+
+// isWorktreePRMiss returns whether the branch was found to have no PR less
+// than worktreePRMissTTL ago
+func (r *localRepo) isWorktreePRMiss(branch string) bool {
+	r.worktreePRMissesMu.Lock()
+	defer r.worktreePRMissesMu.Unlock()
+	missedAt, ok := r.worktreePRMisses[branch]
+	return ok && time.Since(missedAt) < worktreePRMissTTL
+}
+
+func (r *localRepo) setWorktreePRMiss(branch string) {
+	r.worktreePRMissesMu.Lock()
+	defer r.worktreePRMissesMu.Unlock()
+	if r.worktreePRMisses == nil {
+		r.worktreePRMisses = make(map[string]time.Time)
+	}
+	r.worktreePRMisses[branch] = time.Now()
+}
+
+// getLocalRepos returns the repo gh-dash runs in and, with
+// repo.includeRepoPaths, the repos repoPaths maps by name whose path exists
+func getLocalRepos(ctx *context.ProgramContext) []*localRepo {
+	repos := make([]*localRepo, 0)
+	var current *localRepo
+	if ctx.RepoPath != "" {
+		current = &localRepo{
+			name:     git.GetRepoShortName(ctx.RepoUrl),
+			fullName: ctx.RepoUrl,
+			path:     filepath.Clean(ctx.RepoPath),
+			repo:     &git.Repo{Branches: []git.Branch{}},
+		}
+		repos = append(repos, current)
+	}
+	if ctx.Config == nil || !ctx.Config.Repo.IncludeRepoPaths {
+		return repos
+	}
+
+	for _, r := range common.GetLocalRepos(ctx.Config.RepoPaths) {
+		host, name := "", r.Name
+		if strings.Count(r.Name, "/") == 2 {
+			host, name, _ = strings.Cut(r.Name, "/")
+		}
+		dir := filepath.Clean(r.Path)
+		if current != nil && dir == current.path {
+			// The repo gh-dash runs in goes by its name in repoPaths, which
+			// its origin's URL may not spell the same way
+			current.name, current.host, current.fullName = name, host, r.Name
+			continue
+		}
+		if _, err := os.Stat(dir); err != nil {
+			log.Debug("Skipping repo path", "repo", r.Name, "path", dir, "err", err)
+			continue
+		}
+		repos = append(repos, &localRepo{
+			name:     name,
+			host:     host,
+			fullName: r.Name,
+			path:     dir,
+			repo:     &git.Repo{Branches: []git.Branch{}},
+		})
+	}
+	return repos
+}
+
+// errRebaseInProgress returns an error when a rebase is waiting to be
+// continued or aborted, which other actions on branches would get in the
+// way of
+func (r *localRepo) errRebaseInProgress() error {
+	rebase := r.repo.Status.Rebase
+	if rebase == nil {
+		return nil
+	}
+	if rebase.Branch == "" {
+		return errors.New("a rebase is in progress, continue or abort it first")
+	}
+	return fmt.Errorf("%s is being rebased, continue or abort the rebase first", rebase.Branch)
+}
+
+// findRepo returns the repo at path, or nil when the view doesn't show it
+func (m *Model) findRepo(path string) *localRepo {
+	for _, r := range m.repos {
+		if r.path == path {
+			return r
+		}
+	}
+	return nil
+}
+
+// getCurrRepo returns the repo of the selected branch, or the first repo
+// when no branch is selected
+func (m *Model) getCurrRepo() *localRepo {
+	if b := m.getCurrBranch(); b != nil {
+		if r := m.findRepo(b.RepoPath); r != nil {
+			return r
+		}
+	}
+	if len(m.repos) == 0 {
+		return nil
+	}
+	return m.repos[0]
+}
+
+// getCurrBranchAndRepo returns the selected branch along with its repo
+func (m *Model) getCurrBranchAndRepo() (*branch.Branch, *localRepo, error) {
+	b := m.getCurrBranch()
+	if b == nil {
+		return nil, nil, errors.New("no branch selected")
+	}
+	r := m.findRepo(b.RepoPath)
+	if r == nil {
+		return nil, nil, fmt.Errorf("no local repo found for branch %s", b.Data.Name)
+	}
+	return b, r, nil
+}
+
+// repoPrs returns the PRs of the repo's branches. With a single repo every
+// fetched PR is one of its own.
+func (m *Model) repoPrs(r *localRepo) []data.PullRequestData {
+	if len(m.repos) < 2 {
+		return m.Prs
+	}
+	return filterRepoPrs(m.Prs, r.name)
+}
+
+// filterRepoPrs returns the PRs of the repo named name
+func filterRepoPrs(prs []data.PullRequestData, name string) []data.PullRequestData {
+	repoPrs := make([]data.PullRequestData, 0)
+	for _, pr := range prs {
+		if strings.EqualFold(pr.Repository.NameWithOwner, name) {
+			repoPrs = append(repoPrs, pr)
+		}
+	}
+	return repoPrs
+}
+
+// parseSearch splits the search into the repos of its repo: qualifiers and
+// the text that branch names have to contain
+func parseSearch(search string) (repos []string, text string) {
+	words := make([]string, 0)
+	for word := range strings.FieldsSeq(search) {
+		if repo, ok := strings.CutPrefix(word, "repo:"); ok && repo != "" {
+			repos = append(repos, repo)
+			continue
+		}
+		words = append(words, word)
+	}
+	return repos, strings.Join(words, " ")
+}
+
+// matchesRepo returns whether the branch is in one of repos, named with or
+// without their owner. Every branch matches when there are no repos.
+func matchesRepo(b branch.Branch, repos []string) bool {
+	if len(repos) == 0 {
+		return true
+	}
+	for _, repo := range repos {
+		if strings.EqualFold(b.RepoName, repo) || strings.EqualFold(path.Base(b.RepoName), repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// repoTaskText names the repo in the text of a task when the view shows more
+// than one repo
+func (m *Model) repoTaskText(r *localRepo, text string) string {
+	if len(m.repos) < 2 {
+		return text
+	}
+	return fmt.Sprintf("%s in %s", text, r.name)
+}
@@ -0,0 +1,230 @@
+package reposection
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/branch"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+)
+
+func TestGetLocalRepos(t *testing.T) {
+	current, other, missing := t.TempDir(), t.TempDir(), t.TempDir()+"/missing"
+	ctx := &context.ProgramContext{
+		RepoPath: current,
+		RepoUrl:  "git@github.com:dlvhdr/gh-dash.git",
+		Config: &config.Config{
+			Repo: config.RepoConfig{IncludeRepoPaths: true},
+			RepoPaths: map[string]string{
+				"dlvhdr/gh-dash":              current,
+				"dlvhdr/*":                    "/code/*",
+				"ghe.example.com/org/service": other,
+				"dlvhdr/missing":              missing,
+			},
+		},
+	}
+
+	repos := getLocalRepos(ctx)
+	require.Len(t, repos, 2)
+	require.Equal(t, "dlvhdr/gh-dash", repos[0].name)
+	require.Equal(t, "dlvhdr/gh-dash", repos[0].fullName)
+	require.Equal(t, current, repos[0].path)
+	require.Equal(t, "org/service", repos[1].name)
+	require.Equal(t, "ghe.example.com", repos[1].host)
+	require.Equal(t, "ghe.example.com/org/service", repos[1].fullName)
+	require.Equal(t, other, repos[1].path)
+
+	ctx.Config.Repo.IncludeRepoPaths = false
+	repos = getLocalRepos(ctx)
+	require.Len(t, repos, 1)
+	require.Equal(t, git.GetRepoShortName(ctx.RepoUrl), repos[0].name)
+}
+
+func TestGetFilteredBranches(t *testing.T) {
+	m := Model{Branches: []branch.Branch{
+		{RepoName: "dlvhdr/gh-dash", Data: git.Branch{Name: "main"}},
+		{RepoName: "dlvhdr/gh-dash", Data: git.Branch{Name: "feat/repos"}},
+		{RepoName: "dlvhdr/diffnav", Data: git.Branch{Name: "main"}},
+		{RepoName: "org/service", Data: git.Branch{Name: "feat/api"}},
+	}}
+
+	names := func() []string {
+		res := make([]string, 0)
+		for _, b := range m.getFilteredBranches() {
+			res = append(res, b.RepoName+":"+b.Data.Name)
+		}
+		return res
+	}
+
+	testCases := map[string]struct {
+		search string
+		want   []string
+	}{
+		"no search": {
+			search: "",
+			want: []string{
+				"dlvhdr/gh-dash:main",
+				"dlvhdr/gh-dash:feat/repos",
+				"dlvhdr/diffnav:main",
+				"org/service:feat/api",
+			},
+		},
+		"branch name": {
+			search: "feat",
+			want:   []string{"dlvhdr/gh-dash:feat/repos", "org/service:feat/api"},
+		},
+		"repo with owner": {
+			search: "repo:dlvhdr/gh-dash",
+			want:   []string{"dlvhdr/gh-dash:main", "dlvhdr/gh-dash:feat/repos"},
+		},
+		"repo without owner and branch name": {
+			search: "repo:diffnav main",
+			want:   []string{"dlvhdr/diffnav:main"},
+		},
+		"several repos": {
+			search: "feat repo:gh-dash repo:SERVICE",
+			want:   []string{"dlvhdr/gh-dash:feat/repos", "org/service:feat/api"},
+		},
+		"unknown repo": {
+			search: "repo:other",
+			want:   []string{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			m.SearchValue = tc.search
+			require.Equal(t, tc.want, names())
+		})
+	}
+}
+
+func TestPrBaseAndPushRemote(t *testing.T) {
+	r := &localRepo{repo: &git.Repo{Remotes: []string{"origin", "upstream"}, DefaultBranch: "upstream/main"}}
+	remote, base := prBase(r)
+	require.Equal(t, "upstream", remote)
+	require.Equal(t, "main", base)
+
+	r.repo.DefaultBranch = "main"
+	remote, base = prBase(r)
+	require.Empty(t, remote)
+	require.Equal(t, "main", base)
+
+	testCases := []struct {
+		name            string
+		data            git.Branch
+		wantRemote      string
+		wantSetUpstream bool
+		wantHead        string
+	}{
+		{"no upstream", git.Branch{Name: "feat"}, "origin", true, "feat"},
+		{
+			"upstream gone",
+			git.Branch{Name: "feat", Upstream: "fork/feat", UpstreamGone: true},
+			"fork", true, "feat",
+		},
+		{
+			"ahead of upstream",
+			git.Branch{Name: "feat", Upstream: "fork/feat", CommitsAhead: 2},
+			"fork", false, "feat",
+		},
+		{"up to date", git.Branch{Name: "feat", Upstream: "origin/feat"}, "", false, "feat"},
+		{
+			"upstream with another name",
+			git.Branch{Name: "feat", Upstream: "origin/me/feature", CommitsAhead: 1},
+			"origin", false, "me/feature",
+		},
+		{
+			"tracking the base branch",
+			git.Branch{Name: "feat", Upstream: "origin/main", CommitsAhead: 3},
+			"origin", true, "feat",
+		},
+		{
+			"tracking the base branch of another remote",
+			git.Branch{Name: "feat", Upstream: "upstream/main", CommitsAhead: 1},
+			"origin", true, "feat",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := &branch.Branch{Data: tc.data}
+			remote, setUpstream := prPushRemote(b, base)
+			require.Equal(t, tc.wantRemote, remote)
+			require.Equal(t, tc.wantSetUpstream, setUpstream)
+			_, head := prHead(b, base)
+			require.Equal(t, tc.wantHead, head)
+		})
+	}
+}
+
+func TestWorktreePRMisses(t *testing.T) {
+	r := &localRepo{}
+	require.False(t, r.isWorktreePRMiss("feat"))
+
+	r.setWorktreePRMiss("feat")
+	require.True(t, r.isWorktreePRMiss("feat"))
+	require.False(t, r.isWorktreePRMiss("other"))
+
+	r.worktreePRMisses["feat"] = time.Now().Add(-worktreePRMissTTL)
+	require.False(t, r.isWorktreePRMiss("feat"), "misses are searched for again")
+}
+
+func TestForkOwner(t *testing.T) {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "origin", "git@github.com:dlvhdr/gh-dash.git"},
+		{"remote", "add", "fork", "https://github.com/me/gh-dash.git"},
+	} {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	require.Empty(t, forkOwner(dir, "origin", "dlvhdr/gh-dash"))
+	require.Equal(t, "me", forkOwner(dir, "fork", "dlvhdr/gh-dash"))
+	require.Empty(t, forkOwner(dir, "missing", "dlvhdr/gh-dash"))
+}
+
+func TestStopWatchingRefs(t *testing.T) {
+	dir := t.TempDir()
+	out, err := exec.Command("git", "-C", dir, "init", "--quiet").CombinedOutput()
+	require.NoError(t, err, string(out))
+	w, err := git.WatchRefs(dir)
+	require.NoError(t, err)
+
+	m := Model{repos: []*localRepo{{path: dir, refsWatcher: w}, {path: "unwatched"}}}
+	m.StopWatchingRefs()
+	require.Nil(t, m.repos[0].refsWatcher)
+	require.ErrorIs(t, w.Wait(), git.ErrRefsWatcherClosed)
+}
+
+func TestGetPrunableBranches(t *testing.T) {
+	merged := git.Branch{IsMerged: true, UpstreamGone: true}
+	m := Model{Branches: []branch.Branch{
+		{RepoName: "dlvhdr/gh-dash", RepoPath: "/gh-dash", Data: withName(merged, "old")},
+		{RepoName: "dlvhdr/gh-dash", RepoPath: "/gh-dash", Data: git.Branch{Name: "feat"}},
+		{RepoName: "dlvhdr/diffnav", RepoPath: "/diffnav", Data: withName(merged, "old")},
+	}}
+
+	prunable := m.getPrunableBranches()
+	require.Len(t, prunable, 2)
+	require.Equal(t, 2, m.countShownRepos())
+	require.Equal(t, "dlvhdr/gh-dash:old, dlvhdr/diffnav:old", prunePreview(prunable, true))
+
+	m.SearchValue = "repo:diffnav"
+	prunable = m.getPrunableBranches()
+	require.Len(t, prunable, 1)
+	require.Equal(t, "/diffnav", prunable[0].RepoPath)
+	require.Equal(t, 1, m.countShownRepos())
+	require.Equal(t, "old", prunePreview(prunable, false))
+}
+
+func withName(b git.Branch, name string) git.Branch {
+	b.Name = name
+	return b
+}
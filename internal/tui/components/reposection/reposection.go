@@ -1,6 +1,7 @@
 package reposection
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"charm.land/log/v2"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/config"
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
@@ -29,7 +31,9 @@ const SectionType = "repo"
 
 type Model struct {
 	section.BaseModel
-	repo           *git.Repo
+	// repos are the local repos whose branches are shown, the one gh-dash
+	// runs in first
+	repos          []*localRepo
 	Branches       []branch.Branch
 	Prs            []data.PullRequestData
 	isRefreshSetUp bool
@@ -43,13 +47,14 @@ func NewModel(
 	lastUpdated time.Time,
 ) Model {
 	m := Model{}
+	m.repos = getLocalRepos(ctx)
 	m.BaseModel = section.NewModel(
 		ctx,
 		section.NewSectionOptions{
 			Id:          id,
 			Config:      cfg.ToSectionConfig(),
 			Type:        SectionType,
-			Columns:     GetSectionColumns(ctx, cfg),
+			Columns:     GetSectionColumns(ctx, cfg, len(m.repos) > 1),
 			Singular:    "branch",
 			Plural:      "branches",
 			LastUpdated: lastUpdated,
@@ -57,7 +62,6 @@ func NewModel(
 	)
 	m.SearchBar = search.NewModel(ctx, search.SearchOptions{Placeholder: "Search branches..."})
 	m.SearchValue = ""
-	m.repo = &git.Repo{Branches: []git.Branch{}}
 	m.Branches = []branch.Branch{}
 	m.Prs = []data.PullRequestData{}
 	m.isRefreshSetUp = false
@@ -101,19 +105,44 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			case "enter":
 				input := m.PromptConfirmationBox.Value()
 				action := m.GetPromptConfirmationAction()
-				branch := m.getCurrBranch().Data.Name
+				var pr *data.PullRequestData
+				if b := m.getCurrBranch(); b != nil {
+					pr = b.PR
+				}
 				sid := tasks.SectionIdentifier{Id: m.Id, Type: SectionType}
 				switch action {
 				case "new":
 					cmd = m.newBranch(input)
-				case "create_pr":
-					cmd = tasks.CreatePR(m.Ctx, sid, branch, input)
 				default:
-					pr := findPRForRef(m.Prs, branch)
 					if input == "Y" || input == "y" {
 						switch action {
 						case "delete":
 							cmd = m.deleteBranch()
+						case "restack":
+							cmd, err = m.restack()
+							if err != nil {
+								m.Ctx.Error = err
+							}
+						case "abort_rebase":
+							cmd, err = m.finishRebase(true)
+							if err != nil {
+								m.Ctx.Error = err
+							}
+						case "retarget":
+							cmd, err = m.retarget()
+							if err != nil {
+								m.Ctx.Error = err
+							}
+						case "prune_merged":
+							cmd, err = m.pruneMergedBranches()
+							if err != nil {
+								m.Ctx.Error = err
+							}
+						case "prune_worktrees":
+							cmd, err = m.pruneWorktrees()
+							if err != nil {
+								m.Ctx.Error = err
+							}
 						case "close":
 							cmd = tasks.ClosePR(m.Ctx, sid, pr)
 						case "reopen":
@@ -159,32 +188,93 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 			if err != nil {
 				m.Ctx.Error = err
 			}
+		case key.Matches(msg, keys.BranchKeys.Rebase):
+			cmd, err = m.rebaseOntoBase()
+			if err != nil {
+				m.Ctx.Error = err
+			}
+		case key.Matches(msg, keys.BranchKeys.RebaseUpstream):
+			cmd, err = m.rebaseOntoUpstream()
+			if err != nil {
+				m.Ctx.Error = err
+			}
+		case key.Matches(msg, keys.BranchKeys.RebaseInteractive):
+			cmd, err = m.rebaseInteractive()
+			if err != nil {
+				m.Ctx.Error = err
+			}
+		case key.Matches(msg, keys.BranchKeys.ContinueRebase):
+			cmd, err = m.finishRebase(false)
+			if err != nil {
+				m.Ctx.Error = err
+			}
+		case key.Matches(msg, keys.BranchKeys.AbortRebase):
+			// Aborting throws away the conflicts resolved so far
+			if r := m.getCurrRepo(); r == nil || r.repo.Status.Rebase == nil {
+				m.Ctx.Error = errors.New("no rebase in progress")
+			} else {
+				m.SetPromptConfirmationAction("abort_rebase")
+				cmd = m.SetIsPromptConfirmationShown(true)
+			}
 		}
 
 	case tasks.UpdateBranchMsg:
-		if msg.IsCreated != nil && *msg.IsCreated {
-			cmds = append(cmds, m.fetchPRCmd(msg.Name)...)
+		if r := m.getCurrRepo(); r != nil && msg.IsCreated != nil && *msg.IsCreated {
+			cmds = append(cmds, m.fetchPRCmd(r, msg.Name)...)
 		}
 		if msg.NewPr != nil {
 			m.Prs = append(m.Prs, *msg.NewPr)
 		}
 
 	case repoMsg:
-		m.repo = msg.repo
-		m.SetIsLoading(false)
-		m.Table.SetRows(m.BuildRows())
-		if msg.resetSelection {
-			m.Table.ResetCurrItem()
+		m.onRepoMsg(msg)
+
+	case reposMsg:
+		for _, rm := range msg {
+			m.onRepoMsg(rm)
 		}
 
 	case SectionPullRequestsFetchedMsg:
 		m.Prs = msg.Prs
 
+	case retargetedPRsMsg:
+		for i := range m.Prs {
+			if base, ok := msg.bases[m.Prs[i].Url]; ok {
+				m.Prs[i].BaseRefName = base
+			}
+		}
+
 	case RefreshBranchesMsg:
 		if msg.id == m.refreshId {
 			cmds = append(cmds, m.onRefreshBranchesMsg()...)
 		}
 
+	case statusMsg:
+		if r := m.findRepo(msg.path); msg.id == m.refreshId && r != nil {
+			r.repo.Status = msg.status
+		}
+
+	case refsWatchStartedMsg:
+		r := m.findRepo(msg.path)
+		switch {
+		case msg.err != nil:
+			// Branches are still read every BranchesRefetchIntervalSeconds
+			log.Warn("Failed watching refs, polling them instead", "path", msg.path, "err", msg.err)
+		case msg.id != m.refreshId || r == nil:
+			msg.watcher.Close()
+		default:
+			r.refsWatcher = msg.watcher
+			cmds = append(cmds, waitForRefsCmd(msg.id, r.path, msg.watcher))
+		}
+
+	case RefsChangedMsg:
+		if r := m.findRepo(msg.path); msg.id == m.refreshId && r != nil {
+			cmds = append(cmds, m.onRefsChanged(r))
+		} else {
+			// The section was fetched again, with watchers of its own
+			msg.watcher.Close()
+		}
+
 	case RefreshPrsMsg:
 		if msg.id == m.refreshId {
 			cmds = append(cmds, m.onRefreshPrsMsg()...)
@@ -233,9 +323,12 @@ func (m *Model) View() string {
 	)
 }
 
+// GetSectionColumns returns the columns of the branches table. The repo
+// column is only shown along with the branches of more than one repo.
 func GetSectionColumns(
 	ctx *context.ProgramContext,
 	cfg config.PrsSectionConfig,
+	showRepo bool,
 ) []table.Column {
 	dLayout := ctx.Config.Defaults.Layout.Prs
 	sLayout := cfg.Layout
@@ -245,6 +338,9 @@ func GetSectionColumns(
 		sLayout.UpdatedAt,
 	)
 	repoLayout := config.MergeColumnConfigs(dLayout.Repo, sLayout.Repo)
+	if !showRepo {
+		repoLayout.Hidden = utils.BoolPtr(true)
+	}
 	titleLayout := config.MergeColumnConfigs(dLayout.Title, sLayout.Title)
 	authorLayout := config.MergeColumnConfigs(dLayout.Author, sLayout.Author)
 	assigneesLayout := config.MergeColumnConfigs(
@@ -267,6 +363,11 @@ func GetSectionColumns(
 				Width:  utils.IntPtr(3),
 				Hidden: stateLayout.Hidden,
 			},
+			{
+				Title:  "Repo",
+				Width:  repoLayout.Width,
+				Hidden: repoLayout.Hidden,
+			},
 			{
 				Title:  "Title",
 				Grow:   utils.BoolPtr(true),
@@ -361,11 +462,28 @@ func GetSectionColumns(
 	}
 }
 
+// updateBranchesWithPrs lists the branches of every repo, along with their
+// PRs, a repo after the other
 func (m *Model) updateBranchesWithPrs() {
+	all := make([]branch.Branch, 0)
+	for _, r := range m.repos {
+		all = append(all, m.getRepoBranches(r)...)
+	}
+	m.Branches = all
+}
+
+func (m *Model) getRepoBranches(r *localRepo) []branch.Branch {
+	prs := m.repoPrs(r)
 	branches := make([]branch.Branch, 0)
-	for _, ref := range m.repo.Branches {
-		b := branch.Branch{Ctx: m.Ctx, Data: ref, Columns: m.Table.Columns}
-		b.PR = findPRForRef(m.Prs, ref.Name)
+	for _, ref := range r.repo.Branches {
+		b := branch.Branch{
+			Ctx:      m.Ctx,
+			Data:     ref,
+			Columns:  m.Table.Columns,
+			RepoName: r.name,
+			RepoPath: r.path,
+		}
+		b.PR = findPRForRef(prs, ref.Name)
 
 		branches = append(branches, b)
 	}
@@ -385,7 +503,84 @@ func (m *Model) updateBranchesWithPrs() {
 		}
 		return strings.Compare(a.Data.Name, b.Data.Name)
 	})
-	m.Branches = branches
+	return orderStacks(branches, getStacks(prs))
+}
+
+// getStacks returns the stacks of the open PRs of a repo's branches
+func getStacks(prs []data.PullRequestData) []*data.StackNode {
+	stackPrs := make([]data.StackPR, 0, len(prs))
+	for _, pr := range prs {
+		stackPrs = append(stackPrs, data.StackPRFromPullRequest(pr))
+	}
+	return data.BuildStacks(stackPrs)
+}
+
+// orderStacks moves the branches of a stack of PRs together, where the
+// first of them is, and draws them as a tree under the stack's bottom PR.
+func orderStacks(branches []branch.Branch, stacks []*data.StackNode) []branch.Branch {
+	if len(stacks) == 0 {
+		return branches
+	}
+
+	byName := make(map[string]int, len(branches))
+	for i, b := range branches {
+		byName[b.Data.Name] = i
+	}
+	stackOf := make(map[string]*data.StackNode)
+	for _, stack := range stacks {
+		stack.Walk(func(node *data.StackNode, _ int, _ bool) {
+			stackOf[node.PR.HeadRefName] = stack
+		})
+	}
+
+	ordered := make([]branch.Branch, 0, len(branches))
+	added := make(map[string]bool, len(branches))
+	for _, b := range branches {
+		if added[b.Data.Name] {
+			continue
+		}
+		stack, ok := stackOf[b.Data.Name]
+		if !ok {
+			ordered = append(ordered, b)
+			added[b.Data.Name] = true
+			continue
+		}
+
+		// lines[d] is whether the tree line of depth d continues below
+		lines := []bool{}
+		stack.Walk(func(node *data.StackNode, depth int, isLast bool) {
+			lines = append(lines[:depth], !isLast)
+			i, ok := byName[node.PR.HeadRefName]
+			if !ok || added[node.PR.HeadRefName] {
+				return
+			}
+			sb := branches[i]
+			sb.StackPrefix = stackPrefix(lines, depth, isLast)
+			ordered = append(ordered, sb)
+			added[sb.Data.Name] = true
+		})
+	}
+	return ordered
+}
+
+func stackPrefix(lines []bool, depth int, isLast bool) string {
+	if depth == 0 {
+		return ""
+	}
+	prefix := strings.Builder{}
+	for d := 1; d < depth; d++ {
+		if lines[d] {
+			prefix.WriteString("│  ")
+		} else {
+			prefix.WriteString("   ")
+		}
+	}
+	if isLast {
+		prefix.WriteString("└─ ")
+	} else {
+		prefix.WriteString("├─ ")
+	}
+	return prefix.String()
 }
 
 func (m Model) BuildRows() []table.Row {
@@ -395,12 +590,10 @@ func (m Model) BuildRows() []table.Row {
 	filtered := m.getFilteredBranches()
 
 	for i, b := range filtered {
-		if strings.Contains(b.Data.Name, m.SearchValue) {
-			rows = append(
-				rows,
-				b.ToTableRow(currItem == i),
-			)
-		}
+		rows = append(
+			rows,
+			b.ToTableRow(currItem == i),
+		)
 	}
 
 	if rows == nil {
@@ -410,11 +603,13 @@ func (m Model) BuildRows() []table.Row {
 	return rows
 }
 
+// getFilteredBranches returns the branches whose name contains the search,
+// in the repos of its repo: qualifiers if it has any
 func (m *Model) getFilteredBranches() []branch.Branch {
-	sorted := m.Branches
+	repos, text := parseSearch(m.SearchValue)
 	filtered := make([]branch.Branch, 0)
-	for _, b := range sorted {
-		if strings.Contains(b.Data.Name, m.SearchValue) {
+	for _, b := range m.Branches {
+		if strings.Contains(b.Data.Name, text) && matchesRepo(b, repos) {
 			filtered = append(filtered, b)
 		}
 	}
@@ -431,7 +626,7 @@ func findPRForRef(prs []data.PullRequestData, branch string) *data.PullRequestDa
 }
 
 func (m *Model) NumRows() int {
-	return len(m.repo.Branches)
+	return len(m.getFilteredBranches())
 }
 
 type SectionPullRequestsFetchedMsg struct {
@@ -442,23 +637,24 @@ type SectionPullRequestsFetchedMsg struct {
 }
 
 func (m *Model) getCurrBranch() *branch.Branch {
+	filtered := m.getFilteredBranches()
 	idx := m.Table.GetCurrItem()
-	if idx < 0 || idx >= len(m.Branches) {
+	if idx < 0 || idx >= len(filtered) {
 		return nil
 	}
-	return &m.Branches[idx]
+	return &filtered[idx]
 }
 
 func (m *Model) GetCurrRow() data.RowData {
-	idx := m.Table.GetCurrItem()
-	if idx < 0 || idx >= len(m.repo.Branches) {
+	b := m.getCurrBranch()
+	if b == nil {
 		return nil
 	}
-	b := m.repo.Branches[idx]
-	pr := findPRForRef(m.Prs, b.Name)
 	return branch.BranchData{
-		Data: b,
-		PR:   pr,
+		Data:     b.Data,
+		PR:       b.PR,
+		RepoName: b.RepoName,
+		RepoPath: b.RepoPath,
 	}
 }
 
@@ -468,9 +664,11 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 	}
 
 	var cmds []tea.Cmd
-	if m.Ctx.RepoPath != "" {
-		cmds = append(cmds, m.readRepoCmd()...)
-		cmds = append(cmds, m.fetchRepoCmd()...)
+	for _, r := range m.repos {
+		cmds = append(cmds, m.readRepoCmd(r)...)
+		cmds = append(cmds, m.fetchRepoCmd(r)...)
+	}
+	if len(m.repos) > 0 {
 		cmds = append(cmds, m.fetchPRsCmd())
 	}
 
@@ -493,14 +691,19 @@ func FetchAllBranches(ctx *context.ProgramContext) (Model, tea.Cmd) {
 	)
 	m.refreshId = nextID()
 
-	if ctx.RepoPath != "" {
-		cmds = append(cmds, m.readRepoCmd()...)
-		cmds = append(cmds, m.fetchRepoCmd()...)
+	for _, r := range m.repos {
+		cmds = append(cmds, m.readRepoCmd(r)...)
+		cmds = append(cmds, m.fetchRepoCmd(r)...)
+	}
+	if len(m.repos) > 0 {
 		cmds = append(cmds, m.fetchPRsCmd())
 	}
 
 	if !m.isRefreshSetUp {
 		m.isRefreshSetUp = true
+		for _, r := range m.repos {
+			cmds = append(cmds, m.watchRefsCmd(r))
+		}
 		cmds = append(cmds, m.tickRefreshBranchesCmd())
 		cmds = append(cmds, m.tickFetchPrsCmd())
 	}
@@ -508,6 +711,17 @@ func FetchAllBranches(ctx *context.ProgramContext) (Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// StopWatchingRefs closes the watchers of the repos' refs, as the section is
+// about to be replaced by one fetched again, with watchers of its own
+func (m *Model) StopWatchingRefs() {
+	for _, r := range m.repos {
+		if r.refsWatcher != nil {
+			r.refsWatcher.Close()
+			r.refsWatcher = nil
+		}
+	}
+}
+
 func (m Model) GetDimensions() constants.Dimensions {
 	if m.Ctx == nil {
 		return constants.Dimensions{}
@@ -552,14 +766,70 @@ func (m *Model) SetIsLoading(val bool) {
 	m.Table.SetIsLoading(val)
 }
 
+// maxPrunePreview is how many of the branches to prune the prompt names
+const maxPrunePreview = 5
+
+// prunePreview names the first maxPrunePreview branches to prune, along with
+// their repo when withRepo is set
+func prunePreview(prunable []branch.Branch, withRepo bool) string {
+	names := make([]string, 0, maxPrunePreview)
+	for _, b := range prunable[:min(len(prunable), maxPrunePreview)] {
+		if withRepo {
+			names = append(names, b.RepoName+":"+b.Data.Name)
+		} else {
+			names = append(names, b.Data.Name)
+		}
+	}
+	preview := strings.Join(names, ", ")
+	if len(prunable) > maxPrunePreview {
+		preview += fmt.Sprintf(" and %d more", len(prunable)-maxPrunePreview)
+	}
+	return preview
+}
+
+// countShownRepos returns how many repos the filtered branches are of
+func (m *Model) countShownRepos() int {
+	repos := make(map[string]bool)
+	for _, b := range m.getFilteredBranches() {
+		repos[b.RepoPath] = true
+	}
+	return len(repos)
+}
+
+// GetPromptConfirmation previews the branches that pruning merged branches
+// would delete
+func (m *Model) GetPromptConfirmation() string {
+	if !m.IsPromptConfirmationShown || m.PromptConfirmationAction != "prune_merged" {
+		return m.BaseModel.GetPromptConfirmation()
+	}
+
+	prunable := m.getPrunableBranches()
+	var prompt string
+	if len(prunable) == 0 {
+		prompt = "No merged branches to delete, press enter to continue "
+	} else {
+		prompt = fmt.Sprintf("Delete %d merged branches (%s)? (y/N) ", len(prunable),
+			prunePreview(prunable, m.countShownRepos() > 1))
+	}
+	m.PromptConfirmationBox.SetPrompt(prompt)
+
+	return m.Ctx.Styles.ListViewPort.PagerStyle.Render(m.PromptConfirmationBox.View())
+}
+
+// GetPagerContent shows the working tree changes of the selected branch's
+// repo
 func (m *Model) GetPagerContent() string {
+	var status git.Status
+	if r := m.getCurrRepo(); r != nil {
+		status = r.repo.Status
+	}
 	s := lipgloss.NewStyle().Background(m.Ctx.Styles.ListViewPort.PagerStyle.GetBackground())
 	mod := s.Foreground(lipgloss.Color("#e0af68")).Render(
-		fmt.Sprintf(" %d", len(m.repo.Status.Modified)))
+		fmt.Sprintf(" %d", len(status.Modified)))
 	plus := s.Foreground(m.Ctx.Theme.SuccessText).Render(
-		fmt.Sprintf(" %d", len(m.repo.Status.Added)))
+		fmt.Sprintf(" %d", len(status.Added)))
 	minus := s.Foreground(m.Ctx.Theme.ErrorText).Render(
-		fmt.Sprintf(" %d", len(m.repo.Status.Removed)))
+		fmt.Sprintf(" %d", len(status.Removed)))
 	spacer := s.Render(" ")
 	return m.Ctx.Styles.ListViewPort.PagerStyle.Render(
 		lipgloss.JoinHorizontal(lipgloss.Top, plus, spacer, minus, spacer, mod))
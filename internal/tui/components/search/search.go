@@ -15,15 +15,18 @@ import (
 )
 
 type Model struct {
-	ctx          *context.ProgramContext
-	initialValue string
-	cmpctl       *cmpcontroller.Controller
+	ctx           *context.ProgramContext
+	initialValue  string
+	notifications bool
+	cmpctl        *cmpcontroller.Controller
 }
 
 type SearchOptions struct {
 	Prefix       string
 	InitialValue string
 	Placeholder  string
+	// Notifications completes the qualifiers of notification searches
+	Notifications bool
 }
 
 func NewModel(ctx *context.ProgramContext, opts SearchOptions) Model {
@@ -64,9 +67,10 @@ func NewModel(ctx *context.ProgramContext, opts SearchOptions) Model {
 	ctl.SetSelectStyles(selectStyles)
 
 	m := Model{
-		ctx:          ctx,
-		initialValue: opts.InitialValue,
-		cmpctl:       &ctl,
+		ctx:           ctx,
+		initialValue:  opts.InitialValue,
+		notifications: opts.Notifications,
+		cmpctl:        &ctl,
 	}
 
 	m.cmpctl.Exit()
@@ -121,7 +125,9 @@ func (m *Model) Repo() (cmpcontroller.RepoRef, bool) {
 
 func (m *Model) Focus() tea.Cmd {
 	repo, _ := m.Repo()
-	m.cmpctl.SetAutocompleteSource(&fuzzyselect.SearchQuerySource{})
+	m.cmpctl.SetAutocompleteSource(&fuzzyselect.SearchQuerySource{
+		Notifications: m.notifications,
+	})
 	cmd := m.cmpctl.Enter(cmpcontroller.EnterOptions{
 		Mode:                             cmpcontroller.ModeSearch,
 		Prompt:                           "",
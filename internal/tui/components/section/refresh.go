@@ -0,0 +1,69 @@
+package section
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// maxRefreshBackoffExponent caps how far the refresh interval is stretched
+// after consecutive failed fetches (2^3 = 8x the configured interval).
+const maxRefreshBackoffExponent = 3
+
+// refreshJitterRatio spreads section refreshes so sections sharing an
+// interval don't all hit the API in the same instant.
+const refreshJitterRatio = 0.1
+
+// GetRefetchInterval returns how often the section should be refetched in the
+// background. A section's own refetchIntervalMinutes overrides
+// defaults.refetchIntervalMinutes; zero disables background refreshes.
+func (m *BaseModel) GetRefetchInterval() time.Duration {
+	minutes := m.Ctx.Config.Defaults.RefetchIntervalMinutes
+	if m.Config.RefetchIntervalMinutes != nil {
+		minutes = *m.Config.RefetchIntervalMinutes
+	}
+	return time.Duration(max(0, minutes)) * time.Minute
+}
+
+func (m *BaseModel) GetLastFetchTaskId() string {
+	return m.LastFetchTaskId
+}
+
+// SetFetchError records the outcome of the section's latest fetch. A nil
+// error clears any previous failure.
+func (m *BaseModel) SetFetchError(err error) {
+	if err == nil {
+		m.FetchError = nil
+		m.FailedFetches = 0
+		return
+	}
+
+	m.FetchError = err
+	m.FailedFetches++
+	m.IsLoading = false
+	m.Table.SetIsLoading(false)
+}
+
+func (m *BaseModel) GetFetchError() error {
+	return m.FetchError
+}
+
+func (m *BaseModel) GetFailedFetches() int {
+	return m.FailedFetches
+}
+
+// NextRefreshDelay returns how long to wait before the next background
+// refresh. The interval doubles for every consecutive failure (up to
+// maxRefreshBackoffExponent) and is jittered by ±refreshJitterRatio.
+func NextRefreshDelay(interval time.Duration, failedFetches int) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+
+	delay := interval << min(max(0, failedFetches), maxRefreshBackoffExponent)
+	jitter := time.Duration(float64(delay) * refreshJitterRatio)
+	if jitter > 0 {
+		delay += time.Duration(rand.Int64N(int64(2*jitter))) - jitter
+	}
+
+	return delay
+}
@@ -0,0 +1,84 @@
+package section
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+)
+
+func TestGetRefetchInterval(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name     string
+		defaults int
+		section  *int
+		want     time.Duration
+	}{
+		{name: "uses defaults", defaults: 30, want: 30 * time.Minute},
+		{name: "section override", defaults: 30, section: intPtr(5), want: 5 * time.Minute},
+		{name: "section disables", defaults: 30, section: intPtr(0), want: 0},
+		{name: "negative is disabled", defaults: 30, section: intPtr(-1), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := BaseModel{
+				Ctx: &context.ProgramContext{
+					Config: &config.Config{
+						Defaults: config.Defaults{RefetchIntervalMinutes: tt.defaults},
+					},
+				},
+				Config: config.SectionConfig{RefetchIntervalMinutes: tt.section},
+			}
+			require.Equal(t, tt.want, m.GetRefetchInterval())
+		})
+	}
+}
+
+func TestSetFetchError(t *testing.T) {
+	m := BaseModel{IsLoading: true}
+
+	m.SetFetchError(errors.New("boom"))
+	m.SetFetchError(errors.New("boom"))
+	require.Error(t, m.GetFetchError())
+	require.Equal(t, 2, m.GetFailedFetches())
+	require.False(t, m.IsLoading)
+
+	m.SetFetchError(nil)
+	require.NoError(t, m.GetFetchError())
+	require.Zero(t, m.GetFailedFetches())
+}
+
+func TestNextRefreshDelay(t *testing.T) {
+	interval := 10 * time.Minute
+
+	tests := []struct {
+		name          string
+		failedFetches int
+		base          time.Duration
+	}{
+		{name: "no failures", failedFetches: 0, base: interval},
+		{name: "one failure", failedFetches: 1, base: 2 * interval},
+		{name: "two failures", failedFetches: 2, base: 4 * interval},
+		{name: "capped", failedFetches: 10, base: 8 * interval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jitter := time.Duration(float64(tt.base) * refreshJitterRatio)
+			for range 100 {
+				delay := NextRefreshDelay(interval, tt.failedFetches)
+				require.GreaterOrEqual(t, delay, tt.base-jitter)
+				require.Less(t, delay, tt.base+jitter)
+			}
+		})
+	}
+
+	require.Zero(t, NextRefreshDelay(0, 0))
+}
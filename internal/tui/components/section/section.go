@@ -49,6 +49,8 @@ type BaseModel struct {
 	ShowAuthorIcon            bool
 	IsFilteredByCurrentRemote bool
 	IsLoading                 bool
+	FetchError                error
+	FailedFetches             int
 }
 
 type NewSectionOptions struct {
@@ -106,8 +108,9 @@ func NewModel(
 		SingularForm: options.Singular,
 		PluralForm:   options.Plural,
 		SearchBar: search.NewModel(ctx, search.SearchOptions{
-			Prefix:       fmt.Sprintf("is:%s", options.Type),
-			InitialValue: filters,
+			Prefix:        fmt.Sprintf("is:%s", options.Type),
+			InitialValue:  filters,
+			Notifications: options.Type == "notification",
 		}),
 		SearchValue:               filters,
 		IsSearching:               false,
@@ -143,6 +146,7 @@ type Section interface {
 	Table
 	Search
 	PromptConfirmation
+	Refresh
 	GetConfig() config.SectionConfig
 	UpdateProgramContext(ctx *context.ProgramContext)
 	MakeSectionCmd(cmd tea.Cmd) tea.Cmd
@@ -186,6 +190,15 @@ type Search interface {
 	ResetPageInfo()
 }
 
+type Refresh interface {
+	GetRefetchInterval() time.Duration
+	GetLastFetchTaskId() string
+	SetFetchError(err error)
+	GetFetchError() error
+	GetFailedFetches() int
+	LastUpdated() time.Time
+}
+
 type PromptConfirmation interface {
 	SetIsPromptConfirmationShown(val bool) tea.Cmd
 	IsPromptConfirmationFocused() bool
@@ -500,12 +513,26 @@ func (m *BaseModel) GetPromptConfirmation() string {
 			prompt = "Are you sure you want to reopen this issue? (y/N) "
 		case m.PromptConfirmationAction == "delete" && m.Ctx.View == config.RepoView:
 			prompt = "Are you sure you want to delete this branch? (y/N) "
+		case m.PromptConfirmationAction == "restack" && m.Ctx.View == config.RepoView:
+			prompt = "Are you sure you want to rebase the branches of this stack onto their parents? (y/N) "
+		case m.PromptConfirmationAction == "abort_rebase" && m.Ctx.View == config.RepoView:
+			prompt = "Are you sure you want to abort the rebase, losing the conflicts resolved so far? (y/N) "
+		case m.PromptConfirmationAction == "retarget" && m.Ctx.View == config.RepoView:
+			prompt = "Are you sure you want to retarget the PRs stacked on merged PRs? (y/N) "
+		case m.PromptConfirmationAction == "prune_worktrees" && m.Ctx.View == config.RepoView:
+			prompt = "Are you sure you want to remove the worktrees of merged and closed PRs? (y/N) "
 		case m.PromptConfirmationAction == "new" && m.Ctx.View == config.RepoView:
 			prompt = "Enter branch name: "
-		case m.PromptConfirmationAction == "create_pr" && m.Ctx.View == config.RepoView:
-			prompt = "Enter PR title: "
 		case m.PromptConfirmationAction == "done_all" && m.Ctx.View == config.NotificationsView:
 			prompt = "Are you sure you want to mark all as done? (y/N) "
+		case m.PromptConfirmationAction == "snooze" && m.Ctx.View == config.NotificationsView:
+			prompt = "Snooze until (1) in 1h (2) tomorrow 9am (3) next Monday 9am, or for a duration like 3d: "
+		case m.PromptConfirmationAction == "unsubscribe" && m.Ctx.View == config.NotificationsView:
+			prompt = "Unsubscribe from (1) this thread, or mute (2) this repo (3) this repo's CI: "
+		case strings.HasPrefix(m.PromptConfirmationAction, "dismiss_") && m.Ctx.View == config.AlertsView:
+			prompt = dismissAlertPrompt(data.AlertKind(strings.TrimPrefix(m.PromptConfirmationAction, "dismiss_")))
+		case m.PromptConfirmationAction == "reopen" && m.Ctx.View == config.AlertsView:
+			prompt = "Are you sure you want to reopen this alert? (y/N) "
 		}
 
 		m.PromptConfirmationBox.SetPrompt(prompt)
@@ -515,3 +542,14 @@ func (m *BaseModel) GetPromptConfirmation() string {
 
 	return ""
 }
+
+// dismissAlertPrompt lists the reasons an alert of the given kind can be
+// dismissed with, to be answered with a number and an optional comment.
+func dismissAlertPrompt(kind data.AlertKind) string {
+	reasons := data.AlertDismissReasons(kind)
+	options := make([]string, 0, len(reasons))
+	for i, reason := range reasons {
+		options = append(options, fmt.Sprintf("(%d) %s", i+1, strings.ReplaceAll(reason, "_", " ")))
+	}
+	return fmt.Sprintf("Dismiss as %s, then an optional comment: ", strings.Join(options, " "))
+}
@@ -142,6 +142,10 @@ func (m *Model) UpdateTabTitles() {
 				utils.ShortNumber(tab.section.GetTotalCount()))
 		}
 
+		if i > 0 && !tab.section.GetIsLoading() {
+			title += staleIndicator(tab.section)
+		}
+
 		titles = append(titles, title)
 	}
 
@@ -150,6 +154,21 @@ func (m *Model) UpdateTabTitles() {
 	m.carousel.SetCursor(oldCursor)
 }
 
+// staleIndicator marks sections whose last refresh failed, and otherwise
+// shows how long ago the section was last updated.
+func staleIndicator(s section.Section) string {
+	if s.GetFetchError() != nil {
+		return " " + constants.FailureIcon
+	}
+
+	lastUpdated := s.LastUpdated()
+	if lastUpdated.IsZero() {
+		return ""
+	}
+
+	return fmt.Sprintf(" %s%s", constants.SmallDotIcon, utils.TimeElapsed(lastUpdated))
+}
+
 func (m *Model) viewLogo() string {
 	version := lipgloss.NewStyle().Foreground(m.ctx.Theme.SecondaryText).Render(m.ctx.Version)
 	if m.latestVersion != "" && m.ctx.Version != "dev" && m.ctx.Version != m.latestVersion {
@@ -1,6 +1,8 @@
 package testdata
 
 import (
+	"time"
+
 	tea "charm.land/bubbletea/v2"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/config"
@@ -11,8 +13,10 @@ import (
 )
 
 type TestSection struct {
-	Config  config.SectionConfig
-	loading bool
+	Config      config.SectionConfig
+	loading     bool
+	fetchError  error
+	lastUpdated time.Time
 }
 
 // BuildRows implements section.Section.
@@ -184,3 +188,33 @@ func (t *TestSection) View() string {
 func (t *TestSection) ViewCompletions() string {
 	panic("unimplemented")
 }
+
+// GetRefetchInterval implements section.Section.
+func (t *TestSection) GetRefetchInterval() time.Duration {
+	return 0
+}
+
+// GetLastFetchTaskId implements section.Section.
+func (t *TestSection) GetLastFetchTaskId() string {
+	return ""
+}
+
+// SetFetchError implements section.Section.
+func (t *TestSection) SetFetchError(err error) {
+	t.fetchError = err
+}
+
+// GetFetchError implements section.Section.
+func (t *TestSection) GetFetchError() error {
+	return t.fetchError
+}
+
+// GetFailedFetches implements section.Section.
+func (t *TestSection) GetFailedFetches() int {
+	return 0
+}
+
+// LastUpdated implements section.Section.
+func (t *TestSection) LastUpdated() time.Time {
+	return t.lastUpdated
+}
@@ -14,6 +14,7 @@ import (
 
 type UpdateIssueMsg struct {
 	IssueNumber      int
+	IssueUrl         string
 	Labels           *data.IssueLabels
 	NewComment       *data.IssueComment
 	IsClosed         *bool
@@ -21,6 +22,16 @@ type UpdateIssueMsg struct {
 	RemovedAssignees *data.Assignees
 }
 
+// IsFor reports whether the update is for the issue with the given number and
+// URL, so issues with the same number in different repos aren't mixed up.
+// Updates without a URL fall back to the number.
+func (msg UpdateIssueMsg) IsFor(number int, url string) bool {
+	if msg.IssueUrl != "" {
+		return msg.IssueUrl == url
+	}
+	return msg.IssueNumber == number
+}
+
 func CloseIssue(
 	ctx *context.ProgramContext,
 	section SectionIdentifier,
@@ -34,7 +45,7 @@ func CloseIssue(
 			"close",
 			fmt.Sprint(issueNumber),
 			"-R",
-			issue.GetRepoNameWithOwner(),
+			data.RepoArg(issue),
 		},
 		Section:      section,
 		StartText:    fmt.Sprintf("Closing issue #%d", issueNumber),
@@ -42,6 +53,7 @@ func CloseIssue(
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdateIssueMsg{
 				IssueNumber: issueNumber,
+				IssueUrl:    issue.GetUrl(),
 				IsClosed:    utils.BoolPtr(true),
 			}
 		},
@@ -61,7 +73,7 @@ func ReopenIssue(
 			"reopen",
 			fmt.Sprint(issueNumber),
 			"-R",
-			issue.GetRepoNameWithOwner(),
+			data.RepoArg(issue),
 		},
 		Section:      section,
 		StartText:    fmt.Sprintf("Reopening issue #%d", issueNumber),
@@ -69,6 +81,7 @@ func ReopenIssue(
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdateIssueMsg{
 				IssueNumber: issueNumber,
+				IssueUrl:    issue.GetUrl(),
 				IsClosed:    utils.BoolPtr(false),
 			}
 		},
@@ -87,7 +100,7 @@ func AssignIssue(
 		"edit",
 		fmt.Sprint(issueNumber),
 		"-R",
-		issue.GetRepoNameWithOwner(),
+		data.RepoArg(issue),
 	}
 	for _, assignee := range usernames {
 		args = append(args, "--add-assignee", assignee)
@@ -108,6 +121,7 @@ func AssignIssue(
 			}
 			return UpdateIssueMsg{
 				IssueNumber:    issueNumber,
+				IssueUrl:       issue.GetUrl(),
 				AddedAssignees: &returnedAssignees,
 			}
 		},
@@ -126,7 +140,7 @@ func UnassignIssue(
 		"edit",
 		fmt.Sprint(issueNumber),
 		"-R",
-		issue.GetRepoNameWithOwner(),
+		data.RepoArg(issue),
 	}
 	for _, assignee := range usernames {
 		args = append(args, "--remove-assignee", assignee)
@@ -147,6 +161,7 @@ func UnassignIssue(
 			}
 			return UpdateIssueMsg{
 				IssueNumber:      issueNumber,
+				IssueUrl:         issue.GetUrl(),
 				RemovedAssignees: &returnedAssignees,
 			}
 		},
@@ -167,7 +182,7 @@ func CommentOnIssue(
 			"comment",
 			fmt.Sprint(issueNumber),
 			"-R",
-			issue.GetRepoNameWithOwner(),
+			data.RepoArg(issue),
 			"-b",
 			body,
 		},
@@ -177,6 +192,7 @@ func CommentOnIssue(
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdateIssueMsg{
 				IssueNumber: issueNumber,
+				IssueUrl:    issue.GetUrl(),
 				NewComment: &data.IssueComment{
 					Author:    struct{ Login string }{Login: ctx.User},
 					Body:      body,
@@ -200,7 +216,7 @@ func LabelIssue(
 		"edit",
 		fmt.Sprint(issueNumber),
 		"-R",
-		issue.GetRepoNameWithOwner(),
+		data.RepoArg(issue),
 	}
 
 	labelsMap := make(map[string]bool)
@@ -239,6 +255,7 @@ func LabelIssue(
 			}
 			return UpdateIssueMsg{
 				IssueNumber: issueNumber,
+				IssueUrl:    issue.GetUrl(),
 				Labels:      &returnedLabels,
 			}
 		},
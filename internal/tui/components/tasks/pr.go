@@ -1,13 +1,16 @@
 package tasks
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/log/v2"
+	gitm "github.com/aymanbagabas/git-module"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
@@ -22,6 +25,7 @@ type SectionIdentifier struct {
 
 type UpdatePRMsg struct {
 	PrNumber         int
+	PrUrl            string
 	IsClosed         *bool
 	NewComment       *data.Comment
 	ReadyForReview   *bool
@@ -31,6 +35,16 @@ type UpdatePRMsg struct {
 	Labels           *data.PRLabels
 }
 
+// IsFor reports whether the update is for the PR with the given number and
+// URL, so PRs with the same number in different repos aren't mixed up. Updates
+// without a URL fall back to the number.
+func (msg UpdatePRMsg) IsFor(number int, url string) bool {
+	if msg.PrUrl != "" {
+		return msg.PrUrl == url
+	}
+	return msg.PrNumber == number
+}
+
 type UpdateBranchMsg struct {
 	Name      string
 	IsCreated *bool
@@ -75,7 +89,14 @@ func fireTask(ctx *context.ProgramContext, task GitHubTask) tea.Cmd {
 	})
 }
 
-func OpenBranchPR(ctx *context.ProgramContext, section SectionIdentifier, branch string) tea.Cmd {
+// OpenBranchPR opens the PR of the branch of repo in the browser, where repo
+// is a repo's URL or [HOST/]OWNER/REPO name
+func OpenBranchPR(
+	ctx *context.ProgramContext,
+	section SectionIdentifier,
+	repo string,
+	branch string,
+) tea.Cmd {
 	return fireTask(ctx, GitHubTask{
 		Id: fmt.Sprintf("branch_open_%s", branch),
 		Args: []string{
@@ -84,7 +105,7 @@ func OpenBranchPR(ctx *context.ProgramContext, section SectionIdentifier, branch
 			"--web",
 			branch,
 			"-R",
-			ctx.RepoUrl,
+			repo,
 		},
 		Section:      section,
 		StartText:    fmt.Sprintf("Opening PR for branch %s", branch),
@@ -104,7 +125,7 @@ func ReopenPR(ctx *context.ProgramContext, section SectionIdentifier, pr data.Ro
 			"reopen",
 			fmt.Sprint(prNumber),
 			"-R",
-			pr.GetRepoNameWithOwner(),
+			data.RepoArg(pr),
 		},
 		Section:      section,
 		StartText:    fmt.Sprintf("Reopening PR #%d", prNumber),
@@ -112,6 +133,7 @@ func ReopenPR(ctx *context.ProgramContext, section SectionIdentifier, pr data.Ro
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdatePRMsg{
 				PrNumber: prNumber,
+				PrUrl:    pr.GetUrl(),
 				IsClosed: utils.BoolPtr(false),
 			}
 		},
@@ -127,7 +149,7 @@ func ClosePR(ctx *context.ProgramContext, section SectionIdentifier, pr data.Row
 			"close",
 			fmt.Sprint(prNumber),
 			"-R",
-			pr.GetRepoNameWithOwner(),
+			data.RepoArg(pr),
 		},
 		Section:      section,
 		StartText:    fmt.Sprintf("Closing PR #%d", prNumber),
@@ -135,6 +157,7 @@ func ClosePR(ctx *context.ProgramContext, section SectionIdentifier, pr data.Row
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdatePRMsg{
 				PrNumber: prNumber,
+				PrUrl:    pr.GetUrl(),
 				IsClosed: utils.BoolPtr(true),
 			}
 		},
@@ -150,7 +173,7 @@ func PRReady(ctx *context.ProgramContext, section SectionIdentifier, pr data.Row
 			"ready",
 			fmt.Sprint(prNumber),
 			"-R",
-			pr.GetRepoNameWithOwner(),
+			data.RepoArg(pr),
 		},
 		Section:      section,
 		StartText:    fmt.Sprintf("Marking PR #%d as ready for review", prNumber),
@@ -158,6 +181,7 @@ func PRReady(ctx *context.ProgramContext, section SectionIdentifier, pr data.Row
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdatePRMsg{
 				PrNumber:       prNumber,
+				PrUrl:          pr.GetUrl(),
 				ReadyForReview: utils.BoolPtr(true),
 			}
 		},
@@ -172,7 +196,7 @@ func MergePR(ctx *context.ProgramContext, section SectionIdentifier, pr data.Row
 		"merge",
 		fmt.Sprint(prNumber),
 		"-R",
-		pr.GetRepoNameWithOwner(),
+		data.RepoArg(pr),
 	)
 
 	taskId := fmt.Sprintf("merge_%d", prNumber)
@@ -195,49 +219,144 @@ func MergePR(ctx *context.ProgramContext, section SectionIdentifier, pr data.Row
 			Err:         err,
 			Msg: UpdatePRMsg{
 				PrNumber: prNumber,
+				PrUrl:    pr.GetUrl(),
 				IsMerged: &isMerged,
 			},
 		}
 	}))
 }
 
-func CreatePR(
-	ctx *context.ProgramContext,
-	section SectionIdentifier,
-	branchName string,
-	title string,
-) tea.Cmd {
-	c := exec.Command(
-		"gh",
+// CreatePROptions is a PR to create from a branch of a local clone
+type CreatePROptions struct {
+	// Dir is the repo's local clone and Repo how gh's -R flag names it
+	Dir  string
+	Repo string
+	// Head is the branch to create the PR from and Base the one it's merged
+	// into
+	Head string
+	// RemoteHead is Head's name on the remote it's pushed to, when it's a
+	// different one, and HeadOwner the owner of that remote when it's a fork
+	RemoteHead string
+	HeadOwner  string
+	Base       string
+	Title      string
+	Body       string
+	Draft      bool
+	Reviewers  []string
+	Assignees  []string
+	Labels     []string
+	// PushRemote is the remote the branch is pushed to before the PR is
+	// created, when the remote is missing commits of the branch. SetUpstream
+	// makes it the branch's upstream, for branches that don't track one.
+	PushRemote  string
+	SetUpstream bool
+}
+
+// remoteHead returns the name of the head branch on the remote
+func (opts CreatePROptions) remoteHead() string {
+	if opts.RemoteHead != "" {
+		return opts.RemoteHead
+	}
+	return opts.Head
+}
+
+// headRef returns the head branch as gh pr create takes it, prefixed with the
+// owner of the fork it's on
+func (opts CreatePROptions) headRef() string {
+	if opts.HeadOwner != "" {
+		return opts.HeadOwner + ":" + opts.remoteHead()
+	}
+	return opts.remoteHead()
+}
+
+// createPRArgs returns the args of gh pr create, which doesn't prompt for
+// anything given a title, a body and the head branch
+func createPRArgs(opts CreatePROptions) []string {
+	args := []string{
 		"pr",
 		"create",
 		"--title",
-		title,
-		"-R",
-		ctx.RepoUrl,
-	)
+		opts.Title,
+		"--body",
+		opts.Body,
+		"--head",
+		opts.headRef(),
+	}
+	if opts.Base != "" {
+		args = append(args, "--base", opts.Base)
+	}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+	if len(opts.Reviewers) > 0 {
+		args = append(args, "--reviewer", strings.Join(opts.Reviewers, ","))
+	}
+	if len(opts.Assignees) > 0 {
+		args = append(args, "--assignee", strings.Join(opts.Assignees, ","))
+	}
+	if len(opts.Labels) > 0 {
+		args = append(args, "--label", strings.Join(opts.Labels, ","))
+	}
+	return append(args, "-R", opts.Repo)
+}
 
-	taskId := fmt.Sprintf("create_pr_%s", title)
+// CreatePR creates a PR in the repo with gh, run in its local clone, after
+// pushing the branch when it's missing from its remote
+func CreatePR(
+	ctx *context.ProgramContext,
+	section SectionIdentifier,
+	opts CreatePROptions,
+) tea.Cmd {
+	taskId := fmt.Sprintf("create_pr_%s", opts.Head)
 	task := context.Task{
 		Id:           taskId,
-		StartText:    fmt.Sprintf(`Creating PR "%s"`, title),
-		FinishedText: fmt.Sprintf(`PR "%s" has been created`, title),
+		StartText:    fmt.Sprintf(`Creating PR "%s"`, opts.Title),
+		FinishedText: fmt.Sprintf(`PR "%s" has been created`, opts.Title),
 		State:        context.TaskStart,
 		Error:        nil,
 	}
 	startCmd := ctx.StartTask(task)
 
-	return tea.Batch(startCmd, tea.ExecProcess(c, func(err error) tea.Msg {
-		isCreated := err == nil && c.ProcessState.ExitCode() == 0
+	return tea.Batch(startCmd, func() tea.Msg {
+		finished := func(err error) tea.Msg {
+			isCreated := err == nil
+			return constants.TaskFinishedMsg{
+				SectionId:   section.Id,
+				SectionType: section.Type,
+				TaskId:      taskId,
+				Err:         err,
+				Msg:         UpdateBranchMsg{Name: opts.Head, IsCreated: &isCreated},
+			}
+		}
 
-		return constants.TaskFinishedMsg{
-			SectionId:   section.Id,
-			SectionType: section.Type,
-			TaskId:      taskId,
-			Err:         nil,
-			Msg:         UpdateBranchMsg{Name: branchName, IsCreated: &isCreated},
+		if opts.PushRemote != "" {
+			var args []string
+			if opts.SetUpstream {
+				args = append(args, "--set-upstream")
+			}
+			refspec := opts.Head
+			if opts.remoteHead() != opts.Head {
+				refspec += ":" + opts.remoteHead()
+			}
+			err := gitm.Push(opts.Dir, opts.PushRemote, refspec,
+				gitm.PushOptions{CommandOptions: gitm.CommandOptions{Args: args}})
+			if err != nil {
+				return finished(fmt.Errorf("failed pushing %s: %w", opts.Head, err))
+			}
 		}
-	}))
+
+		args := createPRArgs(opts)
+		log.Info("Running task", "cmd", "gh "+strings.Join(args, " "))
+		c := exec.Command("gh", args...)
+		c.Dir = opts.Dir
+		if output, err := c.CombinedOutput(); err != nil {
+			if msg := strings.TrimSpace(string(output)); msg != "" {
+				err = errors.New(msg)
+			}
+			return finished(fmt.Errorf("failed creating PR: %w", err))
+		}
+		return finished(nil)
+	})
 }
 
 func updatePRTask(section SectionIdentifier, pr data.RowData) GitHubTask {
@@ -249,7 +368,7 @@ func updatePRTask(section SectionIdentifier, pr data.RowData) GitHubTask {
 			"update-branch",
 			fmt.Sprint(prNumber),
 			"-R",
-			pr.GetRepoNameWithOwner(),
+			data.RepoArg(pr),
 		},
 		Section:      section,
 		StartText:    fmt.Sprintf("Updating PR #%d", prNumber),
@@ -257,6 +376,7 @@ func updatePRTask(section SectionIdentifier, pr data.RowData) GitHubTask {
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdatePRMsg{
 				PrNumber: prNumber,
+				PrUrl:    pr.GetUrl(),
 			}
 		},
 	}
@@ -278,7 +398,7 @@ func AssignPR(
 		"edit",
 		fmt.Sprint(prNumber),
 		"-R",
-		pr.GetRepoNameWithOwner(),
+		data.RepoArg(pr),
 	}
 	for _, assignee := range usernames {
 		args = append(args, "--add-assignee", assignee)
@@ -299,6 +419,7 @@ func AssignPR(
 			}
 			return UpdatePRMsg{
 				PrNumber:       prNumber,
+				PrUrl:          pr.GetUrl(),
 				AddedAssignees: &returnedAssignees,
 			}
 		},
@@ -317,7 +438,7 @@ func UnassignPR(
 		"edit",
 		fmt.Sprint(prNumber),
 		"-R",
-		pr.GetRepoNameWithOwner(),
+		data.RepoArg(pr),
 	}
 	for _, assignee := range usernames {
 		args = append(args, "--remove-assignee", assignee)
@@ -338,6 +459,7 @@ func UnassignPR(
 			}
 			return UpdatePRMsg{
 				PrNumber:         prNumber,
+				PrUrl:            pr.GetUrl(),
 				RemovedAssignees: &returnedAssignees,
 			}
 		},
@@ -358,7 +480,7 @@ func CommentOnPR(
 			"comment",
 			fmt.Sprint(prNumber),
 			"-R",
-			pr.GetRepoNameWithOwner(),
+			data.RepoArg(pr),
 			"-b",
 			body,
 		},
@@ -368,6 +490,7 @@ func CommentOnPR(
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdatePRMsg{
 				PrNumber: prNumber,
+				PrUrl:    pr.GetUrl(),
 				NewComment: &data.Comment{
 					Author:    struct{ Login string }{Login: ctx.User},
 					Body:      body,
@@ -389,7 +512,7 @@ func ApprovePR(
 		"pr",
 		"review",
 		"-R",
-		pr.GetRepoNameWithOwner(),
+		data.RepoArg(pr),
 		fmt.Sprint(prNumber),
 		"--approve",
 	}
@@ -405,6 +528,7 @@ func ApprovePR(
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdatePRMsg{
 				PrNumber: prNumber,
+				PrUrl:    pr.GetUrl(),
 			}
 		},
 	})
@@ -417,6 +541,10 @@ func ApproveWorkflows(
 ) tea.Cmd {
 	prNumber := pr.GetNumber()
 	repo := pr.GetRepoNameWithOwner()
+	apiArgs := []string{"api"}
+	if host := data.RowHost(pr); host != "" {
+		apiArgs = append(apiArgs, "--hostname", host)
+	}
 	taskId := buildTaskId("pr_approve_workflows", prNumber)
 
 	task := context.Task{
@@ -431,7 +559,7 @@ func ApproveWorkflows(
 	return tea.Batch(startCmd, func() tea.Msg {
 		// Step 1: Get head SHA
 		shaCmd := exec.Command("gh", "pr", "view", fmt.Sprint(prNumber),
-			"-R", repo, "--json", "headRefOid", "--jq", ".headRefOid")
+			"-R", data.RepoArg(pr), "--json", "headRefOid", "--jq", ".headRefOid")
 		shaOut, err := shaCmd.Output()
 		if err != nil {
 			return constants.TaskFinishedMsg{
@@ -439,15 +567,15 @@ func ApproveWorkflows(
 				SectionId:   section.Id,
 				SectionType: section.Type,
 				Err:         fmt.Errorf("failed to get head SHA: %w", err),
-				Msg:         UpdatePRMsg{PrNumber: prNumber},
+				Msg:         UpdatePRMsg{PrNumber: prNumber, PrUrl: pr.GetUrl()},
 			}
 		}
 		sha := strings.TrimSpace(string(shaOut))
 
 		// Step 2: Get workflow run IDs awaiting approval
-		runsCmd := exec.Command("gh", "api",
+		runsCmd := exec.Command("gh", append(slices.Clone(apiArgs),
 			fmt.Sprintf("repos/%s/actions/runs?status=action_required&head_sha=%s", repo, sha),
-			"--jq", ".workflow_runs[].id")
+			"--jq", ".workflow_runs[].id")...)
 		runsOut, err := runsCmd.Output()
 		if err != nil {
 			return constants.TaskFinishedMsg{
@@ -455,7 +583,7 @@ func ApproveWorkflows(
 				SectionId:   section.Id,
 				SectionType: section.Type,
 				Err:         fmt.Errorf("failed to get workflow runs: %w", err),
-				Msg:         UpdatePRMsg{PrNumber: prNumber},
+				Msg:         UpdatePRMsg{PrNumber: prNumber, PrUrl: pr.GetUrl()},
 			}
 		}
 
@@ -466,7 +594,7 @@ func ApproveWorkflows(
 				SectionId:   section.Id,
 				SectionType: section.Type,
 				Err:         fmt.Errorf("no workflows awaiting approval"),
-				Msg:         UpdatePRMsg{PrNumber: prNumber},
+				Msg:         UpdatePRMsg{PrNumber: prNumber, PrUrl: pr.GetUrl()},
 			}
 		}
 
@@ -475,8 +603,8 @@ func ApproveWorkflows(
 		approved := 0
 		for _, runId := range runIds {
 			log.Info("Approving workflow run", "runId", runId, "pr", prNumber)
-			approveCmd := exec.Command("gh", "api", "-X", "POST",
-				fmt.Sprintf("repos/%s/actions/runs/%s/approve", repo, runId))
+			approveCmd := exec.Command("gh", append(slices.Clone(apiArgs), "-X", "POST",
+				fmt.Sprintf("repos/%s/actions/runs/%s/approve", repo, runId))...)
 			output, err := approveCmd.CombinedOutput()
 			if err != nil {
 				outStr := string(output)
@@ -497,7 +625,7 @@ func ApproveWorkflows(
 			SectionId:   section.Id,
 			SectionType: section.Type,
 			Err:         lastErr,
-			Msg:         UpdatePRMsg{PrNumber: prNumber},
+			Msg:         UpdatePRMsg{PrNumber: prNumber, PrUrl: pr.GetUrl()},
 		}
 	})
 }
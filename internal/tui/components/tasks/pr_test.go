@@ -30,6 +30,7 @@ func TestUpdatePR_MsgDoesNotMarkPRClosed(t *testing.T) {
 	task := updatePRTask(SectionIdentifier{Id: 2, Type: "pr"}, mockIssue{
 		number:   42,
 		repoName: "owner/repo",
+		url:      "https://github.com/owner/repo/pull/42",
 	})
 
 	msg := task.Msg(nil, nil)
@@ -37,6 +38,7 @@ func TestUpdatePR_MsgDoesNotMarkPRClosed(t *testing.T) {
 
 	require.True(t, ok, "Msg should return UpdatePRMsg")
 	require.Equal(t, 42, updateMsg.PrNumber)
+	require.Equal(t, "https://github.com/owner/repo/pull/42", updateMsg.PrUrl)
 	require.Nil(
 		t,
 		updateMsg.IsClosed,
@@ -44,6 +46,18 @@ func TestUpdatePR_MsgDoesNotMarkPRClosed(t *testing.T) {
 	)
 }
 
+func TestUpdatePRMsg_IsFor(t *testing.T) {
+	msg := UpdatePRMsg{PrNumber: 1, PrUrl: "https://github.com/o/a/pull/1"}
+	require.True(t, msg.IsFor(1, "https://github.com/o/a/pull/1"))
+	require.False(
+		t,
+		msg.IsFor(1, "https://github.com/o/b/pull/1"),
+		"a PR with the same number in another repo isn't updated",
+	)
+
+	require.True(t, UpdatePRMsg{PrNumber: 1}.IsFor(1, "https://github.com/o/b/pull/1"))
+}
+
 func TestApproveWorkflows_TaskConfiguration(t *testing.T) {
 	var capturedTask context.Task
 
@@ -165,3 +179,32 @@ func TestApproveWorkflows_SectionIdentifierPropagation(t *testing.T) {
 		})
 	}
 }
+
+func TestCreatePRArgs(t *testing.T) {
+	opts := CreatePROptions{
+		Repo:  "owner/repo",
+		Head:  "feat",
+		Title: "Add feature",
+		Body:  "Adds it",
+	}
+	require.Equal(t, []string{
+		"pr", "create", "--title", "Add feature", "--body", "Adds it", "--head", "feat",
+		"-R", "owner/repo",
+	}, createPRArgs(opts))
+
+	opts.Base = "release"
+	opts.Draft = true
+	opts.Reviewers = []string{"alice", "bob"}
+	opts.Assignees = []string{"carol"}
+	opts.Labels = []string{"bug", "good first issue"}
+	require.Equal(t, []string{
+		"pr", "create", "--title", "Add feature", "--body", "Adds it", "--head", "feat",
+		"--base", "release", "--draft",
+		"--reviewer", "alice,bob", "--assignee", "carol", "--label", "bug,good first issue",
+		"-R", "owner/repo",
+	}, createPRArgs(opts))
+
+	// A branch pushed to a fork under another name
+	opts = CreatePROptions{Repo: "owner/repo", Head: "feat", RemoteHead: "me/feat", HeadOwner: "me"}
+	require.Contains(t, createPRArgs(opts), "me:me/feat")
+}
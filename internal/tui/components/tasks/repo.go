@@ -0,0 +1,44 @@
+package tasks
+
+import (
+	"fmt"
+	"os/exec"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+)
+
+// RepoClonedMsg is sent once a repo has been cloned into Path, which repo
+// discovery knows it by from then on
+type RepoClonedMsg struct {
+	RepoName string
+	Path     string
+}
+
+// CloneRepo clones a repo, named [HOST/]OWNER/REPO, into path
+func CloneRepo(
+	ctx *context.ProgramContext,
+	section SectionIdentifier,
+	repoName string,
+	path string,
+) tea.Cmd {
+	return fireTask(ctx, GitHubTask{
+		Id:           fmt.Sprintf("repo_clone_%s", repoName),
+		Args:         []string{"repo", "clone", repoName, path},
+		Section:      section,
+		StartText:    fmt.Sprintf("Cloning %s into %s", repoName, path),
+		FinishedText: fmt.Sprintf("%s has been cloned", repoName),
+		Msg: func(c *exec.Cmd, err error) tea.Msg {
+			if err != nil {
+				return nil
+			}
+			if err := data.GetRepoPathStore().Add(repoName, path); err != nil {
+				log.Error("Failed to save cloned repo", "repo", repoName, "err", err)
+			}
+			return RepoClonedMsg{RepoName: repoName, Path: path}
+		},
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"charm.land/lipgloss/v2"
 	"charm.land/lipgloss/v2/compat"
 
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
 )
 
@@ -56,3 +57,23 @@ func RenderIssueTitle(
 	res := fmt.Sprintf("%s%s", prNumber, rTitle)
 	return res
 }
+
+// RenderSeenElsewhere renders a faint marker listing the other sections a row
+// also appears in, or nothing if it appears in no other section.
+func RenderSeenElsewhere(ctx *context.ProgramContext, sections []string) string {
+	if len(sections) == 0 {
+		return ""
+	}
+	return ctx.Styles.Common.FaintTextStyle.Render(
+		fmt.Sprintf(" %s %s", constants.SeenElsewhereIcon, strings.Join(sections, ", ")))
+}
+
+// RenderHostBadge renders a faint badge with the GitHub host of a row, or
+// nothing for rows of the default host.
+func RenderHostBadge(ctx *context.ProgramContext, host string) string {
+	if host == "" {
+		return ""
+	}
+	return ctx.Styles.Common.FaintTextStyle.Render(
+		fmt.Sprintf(" %s %s", constants.HostIcon, host))
+}
@@ -56,6 +56,16 @@ const (
 	MergeQueueIcon     = "" // \uf4db nf-oct-git_merge_queue
 	OpenIcon           = ""
 	SelectionIcon      = "→"
+	HostIcon           = "" // \uf4b4 nf-oct-server
+	SeenElsewhereIcon  = "" // \uebcc nf-cod-copy
+	SnoozeIcon         = "󰒲" // \udb81\udcb2 nf-md-sleep
+	ExpandedIcon       = "" // \uf47c nf-oct-chevron_down
+	CollapsedIcon      = "" // \uf460 nf-oct-chevron_right
+	WatchingIcon       = "" // \uf441 nf-oct-eye
+	IgnoringIcon       = "" // \uf4c5 nf-oct-eye_closed
+	MutedIcon          = "󰂛" // \udb80\udc9b nf-md-bell_off
+	WorktreeIcon       = "󰙅" // \udb81\ude45 nf-md-file_tree
+	StackIcon          = "" // \uf51e nf-oct-stack
 
 	AutocompleteColumnGap              = 2
 	AutocompleteMinValueWidth          = 8
@@ -87,6 +97,12 @@ const (
 	SecurityIcon     = "󰒃" // \udb80\udc83 nf-md-shield_alert (for security alerts)
 	NotificationIcon = "" // \ueaa2 nf-cod-bell (generic notification fallback)
 	SearchIcon       = "" // \uf002 nf-fa-search
+	RateLimitIcon    = "󰓅" // \udb81\udcc5 nf-md-speedometer
+
+	// Security alert kind icons
+	DependabotAlertIcon     = "" // \uf487 nf-oct-package
+	CodeScanningAlertIcon   = "" // \uf44f nf-oct-code
+	SecretScanningAlertIcon = "" // \uf43d nf-oct-key
 
 	// Prompts
 	AssignPrompt   = "Assign users (whitespace-separated)" + Ellipsis
@@ -0,0 +1,211 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	log "charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/shell"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/notificationrow"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/notificationssection"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+)
+
+const (
+	// desktopNotificationsPollInterval is how often notifications are polled
+	// for desktop notifications. GitHub's X-Poll-Interval, usually a minute,
+	// is honored on top of it.
+	desktopNotificationsPollInterval = time.Minute
+
+	// desktopNotificationsLimit is how many of the latest unread
+	// notifications are polled
+	desktopNotificationsLimit = 50
+)
+
+type desktopNotificationsPollMsg struct{}
+
+type desktopNotificationsFetchedMsg struct {
+	Notifications []data.NotificationData
+	Modified      bool
+	Err           error
+}
+
+// desktopNotifier remembers which notifications a desktop notification was
+// sent for, so every poll only sends the ones that arrived since.
+type desktopNotifier struct {
+	startedAt time.Time
+	// sent holds the update time of every notification already sent
+	sent map[string]time.Time
+}
+
+func newDesktopNotifier(startedAt time.Time) desktopNotifier {
+	return desktopNotifier{
+		startedAt: startedAt,
+		sent:      map[string]time.Time{},
+	}
+}
+
+// newNotifications returns the unread notifications that match the filters
+// and were updated since the dashboard started and since they were last
+// sent, and records them as sent.
+func (n desktopNotifier) newNotifications(
+	filters string,
+	notifications []data.NotificationData,
+) []data.NotificationData {
+	var recent []data.NotificationData
+	for _, notification := range notifications {
+		if !notification.Unread || !notification.UpdatedAt.After(n.startedAt) {
+			continue
+		}
+		if sentAt, ok := n.sent[notification.Id]; ok && !notification.UpdatedAt.After(sentAt) {
+			continue
+		}
+		if !notificationssection.MatchesFilters(filters, notification) {
+			continue
+		}
+		n.sent[notification.Id] = notification.UpdatedAt
+		recent = append(recent, notification)
+	}
+	return recent
+}
+
+func (m *Model) pollDesktopNotifications() tea.Cmd {
+	return func() tea.Msg {
+		if low, resetAt := data.IsRateLimitLow(); low {
+			log.Info("Deferring desktop notifications poll, rate limit is low", "resetAt", resetAt)
+			return desktopNotificationsFetchedMsg{}
+		}
+		notifications, modified, err := data.PollUnreadNotifications(desktopNotificationsLimit)
+		return desktopNotificationsFetchedMsg{
+			Notifications: notifications,
+			Modified:      modified,
+			Err:           err,
+		}
+	}
+}
+
+func (m *Model) onDesktopNotificationsFetched(msg desktopNotificationsFetchedMsg) tea.Cmd {
+	next := tea.Tick(desktopNotificationsPollInterval, func(time.Time) tea.Msg {
+		return desktopNotificationsPollMsg{}
+	})
+	if msg.Err != nil {
+		log.Error("Failed polling notifications for desktop notifications", "err", msg.Err)
+		return next
+	}
+	if !msg.Modified {
+		return next
+	}
+
+	cfg := m.ctx.Config.DesktopNotifications
+	cmds := []tea.Cmd{next}
+	for _, n := range m.desktopNotifier.newNotifications(cfg.Filters, msg.Notifications) {
+		log.Info("Sending desktop notification", "id", n.Id, "reason", n.Reason,
+			"repo", n.Repository.FullName)
+		if cfg.Method == config.DesktopNotificationCommand {
+			cmds = append(cmds, m.runDesktopNotificationCommand(cfg.Command, n))
+		} else {
+			title, body := desktopNotificationText(n)
+			cmds = append(cmds, tea.Raw(oscNotification(cfg.Method, title, body)))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// desktopNotificationText returns the title and body of a notification's
+// desktop notification, e.g. "Review requested" and "owner/repo#1: Title".
+func desktopNotificationText(n data.NotificationData) (string, string) {
+	row := notificationrow.Data{Notification: n}
+	title := notificationrow.ReasonDescription(n.Reason, n.Subject.Type)
+	if title == "" {
+		title = strings.ReplaceAll(n.Reason, "_", " ")
+	}
+	subject := row.GetRepoNameWithOwner()
+	if number := row.GetNumber(); number > 0 {
+		subject = fmt.Sprintf("%s#%d", subject, number)
+	}
+	return title, subject + ": " + row.GetTitle()
+}
+
+// oscNotification returns the OSC 777 or OSC 9 escape sequence that asks the
+// terminal to show a desktop notification. Inside tmux, it's wrapped to be
+// passed through to the outer terminal.
+func oscNotification(method config.DesktopNotificationMethod, title, body string) string {
+	// Control characters would end the sequence early, and ; separates the
+	// OSC 777 title from the body
+	sanitize := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if r < ' ' || r == 0x7f {
+				return ' '
+			}
+			return r
+		}, s)
+	}
+	title, body = sanitize(title), sanitize(body)
+
+	var seq string
+	if method == config.DesktopNotificationOSC9 {
+		seq = "\x1b]9;" + title + ": " + body + "\x07"
+	} else {
+		seq = "\x1b]777;notify;" + strings.ReplaceAll(title, ";", ",") + ";" + body + "\x07"
+	}
+	if os.Getenv("TMUX") != "" {
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq
+}
+
+// shellQuote quotes a string for POSIX shells. Titles come from whoever
+// opened the PR or issue, so commands run without asking should quote them.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// runDesktopNotificationCommand runs the user's desktop notification command
+// in the background, with the same template input as custom keybindings.
+func (m *Model) runDesktopNotificationCommand(commandTemplate string, n data.NotificationData) tea.Cmd {
+	row := notificationrow.Data{Notification: n}
+	title, body := desktopNotificationText(n)
+	input := resolveTemplateInput(&map[string]any{
+		"RepoName": row.GetRepoNameWithOwner(),
+		"Number":   row.GetNumber(),
+		"Title":    row.GetTitle(),
+		"Reason":   n.Reason,
+		"Type":     n.Subject.Type,
+		"Url":      row.GetUrl(),
+		"Summary":  title,
+		"Body":     body,
+	}, m.ctx.Config.RepoPaths, m.ctx.RepoPath)
+
+	tmpl, err := template.New("desktop_notification_command").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"quote": shellQuote}).
+		Parse(commandTemplate)
+	var buff bytes.Buffer
+	if err == nil {
+		err = tmpl.Execute(&buff, input)
+	}
+	if err != nil {
+		return func() tea.Msg {
+			log.Error("failed to parse template", "err", err, "commandTemplate", commandTemplate)
+			return constants.ErrMsg{Err: fmt.Errorf("failed to parse desktop notification command: %w", err)}
+		}
+	}
+
+	cmd := buff.String()
+	return func() tea.Msg {
+		log.Debug("running desktop notification command", "cmd", cmd)
+		if out, err := shell.Command(cmd).CombinedOutput(); err != nil {
+			log.Error("Desktop notification command failed", "cmd", cmd, "err", err,
+				"output", string(out))
+		}
+		return nil
+	}
+}
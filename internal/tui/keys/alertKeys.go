@@ -0,0 +1,88 @@
+package keys
+
+import (
+	"fmt"
+
+	"charm.land/bubbles/v2/key"
+	log "charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+)
+
+type AlertKeyMap struct {
+	Dismiss  key.Binding
+	Reopen   key.Binding
+	ViewNext key.Binding
+}
+
+var AlertKeys = AlertKeyMap{
+	Dismiss: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "dismiss"),
+	),
+	Reopen: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "reopen"),
+	),
+	ViewNext: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "switch to notifications"),
+	),
+}
+
+func AlertFullHelp() []key.Binding {
+	return []key.Binding{
+		AlertKeys.Dismiss,
+		AlertKeys.Reopen,
+		AlertKeys.ViewNext,
+	}
+}
+
+func rebindAlertKeys(keys []config.Keybinding) error {
+	CustomAlertBindings = []key.Binding{}
+
+	for _, alertKey := range keys {
+		if alertKey.Builtin == "" {
+			// Handle custom commands
+			if alertKey.Command != "" {
+				name := alertKey.Name
+				if alertKey.Name == "" {
+					name = config.TruncateCommand(alertKey.Command)
+				}
+
+				customBinding := key.NewBinding(
+					key.WithKeys(alertKey.Key),
+					key.WithHelp(alertKey.Key, name),
+				)
+
+				CustomAlertBindings = append(CustomAlertBindings, customBinding)
+			}
+			continue
+		}
+
+		log.Debug("Rebinding alert key", "builtin", alertKey.Builtin, "key", alertKey.Key)
+
+		var key *key.Binding
+
+		switch alertKey.Builtin {
+		case "dismiss":
+			key = &AlertKeys.Dismiss
+		case "reopen":
+			key = &AlertKeys.Reopen
+		case "viewNext":
+			key = &AlertKeys.ViewNext
+		default:
+			return fmt.Errorf("unknown built-in alert key: '%s'", alertKey.Builtin)
+		}
+
+		key.SetKeys(alertKey.Key)
+
+		helpDesc := key.Help().Desc
+		if alertKey.Name != "" {
+			helpDesc = alertKey.Name
+		}
+		key.SetHelp(alertKey.Key, helpDesc)
+	}
+
+	return nil
+}
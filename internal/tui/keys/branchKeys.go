@@ -10,15 +10,29 @@ import (
 )
 
 type BranchKeyMap struct {
-	Checkout    key.Binding
-	New         key.Binding
-	CreatePr    key.Binding
-	FastForward key.Binding
-	Push        key.Binding
-	ForcePush   key.Binding
-	Delete      key.Binding
-	UpdatePr    key.Binding
-	ViewPRs     key.Binding
+	Checkout          key.Binding
+	New               key.Binding
+	CreatePr          key.Binding
+	FastForward       key.Binding
+	Push              key.Binding
+	ForcePush         key.Binding
+	Delete            key.Binding
+	UpdatePr          key.Binding
+	PruneWorktrees    key.Binding
+	PruneMerged       key.Binding
+	Restack           key.Binding
+	Retarget          key.Binding
+	Rebase            key.Binding
+	RebaseUpstream    key.Binding
+	RebaseInteractive key.Binding
+	ContinueRebase    key.Binding
+	AbortRebase       key.Binding
+	FocusChanges      key.Binding
+	StageFile         key.Binding
+	DiscardFile       key.Binding
+	Commit            key.Binding
+	Amend             key.Binding
+	ViewPRs           key.Binding
 }
 
 var BranchKeys = BranchKeyMap{
@@ -54,6 +68,62 @@ var BranchKeys = BranchKeyMap{
 		key.WithKeys("u"),
 		key.WithHelp("u", "update PR"),
 	),
+	PruneWorktrees: key.NewBinding(
+		key.WithKeys("W"),
+		key.WithHelp("W", "remove stale worktrees"),
+	),
+	PruneMerged: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "delete merged branches"),
+	),
+	Restack: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "restack"),
+	),
+	Retarget: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "retarget stacked PRs"),
+	),
+	Rebase: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "rebase onto base"),
+	),
+	RebaseUpstream: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "rebase onto upstream"),
+	),
+	RebaseInteractive: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "interactive rebase"),
+	),
+	ContinueRebase: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "continue rebase"),
+	),
+	AbortRebase: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "abort rebase"),
+	),
+	FocusChanges: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "focus changes"),
+	),
+	StageFile: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "stage/unstage file"),
+	),
+	DiscardFile: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "discard file changes"),
+	),
+	Commit: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "commit"),
+	),
+	Amend: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "amend commit"),
+	),
 	ViewPRs: key.NewBinding(
 		key.WithKeys("s"),
 		key.WithHelp("s", "Switch to PRs"),
@@ -70,6 +140,18 @@ func BranchFullHelp() []key.Binding {
 		BranchKeys.CreatePr,
 		BranchKeys.Delete,
 		BranchKeys.UpdatePr,
+		BranchKeys.PruneWorktrees,
+		BranchKeys.PruneMerged,
+		BranchKeys.Restack,
+		BranchKeys.Retarget,
+		BranchKeys.Rebase,
+		BranchKeys.RebaseUpstream,
+		BranchKeys.RebaseInteractive,
+		BranchKeys.ContinueRebase,
+		BranchKeys.AbortRebase,
+		BranchKeys.FocusChanges,
+		BranchKeys.Commit,
+		BranchKeys.Amend,
 		BranchKeys.ViewPRs,
 	}
 }
@@ -119,6 +201,34 @@ func rebindBranchKeys(keys []config.Keybinding) error {
 			key = &BranchKeys.ViewPRs
 		case "updatePr":
 			key = &BranchKeys.UpdatePr
+		case "pruneWorktrees":
+			key = &BranchKeys.PruneWorktrees
+		case "pruneMerged":
+			key = &BranchKeys.PruneMerged
+		case "restack":
+			key = &BranchKeys.Restack
+		case "retarget":
+			key = &BranchKeys.Retarget
+		case "rebase":
+			key = &BranchKeys.Rebase
+		case "rebaseUpstream":
+			key = &BranchKeys.RebaseUpstream
+		case "rebaseInteractive":
+			key = &BranchKeys.RebaseInteractive
+		case "continueRebase":
+			key = &BranchKeys.ContinueRebase
+		case "abortRebase":
+			key = &BranchKeys.AbortRebase
+		case "focusChanges":
+			key = &BranchKeys.FocusChanges
+		case "stageFile":
+			key = &BranchKeys.StageFile
+		case "discardFile":
+			key = &BranchKeys.DiscardFile
+		case "commit":
+			key = &BranchKeys.Commit
+		case "amend":
+			key = &BranchKeys.Amend
 		default:
 			return fmt.Errorf("unknown built-in branch key: '%s'", branchKey.Builtin)
 		}
@@ -74,6 +74,9 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	case config.RepoView:
 		additionalKeys = BranchFullHelp()
 		customKeys = append(customKeys, CustomBranchBindings...)
+	case config.AlertsView:
+		additionalKeys = AlertFullHelp()
+		customKeys = append(customKeys, CustomAlertBindings...)
 	case config.NotificationsView:
 		additionalKeys = NotificationFullHelp()
 		customKeys = append(customKeys, CustomNotificationBindings...)
@@ -213,7 +216,8 @@ var Keys = &KeyMap{
 
 // Rebind will update our saved keybindings from configuration values.
 func Rebind(
-	universal, issueKeys, prKeys, branchKeys, notificationKeys, cmpKeys []config.Keybinding,
+	universal, issueKeys, prKeys, branchKeys, notificationKeys, cmpKeys,
+	alertKeys []config.Keybinding,
 ) error {
 	err := rebindUniversal(universal)
 	if err != nil {
@@ -240,6 +244,11 @@ func Rebind(
 		return err
 	}
 
+	err = rebindAlertKeys(alertKeys)
+	if err != nil {
+		return err
+	}
+
 	return rebindNotificationKeys(notificationKeys)
 }
 
@@ -251,6 +260,7 @@ var (
 	CustomBranchBindings       []key.Binding
 	CustomNotificationBindings []key.Binding
 	CustomCmpBindings          []key.Binding
+	CustomAlertBindings        []key.Binding
 )
 
 func rebindUniversal(universal []config.Keybinding) error {
@@ -18,8 +18,16 @@ type NotificationKeyMap struct {
 	MarkAllAsRead        key.Binding
 	Unsubscribe          key.Binding
 	ToggleBookmark       key.Binding
+	Snooze               key.Binding
 	Open                 key.Binding
+	Subscriptions        key.Binding
+	ToggleWatching       key.Binding
+	ToggleIgnoring       key.Binding
+	ToggleRepoMute       key.Binding
+	ToggleCIMute         key.Binding
 	SortByRepo           key.Binding
+	ToggleGrouping       key.Binding
+	ToggleExpand         key.Binding
 	SwitchToPRs          key.Binding
 	ToggleSmartFiltering key.Binding
 }
@@ -57,14 +65,46 @@ var NotificationKeys = NotificationKeyMap{
 		key.WithKeys("b"),
 		key.WithHelp("b", "toggle bookmark"),
 	),
+	Snooze: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "snooze/unsnooze"),
+	),
 	Open: key.NewBinding(
 		key.WithKeys("o"),
 		key.WithHelp("o", "open in browser"),
 	),
+	Subscriptions: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "manage subscriptions"),
+	),
+	ToggleWatching: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "watch/unwatch repo"),
+	),
+	ToggleIgnoring: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "ignore/unignore repo"),
+	),
+	ToggleRepoMute: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "mute/unmute repo"),
+	),
+	ToggleCIMute: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "mute/unmute repo CI"),
+	),
 	SortByRepo: key.NewBinding(
 		key.WithKeys("S"),
 		key.WithHelp("S", "sort by repo"),
 	),
+	ToggleGrouping: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "group by repo"),
+	),
+	ToggleExpand: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "expand/collapse"),
+	),
 	SwitchToPRs: key.NewBinding(
 		key.WithKeys("s"),
 		key.WithHelp("s", "switch to PRs"),
@@ -85,8 +125,12 @@ func NotificationFullHelp() []key.Binding {
 		NotificationKeys.MarkAllAsRead,
 		NotificationKeys.Unsubscribe,
 		NotificationKeys.ToggleBookmark,
+		NotificationKeys.Snooze,
 		NotificationKeys.Open,
+		NotificationKeys.Subscriptions,
 		NotificationKeys.SortByRepo,
+		NotificationKeys.ToggleGrouping,
+		NotificationKeys.ToggleExpand,
 		NotificationKeys.SwitchToPRs,
 		NotificationKeys.ToggleSmartFiltering,
 	}
@@ -133,10 +177,26 @@ func rebindNotificationKeys(keys []config.Keybinding) error {
 			key = &NotificationKeys.Unsubscribe
 		case "toggleBookmark":
 			key = &NotificationKeys.ToggleBookmark
+		case "snooze":
+			key = &NotificationKeys.Snooze
 		case "open":
 			key = &NotificationKeys.Open
+		case "subscriptions":
+			key = &NotificationKeys.Subscriptions
+		case "toggleWatching":
+			key = &NotificationKeys.ToggleWatching
+		case "toggleIgnoring":
+			key = &NotificationKeys.ToggleIgnoring
+		case "toggleRepoMute":
+			key = &NotificationKeys.ToggleRepoMute
+		case "toggleCIMute":
+			key = &NotificationKeys.ToggleCIMute
 		case "sortByRepo":
 			key = &NotificationKeys.SortByRepo
+		case "toggleGrouping":
+			key = &NotificationKeys.ToggleGrouping
+		case "toggleExpand":
+			key = &NotificationKeys.ToggleExpand
 		case "switchToPRs":
 			key = &NotificationKeys.SwitchToPRs
 		case "toggleSmartFiltering":
@@ -26,6 +26,8 @@ type PRKeyMap struct {
 	Merge                key.Binding
 	Update               key.Binding
 	WatchChecks          key.Binding
+	FocusCommits         key.Binding
+	ShowCommitDiff       key.Binding
 	ApproveWorkflows     key.Binding
 	ToggleSmartFiltering key.Binding
 	ViewIssues           key.Binding
@@ -96,6 +98,14 @@ var PRKeys = PRKeyMap{
 		key.WithKeys("w"),
 		key.WithHelp("w", "watch checks"),
 	),
+	FocusCommits: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "focus commits"),
+	),
+	ShowCommitDiff: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "show commit diff"),
+	),
 	ApproveWorkflows: key.NewBinding(
 		key.WithKeys("V"),
 		key.WithHelp("V", "approve all workflows"),
@@ -127,6 +137,8 @@ func PRFullHelp() []key.Binding {
 		PRKeys.Merge,
 		PRKeys.Update,
 		PRKeys.WatchChecks,
+		PRKeys.FocusCommits,
+		PRKeys.ShowCommitDiff,
 		PRKeys.ApproveWorkflows,
 		PRKeys.ToggleSmartFiltering,
 		PRKeys.ViewIssues,
@@ -190,6 +202,10 @@ func rebindPRKeys(keys []config.Keybinding) error {
 			key = &PRKeys.Update
 		case "watchChecks":
 			key = &PRKeys.WatchChecks
+		case "focusCommits":
+			key = &PRKeys.FocusCommits
+		case "showCommitDiff":
+			key = &PRKeys.ShowCommitDiff
 		case "approveWorkflows":
 			key = &PRKeys.ApproveWorkflows
 		case "viewIssues":
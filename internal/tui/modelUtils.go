@@ -114,6 +114,18 @@ func (m *Model) executeKeybinding(key string) tea.Cmd {
 				return m.runCustomBranchCommand(keybinding.Command, data)
 			}
 		}
+	case config.AlertsView:
+		for _, keybinding := range m.ctx.Config.Keybindings.Alerts {
+			if keybinding.Key != key || keybinding.Command == "" {
+				continue
+			}
+
+			log.Debug("executing alert keybind", "key", keybinding.Key, "command", keybinding.Command)
+
+			if alert, ok := currRowData.(*data.SecurityAlert); ok {
+				return m.runCustomAlertCommand(keybinding.Command, alert)
+			}
+		}
 	case config.NotificationsView:
 		for _, keybinding := range m.ctx.Config.Keybindings.Notifications {
 			if keybinding.Key != key || keybinding.Command == "" {
@@ -189,9 +201,9 @@ func resolveTemplateInput(
 	}
 
 	// Append in the local RepoPath only if it can be found
-	if input["RepoName"] != nil {
-		if repoPath, ok := common.GetRepoLocalPath(
-			input["RepoName"].(string),
+	if repoName := templateRepoName(input); repoName != "" {
+		if repoPath, ok := common.ResolveRepoLocalPath(
+			repoName,
 			repoPaths,
 		); ok {
 			input["RepoPath"] = repoPath
@@ -206,11 +218,28 @@ func resolveTemplateInput(
 	return input
 }
 
+// templateRepoName returns the name the repo of a command's input goes by in
+// repoPaths, prefixed with its host unless it's the default one, or an empty
+// string when the input has no repo
+func templateRepoName(input map[string]any) string {
+	repoName, _ := input["RepoName"].(string)
+	if repoName == "" {
+		return ""
+	}
+	if host, ok := input["Host"].(string); ok && host != "" {
+		repoName = host + "/" + repoName
+	}
+	return repoName
+}
+
 // runCustomCommand executes a user-defined command.
 // commandTemplate is a template string that will be parsed with the input data.
 // contextData is a map of key-value pairs of data specific to the context the command is being run in.
 func (m *Model) runCustomCommand(commandTemplate string, contextData *map[string]any) tea.Cmd {
 	input := resolveTemplateInput(contextData, m.ctx.Config.RepoPaths, m.ctx.RepoPath)
+	if clone := m.getRepoToClone(commandTemplate, contextData); clone != nil {
+		return m.promptRepoClone(*clone)
+	}
 
 	cmd, err := template.New("keybinding_command").Parse(commandTemplate)
 	if err != nil {
@@ -223,6 +252,15 @@ func (m *Model) runCustomCommand(commandTemplate string, contextData *map[string
 	var buff bytes.Buffer
 	err = cmd.Execute(&buff, input)
 	if err != nil {
+		if _, ok := input["RepoPath"]; !ok && usesRepoPath(commandTemplate) {
+			if repoName := templateRepoName(input); repoName != "" {
+				return func() tea.Msg {
+					return constants.ErrMsg{Err: fmt.Errorf(
+						"no local clone of %s found, map it in repoPaths or set repoDiscovery.roots",
+						repoName)}
+				}
+			}
+		}
 		return func() tea.Msg {
 			log.Error("failed to parsetemplate", "err", err, "commandTemplate", commandTemplate)
 			return constants.ErrMsg{Err: fmt.Errorf("failed to parsetemplate %s", commandTemplate)}
@@ -235,6 +273,7 @@ func (m *Model) runCustomPRCommand(commandTemplate string, prData *prrow.Data) t
 	return m.runCustomCommand(commandTemplate,
 		&map[string]any{
 			"RepoName":    prData.GetRepoNameWithOwner(),
+			"Host":        data.RowHost(prData),
 			"PrNumber":    prData.Primary.Number,
 			"HeadRefName": prData.Primary.HeadRefName,
 			"BaseRefName": prData.Primary.BaseRefName,
@@ -246,6 +285,7 @@ func (m *Model) runCustomIssueCommand(commandTemplate string, issueData *data.Is
 	return m.runCustomCommand(commandTemplate,
 		&map[string]any{
 			"RepoName":    issueData.GetRepoNameWithOwner(),
+			"Host":        data.RowHost(issueData),
 			"IssueNumber": issueData.Number,
 			"IssueTitle":  issueData.Title,
 			"Author":      issueData.Author.Login,
@@ -319,6 +359,21 @@ func (m *Model) runCustomNotificationCommand(
 	return m.runCustomCommand(commandTemplate, &fields)
 }
 
+func (m *Model) runCustomAlertCommand(
+	commandTemplate string,
+	alert *data.SecurityAlert,
+) tea.Cmd {
+	fields := map[string]any{
+		"RepoName": alert.GetRepoNameWithOwner(),
+		"Number":   alert.GetNumber(),
+		"Url":      alert.GetUrl(),
+		"Kind":     string(alert.Kind),
+		"Package":  alert.Package,
+		"Severity": alert.Severity,
+	}
+	return m.runCustomCommand(commandTemplate, &fields)
+}
+
 type execProcessFinishedMsg struct{}
 
 func (m *Model) executeCustomCommand(cmd string) tea.Cmd {
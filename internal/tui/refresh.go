@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	log "charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/alertssection"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/issuessection"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/notificationssection"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prssection"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/section"
+)
+
+type sectionRefreshKey struct {
+	View      config.ViewType
+	SectionId int
+}
+
+// sectionRefreshMsg fires when a section's background refresh is due.
+// Generation guards against stale timers: every reschedule bumps it, so only
+// the most recently scheduled tick for a section is acted upon.
+type sectionRefreshMsg struct {
+	View       config.ViewType
+	SectionId  int
+	Generation int
+}
+
+// scheduleSectionRefreshes schedules an independent background refresh for
+// each of the given sections.
+func (m *Model) scheduleSectionRefreshes(
+	view config.ViewType,
+	sections []section.Section,
+) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(sections))
+	for _, s := range sections {
+		cmds = append(cmds, m.scheduleSectionRefresh(view, s))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) scheduleSectionRefresh(view config.ViewType, s section.Section) tea.Cmd {
+	// The search section (id 0) only fetches on demand.
+	if s == nil || s.GetId() == 0 {
+		return nil
+	}
+
+	interval := s.GetRefetchInterval()
+	if interval <= 0 {
+		return nil
+	}
+
+	if m.refreshGenerations == nil {
+		m.refreshGenerations = map[sectionRefreshKey]int{}
+	}
+	key := sectionRefreshKey{View: view, SectionId: s.GetId()}
+	m.refreshGenerations[key]++
+	generation := m.refreshGenerations[key]
+	delay := section.NextRefreshDelay(interval, s.GetFailedFetches())
+	if low, resetAt := data.IsRateLimitLow(); low {
+		delay = max(delay, time.Until(resetAt))
+	}
+	log.Debug("Scheduling section refresh", "view", view, "section", s.GetId(), "in", delay)
+
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return sectionRefreshMsg{
+			View:       view,
+			SectionId:  key.SectionId,
+			Generation: generation,
+		}
+	})
+}
+
+func (m *Model) onSectionRefresh(msg sectionRefreshMsg) tea.Cmd {
+	key := sectionRefreshKey{View: msg.View, SectionId: msg.SectionId}
+	if m.refreshGenerations[key] != msg.Generation {
+		return nil
+	}
+
+	s := m.getViewSection(msg.View, msg.SectionId)
+	if s == nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	// Sections of inactive views are refreshed when the user switches to them,
+	// so only keep their timers alive here.
+	if low, resetAt := data.IsRateLimitLow(); low {
+		log.Info("Deferring section refresh, rate limit is low",
+			"section", msg.SectionId, "resetAt", resetAt)
+	} else if msg.View == m.ctx.View && !s.GetIsLoading() {
+		s.ResetPageInfo()
+		cmds = append(cmds, s.FetchNextPageSectionRows()...)
+	}
+	cmds = append(cmds, m.scheduleSectionRefresh(msg.View, s))
+
+	return tea.Batch(cmds...)
+}
+
+// onSectionFetchFinished records the outcome of a section fetch and restarts
+// the section's refresh timer, backing off when the fetch failed.
+func (m *Model) onSectionFetchFinished(sType string, id int, taskId string, err error) tea.Cmd {
+	view := sectionTypeToView(sType)
+	s := m.getViewSection(view, id)
+	if s == nil || s.GetLastFetchTaskId() != taskId {
+		return nil
+	}
+
+	s.SetFetchError(err)
+	m.syncRowStore(view)
+	return m.scheduleSectionRefresh(view, s)
+}
+
+func (m *Model) getViewSection(view config.ViewType, id int) section.Section {
+	var sections []section.Section
+	switch view {
+	case config.NotificationsView:
+		sections = m.notifications
+	case config.PRsView:
+		sections = m.prs
+	case config.IssuesView:
+		sections = m.issues
+	case config.AlertsView:
+		sections = m.alerts
+	}
+
+	if id < 0 || id >= len(sections) {
+		return nil
+	}
+	return sections[id]
+}
+
+func sectionTypeToView(sType string) config.ViewType {
+	switch sType {
+	case notificationssection.SectionType:
+		return config.NotificationsView
+	case prssection.SectionType:
+		return config.PRsView
+	case issuessection.SectionType:
+		return config.IssuesView
+	case alertssection.SectionType:
+		return config.AlertsView
+	default:
+		return config.RepoView
+	}
+}
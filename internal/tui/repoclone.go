@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	log "charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
+)
+
+// repoClone is a clone of a repo a custom command needs the local path of,
+// which has no clone yet. The command runs once the repo has been cloned.
+type repoClone struct {
+	repoName        string
+	path            string
+	commandTemplate string
+	contextData     *map[string]any
+}
+
+// usesRepoPath returns whether a command template needs the local path of
+// its repo
+func usesRepoPath(commandTemplate string) bool {
+	return strings.Contains(commandTemplate, ".RepoPath")
+}
+
+// getRepoToClone returns the clone a custom command needs before it can
+// run, or nil when its repo has a local clone or repoDiscovery.cloneDir isn't
+// set. A path repoPaths maps the repo to is cloned into rather than cloneDir.
+func (m *Model) getRepoToClone(commandTemplate string, contextData *map[string]any) *repoClone {
+	cloneDir := m.ctx.Config.RepoDiscovery.CloneDir
+	if cloneDir == "" || contextData == nil || !usesRepoPath(commandTemplate) {
+		return nil
+	}
+	if _, ok := (*contextData)["RepoPath"]; ok {
+		return nil
+	}
+	repoName := templateRepoName(*contextData)
+	if repoName == "" || m.isCurrentRepo(repoName) {
+		return nil
+	}
+
+	path, ok := common.ResolveRepoLocalPath(repoName, m.ctx.Config.RepoPaths)
+	if ok {
+		path = common.ExpandHomeDir(path)
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		}
+	} else {
+		path = common.GetClonePath(cloneDir, repoName)
+	}
+	return &repoClone{
+		repoName:        repoName,
+		path:            path,
+		commandTemplate: commandTemplate,
+		contextData:     contextData,
+	}
+}
+
+// isCurrentRepo returns whether repoName is the repo gh-dash runs in, which
+// commands fall back to
+func (m *Model) isCurrentRepo(repoName string) bool {
+	if m.ctx.RepoPath == "" {
+		return false
+	}
+	host, owner, repo, ok := git.ParseRemoteUrl(m.ctx.RepoUrl)
+	if !ok {
+		return false
+	}
+	name := owner + "/" + repo
+	if !data.IsDefaultHost(host) {
+		name = host + "/" + name
+	}
+	return strings.EqualFold(name, repoName)
+}
+
+func (m *Model) promptRepoClone(clone repoClone) tea.Cmd {
+	m.cloneToConfirm = &clone
+	m.footer.SetLeftSection(m.renderClonePrompt())
+	return nil
+}
+
+func (m *Model) renderClonePrompt() string {
+	return m.ctx.Styles.ListViewPort.PagerStyle.Render(fmt.Sprintf(
+		"No local clone of %s. Clone it into %s and continue? (y/N)",
+		m.cloneToConfirm.repoName, m.cloneToConfirm.path))
+}
+
+func (m *Model) cloneRepo(clone repoClone) tea.Cmd {
+	if m.clonesInProgress == nil {
+		m.clonesInProgress = map[string]repoClone{}
+	}
+	m.clonesInProgress[clone.repoName] = clone
+	sid := tasks.SectionIdentifier{Id: m.currSectionId}
+	if currSection := m.getCurrSection(); currSection != nil {
+		sid.Type = currSection.GetType()
+	}
+	return tasks.CloneRepo(m.ctx, sid, clone.repoName, clone.path)
+}
+
+// onRepoCloned runs the command that was waiting for the repo, which now
+// finds its clone
+func (m *Model) onRepoCloned(msg tasks.RepoClonedMsg) tea.Cmd {
+	clone, ok := m.clonesInProgress[msg.RepoName]
+	if !ok {
+		return nil
+	}
+	delete(m.clonesInProgress, msg.RepoName)
+	return m.runCustomCommand(clone.commandTemplate, clone.contextData)
+}
+
+// discoverRepos scans repoDiscovery's roots for clones, unless the last
+// scan is recent enough
+func (m *Model) discoverRepos() tea.Cmd {
+	cfg := m.ctx.Config.RepoDiscovery
+	return func() tea.Msg {
+		if err := common.RefreshDiscoveredRepos(cfg); err != nil {
+			log.Error("Failed to save discovered repos", "err", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/footer"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/theme"
+)
+
+func newRepoCloneTestModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("GH_HOST", "github.com")
+	cfg, err := config.ParseConfig(config.Location{
+		ConfigFlag: "../config/testdata/test-config.yml",
+	})
+	require.NoError(t, err)
+	cfg.RepoPaths = map[string]string{
+		"owner/mapped": t.TempDir(),
+		"org/*":        "/nowhere/org/*",
+	}
+	cfg.RepoDiscovery = config.RepoDiscoveryConfig{CloneDir: "/src/:owner/:repo"}
+	ctx := &context.ProgramContext{
+		Config:   &cfg,
+		RepoPath: "/code/gh-dash",
+		RepoUrl:  "git@github.com:dlvhdr/gh-dash.git",
+	}
+	ctx.Theme = theme.ParseTheme(ctx.Config)
+	ctx.Styles = context.InitStyles(ctx.Theme)
+	return Model{ctx: ctx, footer: footer.NewModel(ctx)}
+}
+
+func TestGetRepoToClone(t *testing.T) {
+	m := newRepoCloneTestModel(t)
+
+	testCases := map[string]struct {
+		template    string
+		contextData map[string]any
+		want        string
+	}{
+		"repo without a clone": {
+			template:    "cd {{.RepoPath}}",
+			contextData: map[string]any{"RepoName": "owner/other"},
+			want:        "/src/owner/other",
+		},
+		"repoPaths path that doesn't exist": {
+			template:    "cd {{.RepoPath}}",
+			contextData: map[string]any{"RepoName": "org/service"},
+			want:        "/nowhere/org/service",
+		},
+		"repo with a clone": {
+			template:    "cd {{.RepoPath}}",
+			contextData: map[string]any{"RepoName": "owner/mapped"},
+		},
+		"repo gh-dash runs in": {
+			template:    "cd {{.RepoPath}}",
+			contextData: map[string]any{"RepoName": "dlvhdr/gh-dash"},
+		},
+		"command without RepoPath": {
+			template:    "gh pr view {{.PrNumber}} -R {{.RepoName}}",
+			contextData: map[string]any{"RepoName": "owner/other", "PrNumber": 1},
+		},
+		"RepoPath given": {
+			template:    "cd {{.RepoPath}}",
+			contextData: map[string]any{"RepoName": "owner/other", "RepoPath": "/code/other"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			clone := m.getRepoToClone(tc.template, &tc.contextData)
+			if tc.want == "" {
+				require.Nil(t, clone)
+				return
+			}
+			require.NotNil(t, clone)
+			require.Equal(t, tc.want, clone.path)
+			require.Equal(t, tc.contextData["RepoName"], clone.repoName)
+		})
+	}
+
+	m.ctx.Config.RepoDiscovery.CloneDir = ""
+	require.Nil(t, m.getRepoToClone("cd {{.RepoPath}}", &map[string]any{"RepoName": "owner/other"}),
+		"no prompt without cloneDir")
+}
+
+func TestRepoClonePrompt(t *testing.T) {
+	m := newRepoCloneTestModel(t)
+
+	cmd := m.runCustomCommand("cd {{.RepoPath}}", &map[string]any{"RepoName": "owner/other"})
+	require.Nil(t, cmd)
+	require.NotNil(t, m.cloneToConfirm)
+	require.Equal(t, "owner/other", m.cloneToConfirm.repoName)
+	require.Contains(t, m.renderClonePrompt(), "Clone it into /src/owner/other and continue?")
+
+	model, cmd := m.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	require.Nil(t, cmd)
+	require.Nil(t, model.(Model).cloneToConfirm, "any other key cancels the clone")
+	require.Empty(t, model.(Model).clonesInProgress)
+}
+
+func TestMissingRepoPathError(t *testing.T) {
+	m := newRepoCloneTestModel(t)
+	m.ctx.Config.RepoDiscovery.CloneDir = ""
+	m.ctx.RepoPath = ""
+
+	cmd := m.runCustomCommand("cd {{.RepoPath}}", &map[string]any{"RepoName": "owner/other"})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(constants.ErrMsg)
+	require.True(t, ok)
+	require.ErrorContains(t, msg.Err, "no local clone of owner/other found")
+}
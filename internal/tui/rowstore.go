@@ -0,0 +1,75 @@
+package tui
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prssection"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+)
+
+// rowStoreSection is implemented by sections whose rows are tracked in the
+// shared data.RowStore.
+type rowStoreSection interface {
+	SyncRowStore()
+	RebuildRows()
+}
+
+// syncRowStore records the rows of every section of the view in the row
+// store, in section order so deduped sections see the final rows of the
+// sections before them, then re-renders them with the updated store.
+func (m *Model) syncRowStore(view config.ViewType) {
+	var sections []rowStoreSection
+	for id := 1; ; id++ {
+		s := m.getViewSection(view, id)
+		if s == nil {
+			break
+		}
+		if rs, ok := s.(rowStoreSection); ok {
+			sections = append(sections, rs)
+		}
+	}
+
+	for _, s := range sections {
+		s.SyncRowStore()
+	}
+	for _, s := range sections {
+		s.RebuildRows()
+	}
+}
+
+// propagateRowUpdate forwards the result of an action on a PR or issue to the
+// other sections showing it, so every occurrence is updated at once.
+func (m *Model) propagateRowUpdate(msg constants.TaskFinishedMsg) tea.Cmd {
+	var url string
+	switch update := msg.Msg.(type) {
+	case tasks.UpdatePRMsg:
+		url = update.PrUrl
+	case tasks.UpdateIssueMsg:
+		url = update.IssueUrl
+	default:
+		return nil
+	}
+	if url == "" {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	key := data.SectionKey{Type: msg.SectionType, Id: msg.SectionId}
+	for _, id := range data.GetRowStore().OtherSections(key, url) {
+		cmds = append(cmds, m.updateSection(id, msg.SectionType, msg.Msg))
+	}
+	return tea.Batch(cmds...)
+}
+
+// enrichAllPrSections shares a PR's enrichment with every section showing it.
+func (m *Model) enrichAllPrSections(enriched data.EnrichedPullRequestData) {
+	data.GetRowStore().SetEnrichedPullRequest(enriched)
+	for _, s := range m.prs {
+		if prSection, ok := s.(*prssection.Model); ok {
+			prSection.EnrichPR(enriched)
+		}
+	}
+}
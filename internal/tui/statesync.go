@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"errors"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	log "charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
+)
+
+// stateSyncInterval is how often the local state is synced with the
+// stateSync dir while the dashboard is running
+const stateSyncInterval = 5 * time.Minute
+
+type stateSyncMsg struct{}
+
+// stateSyncDir returns the configured stateSync dir with ~ expanded, or an
+// empty string if syncing is disabled.
+func stateSyncDir(cfg *config.Config) string {
+	if cfg == nil {
+		return ""
+	}
+	return common.ExpandHomeDir(cfg.StateSync.Dir)
+}
+
+// syncState syncs the done notifications and bookmarks with the stateSync
+// dir, if it's configured. Unless wait is set, it skips syncing when another
+// instance holds the lock instead of waiting for it.
+func syncState(cfg *config.Config, wait bool) {
+	dir := stateSyncDir(cfg)
+	if dir == "" {
+		return
+	}
+	sync := data.TrySyncState
+	if wait {
+		sync = data.SyncState
+	}
+	err := sync(dir)
+	if errors.Is(err, data.ErrStateLocked) && !wait {
+		log.Debug("Skipped syncing state, another instance is syncing", "dir", dir)
+		return
+	}
+	if err != nil {
+		log.Error("Failed syncing state", "dir", dir, "err", err)
+		return
+	}
+	log.Debug("Synced state", "dir", dir)
+}
+
+func (m *Model) scheduleStateSync() tea.Cmd {
+	if stateSyncDir(m.ctx.Config) == "" {
+		return nil
+	}
+	return tea.Tick(stateSyncInterval, func(time.Time) tea.Msg {
+		return stateSyncMsg{}
+	})
+}
+
+func (m *Model) onStateSync() tea.Cmd {
+	cfg := m.ctx.Config
+	return tea.Batch(func() tea.Msg {
+		syncState(cfg, true)
+		return nil
+	}, m.scheduleStateSync())
+}
+
+// quit syncs the state one last time before quitting, so the changes made
+// since the last sync aren't left behind. It doesn't wait for another
+// instance that's syncing, which would hold up quitting.
+func (m *Model) quit() tea.Cmd {
+	cfg := m.ctx.Config
+	return tea.Sequence(func() tea.Msg {
+		syncState(cfg, false)
+		return nil
+	}, tea.Quit)
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/git"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/alertssection"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/alertview"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/branch"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/branchsidebar"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/footer"
@@ -50,23 +52,32 @@ import (
 )
 
 type Model struct {
-	keys             *keys.KeyMap
-	sidebar          sidebar.Model
-	prView           prview.Model
-	issueSidebar     issueview.Model
-	branchSidebar    branchsidebar.Model
-	notificationView notificationview.Model
-	currSectionId    int
-	footer           footer.Model
-	repo             section.Section
-	prs              []section.Section
-	issues           []section.Section
-	notifications    []section.Section
-	tabs             tabs.Model
-	ctx              *context.ProgramContext
-	taskSpinner      spinner.Model
-	tasks            map[string]context.Task
-	positionOverride string // "" means no override, "right" or "bottom"
+	keys               *keys.KeyMap
+	sidebar            sidebar.Model
+	prView             prview.Model
+	issueSidebar       issueview.Model
+	branchSidebar      branchsidebar.Model
+	alertView          alertview.Model
+	notificationView   notificationview.Model
+	currSectionId      int
+	footer             footer.Model
+	repo               section.Section
+	prs                []section.Section
+	issues             []section.Section
+	notifications      []section.Section
+	alerts             []section.Section
+	tabs               tabs.Model
+	ctx                *context.ProgramContext
+	taskSpinner        spinner.Model
+	tasks              map[string]context.Task
+	refreshGenerations map[sectionRefreshKey]int
+	desktopNotifier    desktopNotifier
+	positionOverride   string // "" means no override, "right" or "bottom"
+	// cloneToConfirm is the clone of a missing repo a custom command needs,
+	// waiting for y/N, and clonesInProgress the commands to run once their
+	// repo has been cloned
+	cloneToConfirm   *repoClone
+	clonesInProgress map[string]repoClone
 }
 
 type Repositories struct {
@@ -77,10 +88,13 @@ type Repositories struct {
 func NewModel(location config.Location, repos Repositories) Model {
 	taskSpinner := spinner.Model{Spinner: spinner.Dot}
 	m := Model{
-		keys:        keys.Keys,
-		sidebar:     sidebar.NewModel(),
-		taskSpinner: taskSpinner,
-		tasks:       map[string]context.Task{},
+		keys:               keys.Keys,
+		sidebar:            sidebar.NewModel(),
+		taskSpinner:        taskSpinner,
+		tasks:              map[string]context.Task{},
+		refreshGenerations: map[sectionRefreshKey]int{},
+		desktopNotifier:    newDesktopNotifier(time.Now()),
+		clonesInProgress:   map[string]repoClone{},
 	}
 
 	version := "dev"
@@ -108,6 +122,7 @@ func NewModel(location config.Location, repos Repositories) Model {
 	m.prView = prview.NewModel(m.ctx)
 	m.issueSidebar = issueview.NewModel(m.ctx)
 	m.branchSidebar = branchsidebar.NewModel(m.ctx)
+	m.alertView = alertview.NewModel(m.ctx)
 	m.notificationView = notificationview.NewModel(m.ctx)
 	m.tabs = tabs.NewModel(m.ctx)
 
@@ -157,6 +172,10 @@ func (m *Model) initScreen() tea.Msg {
 		url = res
 	}
 
+	// Synced before the sections are fetched, so they hide the notifications
+	// marked done on other machines
+	syncState(&cfg, true)
+
 	err = keys.Rebind(
 		cfg.Keybindings.Universal,
 		cfg.Keybindings.Issues,
@@ -164,6 +183,7 @@ func (m *Model) initScreen() tea.Msg {
 		cfg.Keybindings.Branches,
 		cfg.Keybindings.Notifications,
 		cfg.Keybindings.Cmp,
+		cfg.Keybindings.Alerts,
 	)
 	if err != nil {
 		showError(err)
@@ -194,6 +214,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		log.Info("Key pressed", "key", msg.String())
 		m.ctx.Error = nil
 
+		if m.cloneToConfirm != nil {
+			clone := *m.cloneToConfirm
+			m.cloneToConfirm = nil
+			m.footer.SetLeftSection("")
+			if msg.String() == "y" || msg.String() == "Y" {
+				return m, m.cloneRepo(clone)
+			}
+			return m, nil
+		}
+
 		if currSection != nil && (currSection.IsSearchFocused() ||
 			currSection.IsPromptConfirmationFocused()) {
 			cmd = m.updateSection(currSection.GetId(), currSection.GetType(), msg)
@@ -206,14 +236,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		// The sidebar still scrolls through a commit's diff
+		if m.prView.IsCommitsFocused() {
+			m.prView, cmd = m.prView.Update(msg)
+			m.sidebar, sidebarCmd = m.sidebar.Update(msg)
+			m.syncSidebar()
+			return m, tea.Batch(cmd, sidebarCmd)
+		}
+
 		if m.issueSidebar.IsTextInputBoxFocused() {
 			m.issueSidebar, cmd, _ = m.issueSidebar.Update(msg)
 			m.syncSidebar()
 			return m, cmd
 		}
 
+		if m.ctx.View == config.RepoView && m.branchSidebar.IsFocused() {
+			m.branchSidebar, cmd = m.branchSidebar.Update(msg)
+			m.syncSidebar()
+			return m, cmd
+		}
+
 		if m.footer.ShowConfirmQuit && (msg.String() == "y" || msg.String() == "enter") {
-			return m, tea.Quit
+			return m, m.quit()
 		} else if m.footer.ShowConfirmQuit {
 			m.footer.SetShowConfirmQuit(false)
 			return m, nil
@@ -361,7 +405,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, m.keys.Quit):
 			if !m.ctx.Config.ConfirmQuit {
-				return m, tea.Quit
+				return m, m.quit()
 			}
 
 			m.footer.SetShowConfirmQuit(true)
@@ -378,20 +422,57 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, cmd
 
-			case key.Matches(msg, keys.BranchKeys.New):
+			case key.Matches(msg, keys.BranchKeys.PruneMerged):
 				if currSection != nil {
-					currSection.SetPromptConfirmationAction("new")
+					currSection.SetPromptConfirmationAction("prune_merged")
 					cmd = currSection.SetIsPromptConfirmationShown(true)
 				}
 				return m, cmd
 
-			case key.Matches(msg, keys.BranchKeys.CreatePr):
+			case key.Matches(msg, keys.BranchKeys.Restack):
 				if currSection != nil {
-					currSection.SetPromptConfirmationAction("create_pr")
+					currSection.SetPromptConfirmationAction("restack")
+					cmd = currSection.SetIsPromptConfirmationShown(true)
+				}
+				return m, cmd
+
+			case key.Matches(msg, keys.BranchKeys.Retarget):
+				if currSection != nil {
+					currSection.SetPromptConfirmationAction("retarget")
+					cmd = currSection.SetIsPromptConfirmationShown(true)
+				}
+				return m, cmd
+
+			case key.Matches(msg, keys.BranchKeys.PruneWorktrees):
+				if currSection != nil {
+					currSection.SetPromptConfirmationAction("prune_worktrees")
+					cmd = currSection.SetIsPromptConfirmationShown(true)
+				}
+				return m, cmd
+
+			case key.Matches(msg, keys.BranchKeys.New):
+				if currSection != nil {
+					currSection.SetPromptConfirmationAction("new")
 					cmd = currSection.SetIsPromptConfirmationShown(true)
 				}
 				return m, cmd
 
+			case key.Matches(msg, keys.BranchKeys.CreatePr):
+				return m, m.repo.(*reposection.Model).CreatePROptions()
+
+			case key.Matches(msg, keys.BranchKeys.FocusChanges):
+				m.openSidebar()
+				m.branchSidebar.Focus()
+				m.syncSidebar()
+				return m, nil
+
+			case key.Matches(msg, keys.BranchKeys.Commit),
+				key.Matches(msg, keys.BranchKeys.Amend):
+				m.openSidebar()
+				cmd = m.branchSidebar.StartCommit(key.Matches(msg, keys.BranchKeys.Amend))
+				m.syncSidebar()
+				return m, cmd
+
 			case key.Matches(msg, keys.BranchKeys.ViewPRs):
 				cmds = append(cmds, m.switchSelectedView())
 			}
@@ -467,6 +548,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.prView.SetSummaryViewMore()
 				m.syncSidebar()
 				return m, nil
+
+			case key.Matches(msg, keys.PRKeys.FocusCommits):
+				// The PR view only has the row once the sidebar shows it
+				m.openSidebar()
+				m.syncSidebar()
+				m.prView.FocusCommits()
+				m.syncSidebar()
+				return m, nil
 			}
 		case m.ctx.View == config.IssuesView:
 			switch {
@@ -507,6 +596,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, keys.IssueKeys.ViewPRs):
 				cmds = append(cmds, m.switchSelectedView())
 			}
+		case m.ctx.View == config.AlertsView:
+			switch {
+			case key.Matches(msg, m.keys.OpenGithub):
+				cmds = append(cmds, m.openBrowser())
+
+			case key.Matches(msg, keys.AlertKeys.Dismiss):
+				if alert, ok := currRowData.(*data.SecurityAlert); ok && alert.IsOpen() {
+					cmd = m.promptConfirmation(currSection, "dismiss_"+string(alert.Kind))
+				}
+				return m, cmd
+
+			case key.Matches(msg, keys.AlertKeys.Reopen):
+				// Fixed alerts are closed by GitHub and can't be reopened
+				if alert, ok := currRowData.(*data.SecurityAlert); ok && !alert.IsOpen() &&
+					alert.State != data.AlertStateFixed {
+					cmd = m.promptConfirmation(currSection, "reopen")
+				}
+				return m, cmd
+
+			case key.Matches(msg, keys.AlertKeys.ViewNext):
+				cmds = append(cmds, m.switchSelectedView())
+			}
 		case m.ctx.View == config.NotificationsView:
 			switch {
 			case key.Matches(msg, m.keys.OpenGithub):
@@ -518,10 +629,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, keys.NotificationKeys.View):
 				cmds = append(cmds, m.loadNotificationContent())
 
-			// Return from PR/Issue detail back to the default notification prompt
+			// Return from PR/Issue detail back to the default notification prompt,
+			// or from the subscription manager back to the notifications
 			case key.Matches(msg, keys.NotificationKeys.BackToNotification):
+				if m.isShowingSubscriptions() {
+					return m, tea.Batch(m.updateCurrentSection(msg), m.onViewedRowChanged())
+				}
 				return m, m.backToNotification()
 
+			// The subscription manager has no notification to show
+			case key.Matches(msg, keys.NotificationKeys.Subscriptions):
+				return m, tea.Batch(m.updateCurrentSection(msg), m.onViewedRowChanged())
+
 			// PR keybindings when viewing a PR notification
 			case m.notificationView.GetSubjectPR() != nil:
 				// Check for PR actions first (before updating prView)
@@ -546,7 +665,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 						case prview.PRActionDiff:
 							if pr := m.notificationView.GetSubjectPR(); pr != nil {
-								cmd = common.DiffPR(pr.GetNumber(), pr.GetRepoNameWithOwner(),
+								cmd = common.DiffPR(pr.GetNumber(), data.RepoArg(pr),
 									m.ctx.Config.GetFullScreenDiffPagerEnv())
 							}
 							return m, cmd
@@ -586,6 +705,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.prView.SetSummaryViewMore()
 							m.syncSidebar()
 							return m, nil
+
+						case prview.PRActionFocusCommits:
+							m.prView.FocusCommits()
+							m.syncSidebar()
+							return m, nil
 						}
 					}
 				}
@@ -700,12 +824,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		cmds = append(cmds, fetchSectionsCmds, m.tabs.Init(), fetchUser,
-			m.doRefreshAtInterval(), m.doUpdateFooterAtInterval())
+			m.doUpdateFooterAtInterval())
+		if m.ctx.Config.DesktopNotifications.Enabled {
+			cmds = append(cmds, m.pollDesktopNotifications())
+		}
+		cmds = append(cmds, m.scheduleStateSync())
+		if len(m.ctx.Config.RepoDiscovery.Roots) > 0 {
+			cmds = append(cmds, m.discoverRepos())
+		}
 
-	case intervalRefresh:
-		newSections, fetchSectionsCmds := m.fetchAllViewSections()
-		m.setCurrentViewSections(newSections)
-		cmds = append(cmds, fetchSectionsCmds, m.doRefreshAtInterval())
+	case sectionRefreshMsg:
+		cmds = append(cmds, m.onSectionRefresh(msg))
+
+	case stateSyncMsg:
+		cmds = append(cmds, m.onStateSync())
+
+	case desktopNotificationsPollMsg:
+		cmds = append(cmds, m.pollDesktopNotifications())
+
+	case desktopNotificationsFetchedMsg:
+		cmds = append(cmds, m.onDesktopNotificationsFetched(msg))
 
 	case userFetchedMsg:
 		m.ctx.User = msg.user
@@ -732,6 +870,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			scmd := m.updateSection(msg.SectionId, msg.SectionType, msg.Msg)
 			cmds = append(cmds, scmd)
 
+			if cloned, ok := msg.Msg.(tasks.RepoClonedMsg); ok {
+				cmds = append(cmds, m.onRepoCloned(cloned))
+			}
+
+			refreshCmd := m.onSectionFetchFinished(
+				msg.SectionType, msg.SectionId, msg.TaskId, msg.Err)
+			cmds = append(cmds, refreshCmd, m.propagateRowUpdate(msg))
+
 			syncCmd := m.syncSidebar()
 			cmds = append(cmds, syncCmd)
 		}
@@ -739,13 +885,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case prview.EnrichedPrMsg:
 		if msg.Err == nil {
 			m.prView.SetEnrichedPR(msg.Data)
-			m.prs[msg.Id].(*prssection.Model).EnrichPR(msg.Data)
+			m.enrichAllPrSections(msg.Data)
 			syncCmd := m.syncSidebar()
 			cmds = append(cmds, syncCmd)
 		} else {
 			log.Error("failed enriching pr", "err", msg.Err)
 		}
 
+	case prview.StackFetchedMsg:
+		m.prView, cmd = m.prView.Update(msg)
+		cmds = append(cmds, cmd, m.syncSidebar())
+
+	case prview.CommitDiffFetchedMsg:
+		m.prView, cmd = m.prView.Update(msg)
+		cmds = append(cmds, cmd, m.syncSidebar())
+
+	case reposection.CreatePROptionsMsg:
+		if msg.Err != nil {
+			m.ctx.Error = msg.Err
+			return m, nil
+		}
+		m.openSidebar()
+		cmd = m.branchSidebar.StartCreatePR(msg.Options)
+		m.syncSidebar()
+		return m, cmd
+
+	case alertview.FixPRFetchedMsg:
+		m.alertView, cmd = m.alertView.Update(msg)
+		cmds = append(cmds, cmd, m.syncSidebar())
+
 	case notificationPRFetchedMsg:
 		if msg.Err == nil {
 			// Convert enriched PR to prrow.Data for display
@@ -755,6 +923,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				Enriched:   msg.PR,
 				IsEnriched: true,
 			}, msg.NotificationId)
+			m.notificationView.SetTimeline(msg.LastReadAt, msg.StateEvents)
 			keys.SetNotificationSubject(keys.NotificationSubjectPR)
 			// Update sidebar with PR view
 			width := m.sidebar.GetSidebarContentWidth()
@@ -762,18 +931,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.prView.SetRow(m.notificationView.GetSubjectPR())
 			m.prView.SetWidth(width)
 			m.prView.SetEnrichedPR(msg.PR)
-			// Switch to Activity tab and scroll to bottom if there's a latest comment
-			// (indicates there's new activity to show)
+			// Switch to Activity tab if there's a latest comment (indicates there's
+			// new activity to show), otherwise show the Overview tab. The timeline
+			// above it shows what's new either way.
 			if msg.LatestCommentUrl != "" {
 				m.prView.GoToActivityTab()
-				m.sidebar.SetContent(m.prView.View())
-				m.sidebar.ScrollToBottom()
 			} else {
-				// For notifications without comments (new PRs, state changes, etc.)
-				// show the Overview tab without scrolling
 				m.prView.GoToFirstTab()
-				m.sidebar.SetContent(m.prView.View())
 			}
+			m.sidebar.SetContent(m.notificationSubjectView(m.prView.View()))
+			m.sidebar.ScrollToTop()
 			m.markNotificationAsRead(msg.NotificationId)
 		} else {
 			log.Error("failed fetching notification PR", "err", msg.Err)
@@ -782,17 +949,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case notificationIssueFetchedMsg:
 		if msg.Err == nil {
 			m.notificationView.SetSubjectIssue(&msg.Issue, msg.NotificationId)
+			m.notificationView.SetTimeline(msg.LastReadAt, msg.StateEvents)
 			keys.SetNotificationSubject(keys.NotificationSubjectIssue)
-			// Update sidebar with Issue view
+			// Update sidebar with Issue view, below the timeline of what's new
 			width := m.sidebar.GetSidebarContentWidth()
 			m.issueSidebar.SetSectionId(0)
 			m.issueSidebar.SetRow(m.notificationView.GetSubjectIssue())
 			m.issueSidebar.SetWidth(width)
-			m.sidebar.SetContent(m.issueSidebar.View())
-			// Scroll to bottom if there's a latest comment (indicates new activity)
-			if msg.LatestCommentUrl != "" {
-				m.sidebar.ScrollToBottom()
-			}
+			m.sidebar.SetContent(m.notificationSubjectView(m.issueSidebar.View()))
+			m.sidebar.ScrollToTop()
 			m.markNotificationAsRead(msg.NotificationId)
 		} else {
 			log.Error("failed fetching notification Issue", "err", msg.Err)
@@ -801,6 +966,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case notificationssection.UpdateNotificationReadStateMsg:
 		m.updateNotificationSections(msg)
 
+	case notificationssection.UpdateNotificationMsg:
+		// Sent by notification rules, which apply to every section
+		m.updateNotificationSections(msg)
+
+	case notificationssection.NotificationSnoozedMsg:
+		m.updateNotificationSections(msg)
+
+	case notificationssection.NotificationsMutedMsg:
+		m.updateNotificationSections(msg)
+
 	case notificationssection.UpdateNotificationCommentsMsg:
 		cmds = append(cmds, m.updateNotificationSections(msg))
 
@@ -876,6 +1051,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var bsCmd tea.Cmd
 	m.branchSidebar, bsCmd = m.branchSidebar.Update(msg)
 	cmds = append(cmds, bsCmd)
+	// The create PR form's suggestions load in the background
+	if m.ctx.View == config.RepoView && m.branchSidebar.IsCreatingPR() {
+		m.syncSidebar()
+	}
 
 	m.sidebar, sidebarCmd = m.sidebar.Update(msg)
 
@@ -889,7 +1068,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.syncSidebar()
 	}
 
-	if currSection != nil {
+	if m.cloneToConfirm != nil {
+		m.footer.SetLeftSection(m.renderClonePrompt())
+	} else if currSection != nil {
 		if currSection.IsPromptConfirmationFocused() {
 			m.footer.SetLeftSection(currSection.GetPromptConfirmation())
 		}
@@ -1014,6 +1195,8 @@ type notificationPRFetchedMsg struct {
 	NotificationId   string
 	PR               data.EnrichedPullRequestData
 	LatestCommentUrl string
+	LastReadAt       *time.Time
+	StateEvents      []data.StateEvent
 	Err              error
 }
 
@@ -1021,6 +1204,8 @@ type notificationIssueFetchedMsg struct {
 	NotificationId   string
 	Issue            data.IssueData
 	LatestCommentUrl string
+	LastReadAt       *time.Time
+	StateEvents      []data.StateEvent
 	Err              error
 }
 
@@ -1054,10 +1239,11 @@ func (m *Model) onViewedRowChanged() tea.Cmd {
 	m.prView.GoToFirstTab()
 	sidebarCmd := m.syncSidebar()
 	enrichCmd := m.prView.EnrichCurrRow()
+	stackCmd := m.prView.FetchStack()
 	m.sidebar.ScrollToTop()
 	m.notificationView.ResetSubject()
 	keys.SetNotificationSubject(keys.NotificationSubjectNone)
-	return tea.Batch(sidebarCmd, enrichCmd)
+	return tea.Batch(sidebarCmd, enrichCmd, stackCmd)
 }
 
 func (m *Model) onWindowSizeChanged(msg tea.WindowSizeMsg) {
@@ -1075,6 +1261,14 @@ func (m *Model) onWindowSizeChanged(msg tea.WindowSizeMsg) {
 	}
 }
 
+// openSidebar opens the sidebar, for actions that take place in it
+func (m *Model) openSidebar() {
+	if !m.sidebar.IsOpen {
+		m.sidebar.IsOpen = true
+		m.syncMainContentDimensions()
+	}
+}
+
 func (m *Model) syncProgramContext() {
 	for _, section := range m.getCurrentViewSections() {
 		section.UpdateProgramContext(m.ctx)
@@ -1085,6 +1279,7 @@ func (m *Model) syncProgramContext() {
 	m.prView.UpdateProgramContext(m.ctx)
 	m.issueSidebar.UpdateProgramContext(m.ctx)
 	m.branchSidebar.UpdateProgramContext(m.ctx)
+	m.alertView.UpdateProgramContext(m.ctx)
 	m.notificationView.UpdateProgramContext(m.ctx)
 }
 
@@ -1105,6 +1300,10 @@ func (m *Model) updateSection(id int, sType string, msg tea.Msg) (cmd tea.Cmd) {
 	case issuessection.SectionType:
 		updatedSection, cmd = m.issues[id].Update(msg)
 		m.issues[id] = updatedSection
+	case alertssection.SectionType:
+		if id < len(m.alerts) && m.alerts[id] != nil {
+			m.alerts[id], cmd = m.alerts[id].Update(msg)
+		}
 	}
 
 	currSection := m.getCurrSection()
@@ -1222,6 +1421,13 @@ func (m *Model) openSidebarForInput(setFunc func(bool) tea.Cmd) tea.Cmd {
 	return cmd
 }
 
+// isShowingSubscriptions returns true if the current section shows the
+// subscription manager instead of notifications
+func (m *Model) isShowingSubscriptions() bool {
+	s, ok := m.getCurrSection().(*notificationssection.Model)
+	return ok && s.IsShowingSubscriptions()
+}
+
 func (m *Model) backToNotification() tea.Cmd {
 	if m.notificationView.GetSubjectPR() == nil && m.notificationView.GetSubjectIssue() == nil {
 		return nil
@@ -1259,6 +1465,10 @@ func (m *Model) syncSidebar() tea.Cmd {
 	case branch.BranchData:
 		cmd = m.branchSidebar.SetRow(&row)
 		m.sidebar.SetContent(m.branchSidebar.View())
+		// Scroll to the top, where the changes and the commit message input are
+		if m.branchSidebar.IsFocused() {
+			m.sidebar.ScrollToTop()
+		}
 	case *prrow.Data:
 		m.prView.SetSectionId(m.currSectionId)
 		m.prView.SetRow(row)
@@ -1277,17 +1487,22 @@ func (m *Model) syncSidebar() tea.Cmd {
 		if m.issueSidebar.IsTextInputBoxFocused() {
 			m.sidebar.ScrollToBottom()
 		}
+	case *data.SecurityAlert:
+		cmd = m.alertView.SetRow(row)
+		m.alertView.SetWidth(width)
+		m.sidebar.SetContent(m.alertView.View())
 	case *notificationrow.Data:
 		notifId := row.GetId()
 
 		// Check if we already have cached data for this notification (user already viewed it)
 		if m.notificationView.GetSubjectId() == notifId {
 			// Use cached data
+			m.notificationView.SetWidth(width)
 			if m.notificationView.GetSubjectPR() != nil {
 				m.prView.SetSectionId(0)
 				m.prView.SetRow(m.notificationView.GetSubjectPR())
 				m.prView.SetWidth(width)
-				m.sidebar.SetContent(m.prView.View())
+				m.sidebar.SetContent(m.notificationSubjectView(m.prView.View()))
 				// Scroll to bottom if in input mode to keep inputbox visible
 				if m.prView.IsTextInputBoxFocused() {
 					m.sidebar.ScrollToBottom()
@@ -1296,7 +1511,7 @@ func (m *Model) syncSidebar() tea.Cmd {
 				m.issueSidebar.SetSectionId(0)
 				m.issueSidebar.SetRow(m.notificationView.GetSubjectIssue())
 				m.issueSidebar.SetWidth(width)
-				m.sidebar.SetContent(m.issueSidebar.View())
+				m.sidebar.SetContent(m.notificationSubjectView(m.issueSidebar.View()))
 				// Scroll to bottom if in input mode to keep inputbox visible
 				if m.issueSidebar.IsTextInputBoxFocused() {
 					m.sidebar.ScrollToBottom()
@@ -1417,6 +1632,22 @@ func (m *Model) renderNotificationPrompt(row *notificationrow.Data) string {
 	return content.String()
 }
 
+// notificationSubjectView puts the timeline of what changed since the
+// notification was last read above the view of its PR or issue.
+func (m *Model) notificationSubjectView(subjectView string) string {
+	return lipgloss.JoinVertical(lipgloss.Left, m.notificationView.ViewTimeline(), subjectView)
+}
+
+// fetchStateEvents fetches the state changes of a notification's subject for
+// its timeline. The timeline still shows comments and commits without them.
+func fetchStateEvents(subjectUrl string) []data.StateEvent {
+	events, err := data.FetchStateEvents(subjectUrl)
+	if err != nil {
+		log.Debug("Failed to fetch state events", "url", subjectUrl, "err", err)
+	}
+	return events
+}
+
 // loadNotificationContent fetches and displays notification content, marking it as read
 func (m *Model) loadNotificationContent() tea.Cmd {
 	currRowData := m.getCurrRowData()
@@ -1426,9 +1657,11 @@ func (m *Model) loadNotificationContent() tea.Cmd {
 	}
 
 	notifId := row.GetId()
+	notifHost := data.RowHost(row.Notification)
 	subjectType := row.GetSubjectType()
 	subjectUrl := row.GetUrl()
 	latestCommentUrl := row.GetLatestCommentUrl()
+	lastReadAt := row.Notification.LastReadAt
 
 	// Show loading indicator
 	width := m.sidebar.GetSidebarContentWidth()
@@ -1440,7 +1673,7 @@ func (m *Model) loadNotificationContent() tea.Cmd {
 	case "PullRequest":
 		return tea.Batch(
 			func() tea.Msg {
-				_ = data.MarkNotificationRead(notifId)
+				_ = data.MarkNotificationRead(notifHost, notifId)
 				return notificationssection.UpdateNotificationReadStateMsg{
 					Id:     notifId,
 					Unread: false,
@@ -1452,6 +1685,8 @@ func (m *Model) loadNotificationContent() tea.Cmd {
 					NotificationId:   notifId,
 					PR:               pr,
 					LatestCommentUrl: latestCommentUrl,
+					LastReadAt:       lastReadAt,
+					StateEvents:      fetchStateEvents(subjectUrl),
 					Err:              err,
 				}
 			},
@@ -1459,7 +1694,7 @@ func (m *Model) loadNotificationContent() tea.Cmd {
 	case "Issue":
 		return tea.Batch(
 			func() tea.Msg {
-				_ = data.MarkNotificationRead(notifId)
+				_ = data.MarkNotificationRead(notifHost, notifId)
 				return notificationssection.UpdateNotificationReadStateMsg{
 					Id:     notifId,
 					Unread: false,
@@ -1471,6 +1706,8 @@ func (m *Model) loadNotificationContent() tea.Cmd {
 					NotificationId:   notifId,
 					Issue:            issue,
 					LatestCommentUrl: latestCommentUrl,
+					LastReadAt:       lastReadAt,
+					StateEvents:      fetchStateEvents(subjectUrl),
 					Err:              err,
 				}
 			},
@@ -1480,7 +1717,7 @@ func (m *Model) loadNotificationContent() tea.Cmd {
 		// since we can't show rich content for these types
 		return tea.Batch(
 			func() tea.Msg {
-				_ = data.MarkNotificationRead(notifId)
+				_ = data.MarkNotificationRead(notifHost, notifId)
 				return notificationssection.UpdateNotificationReadStateMsg{
 					Id:     notifId,
 					Unread: false,
@@ -1498,22 +1735,29 @@ func (m *Model) fetchAllViewSections() ([]section.Section, tea.Cmd) {
 	switch m.ctx.View {
 	case config.RepoView:
 		var cmd tea.Cmd
+		if repo, ok := m.repo.(*reposection.Model); ok {
+			repo.StopWatchingRefs()
+		}
 		s, cmd := reposection.FetchAllBranches(m.ctx)
 		cmds = append(cmds, cmd)
 		m.repo = &s
 		return nil, tea.Batch(cmds...)
 	case config.NotificationsView:
 		s, notifCmd := notificationssection.FetchAllSections(m.ctx, m.notifications)
-		cmds = append(cmds, notifCmd)
+		cmds = append(cmds, notifCmd, m.scheduleSectionRefreshes(m.ctx.View, s))
 		m.notifications = s
 		return s, tea.Batch(cmds...)
+	case config.AlertsView:
+		s, alertcmds := alertssection.FetchAllSections(m.ctx)
+		cmds = append(cmds, alertcmds, m.scheduleSectionRefreshes(m.ctx.View, s))
+		return s, tea.Batch(cmds...)
 	case config.PRsView:
 		s, prcmds := prssection.FetchAllSections(m.ctx, m.prs)
-		cmds = append(cmds, prcmds)
+		cmds = append(cmds, prcmds, m.scheduleSectionRefreshes(m.ctx.View, s))
 		return s, tea.Batch(cmds...)
 	default:
 		s, issuecmds := issuessection.FetchAllSections(m.ctx)
-		cmds = append(cmds, issuecmds)
+		cmds = append(cmds, issuecmds, m.scheduleSectionRefreshes(m.ctx.View, s))
 		return s, tea.Batch(cmds...)
 	}
 }
@@ -1530,6 +1774,8 @@ func (m *Model) getCurrentViewSections() []section.Section {
 			return []section.Section{}
 		}
 		return m.notifications
+	case config.AlertsView:
+		return m.alerts
 	case config.PRsView:
 		return m.prs
 	default:
@@ -1604,6 +1850,21 @@ func (m *Model) setCurrentViewSections(newSections []section.Section) {
 		}
 		m.prs = append(s, newSections...)
 		newSections = m.prs
+	} else if m.ctx.View == config.AlertsView {
+		if missingSearchSection {
+			search := alertssection.NewModel(
+				0,
+				m.ctx,
+				config.AlertsSectionConfig{
+					Title:   "",
+					Filters: "state:open",
+				},
+				time.Now(),
+			)
+			s = append(s, &search)
+		}
+		m.alerts = append(s, newSections...)
+		newSections = m.alerts
 	} else {
 		if missingSearchSection {
 			search := issuessection.NewModel(
@@ -1634,7 +1895,9 @@ func (m *Model) switchSelectedView() tea.Cmd {
 		m.notificationView.ClearSubject()
 	}
 
-	// View cycle: Notifications → PRs → Issues (→ Repo if enabled) → Notifications
+	// View cycle: Notifications → PRs → Issues (→ Repo if enabled)
+	// (→ Alerts if configured) → Notifications
+	prevView := m.ctx.View
 	if repoFF {
 		switch m.ctx.View {
 		case config.NotificationsView:
@@ -1643,7 +1906,7 @@ func (m *Model) switchSelectedView() tea.Cmd {
 			m.ctx.View = config.IssuesView
 		case config.IssuesView:
 			m.ctx.View = config.RepoView
-		case config.RepoView:
+		case config.RepoView, config.AlertsView:
 			m.ctx.View = config.NotificationsView
 		}
 	} else {
@@ -1656,6 +1919,10 @@ func (m *Model) switchSelectedView() tea.Cmd {
 			m.ctx.View = config.NotificationsView
 		}
 	}
+	if m.ctx.View == config.NotificationsView && prevView != config.AlertsView &&
+		len(m.ctx.Config.AlertsSections) > 0 {
+		m.ctx.View = config.AlertsView
+	}
 
 	m.syncMainContentDimensions()
 	m.setCurrSectionId(m.getCurrentViewDefaultSection())
@@ -1708,6 +1975,14 @@ func (m *Model) isUserDefinedKeybinding(msg tea.KeyMsg) bool {
 		}
 	}
 
+	if m.ctx.View == config.AlertsView {
+		for _, keybinding := range m.ctx.Config.Keybindings.Alerts {
+			if keybinding.Builtin == "" && keybinding.Key == msg.String() {
+				return true
+			}
+		}
+	}
+
 	if m.ctx.View == config.NotificationsView {
 		for _, keybinding := range m.ctx.Config.Keybindings.Notifications {
 			if keybinding.Builtin == "" && keybinding.Key == msg.String() {
@@ -1817,21 +2092,6 @@ func fetchUser() tea.Msg {
 	}
 }
 
-type intervalRefresh time.Time
-
-func (m *Model) doRefreshAtInterval() tea.Cmd {
-	if m.ctx.Config.Defaults.RefetchIntervalMinutes == 0 {
-		return nil
-	}
-
-	return tea.Tick(
-		time.Minute*time.Duration(m.ctx.Config.Defaults.RefetchIntervalMinutes),
-		func(t time.Time) tea.Msg {
-			return intervalRefresh(t)
-		},
-	)
-}
-
 type updateFooterMsg struct{}
 
 func (m *Model) doUpdateFooterAtInterval() tea.Cmd {
@@ -2261,3 +2261,58 @@ func TestNotificationCommandTemplateVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestDesktopNotifierNewNotifications(t *testing.T) {
+	startedAt := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	notification := func(id, reason string, updatedAt time.Time) data.NotificationData {
+		return data.NotificationData{
+			Id:         id,
+			Unread:     true,
+			Reason:     reason,
+			UpdatedAt:  updatedAt,
+			Repository: data.NotificationRepository{FullName: "o/r"},
+		}
+	}
+	filters := "reason:review-requested,mention"
+	n := newDesktopNotifier(startedAt)
+
+	ids := func(notifications []data.NotificationData) []string {
+		var ids []string
+		for _, n := range notifications {
+			ids = append(ids, n.Id)
+		}
+		return ids
+	}
+	read := notification("4", data.ReasonMention, startedAt.Add(time.Minute))
+	read.Unread = false
+	notifications := []data.NotificationData{
+		notification("1", data.ReasonReviewRequested, startedAt.Add(time.Minute)),
+		notification("2", data.ReasonMention, startedAt.Add(-time.Minute)),
+		notification("3", data.ReasonSubscribed, startedAt.Add(time.Minute)),
+		read,
+	}
+	require.Equal(t, []string{"1"}, ids(n.newNotifications(filters, notifications)),
+		"only unread notifications matching the filters that arrived since the start are sent")
+	require.Empty(t, n.newNotifications(filters, notifications), "notifications are sent once")
+
+	notifications[0].UpdatedAt = startedAt.Add(2 * time.Minute)
+	require.Equal(t, []string{"1"}, ids(n.newNotifications(filters, notifications)),
+		"new activity on a sent notification is sent again")
+}
+
+func TestOSCNotification(t *testing.T) {
+	t.Setenv("TMUX", "")
+	require.Equal(t, "\x1b]777;notify;Review requested;o/r#1: Fix\x07",
+		oscNotification(config.DesktopNotificationOSC777, "Review requested", "o/r#1: Fix"))
+	require.Equal(t, "\x1b]9;Review requested: o/r#1: Fix it\x07",
+		oscNotification(config.DesktopNotificationOSC9, "Review requested", "o/r#1: Fix\x07it"),
+		"control characters can't end the sequence early")
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	require.Equal(t, "\x1bPtmux;\x1b\x1b]9;a: b\x07\x1b\\",
+		oscNotification(config.DesktopNotificationOSC9, "a", "b"))
+}
+
+func TestShellQuote(t *testing.T) {
+	require.Equal(t, `'it'\''s $(reboot)'`, shellQuote("it's $(reboot)"))
+}